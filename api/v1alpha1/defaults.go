@@ -0,0 +1,59 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// DefaultDefinition is the OS and resource definition applied when none is set
+	DefaultDefinition = "a"
+	// DefaultCPUCount is the number of CPU cores requested when none is set
+	DefaultCPUCount uint32 = 2
+	// DefaultMemory is the memory requested when none is set
+	DefaultMemory = "8Gi"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+func init() {
+	localSchemeBuilder.Register(addDefaultingFuncs)
+}
+
+// SetDefaults_VirtualServer applies the defaults declared by the kubebuilder markers of the CRD.
+// Zero values are treated as unset, so a VirtualServer built in Go matches the object the apiserver stores.
+// Network.Public is left untouched as an explicit false cannot be distinguished from an unset value;
+// decoders should set Public to true before decoding a document into the VirtualServer, as vsctl does.
+func SetDefaults_VirtualServer(vs *VirtualServer) {
+	if vs.Spec.OS.Definition == "" {
+		vs.Spec.OS.Definition = DefaultDefinition
+	}
+	if vs.Spec.Resources.Definition == "" {
+		vs.Spec.Resources.Definition = DefaultDefinition
+	}
+	if vs.Spec.Resources.CPU.Count == 0 {
+		vs.Spec.Resources.CPU.Count = DefaultCPUCount
+	}
+	if vs.Spec.Resources.Memory.IsZero() {
+		vs.Spec.Resources.Memory = resource.MustParse(DefaultMemory)
+	}
+	if vs.Spec.Storage.Root.VolumeMode == "" {
+		vs.Spec.Storage.Root.VolumeMode = corev1.PersistentVolumeBlock
+	}
+	if vs.Spec.Storage.Root.AccessMode == "" {
+		vs.Spec.Storage.Root.AccessMode = corev1.ReadWriteOnce
+	}
+	for _, disk := range vs.Spec.Storage.AdditionalDisks {
+		if dv := disk.DataVolumeTemplate; dv != nil {
+			if dv.VolumeMode == "" {
+				dv.VolumeMode = corev1.PersistentVolumeBlock
+			}
+			if dv.AccessMode == "" {
+				dv.AccessMode = corev1.ReadWriteOnce
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	vsv1alpha "github.com/coreweave/virtual-server/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestSchemeDefaults(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := vsv1alpha.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	vs.SetOS(vsv1alpha.VirtualServerOSTypeLinux)
+	list := &vsv1alpha.VirtualServerList{Items: []vsv1alpha.VirtualServer{*vs.DeepCopy()}}
+	scheme.Default(vs)
+	scheme.Default(list)
+
+	for _, got := range []*vsv1alpha.VirtualServer{vs, &list.Items[0]} {
+		if got.Spec.OS.Definition != vsv1alpha.DefaultDefinition {
+			t.Errorf("expected os definition %q, got %q", vsv1alpha.DefaultDefinition, got.Spec.OS.Definition)
+		}
+		if got.Spec.Resources.Definition != vsv1alpha.DefaultDefinition {
+			t.Errorf("expected resource definition %q, got %q", vsv1alpha.DefaultDefinition, got.Spec.Resources.Definition)
+		}
+		if got.Spec.Resources.CPU.Count != vsv1alpha.DefaultCPUCount {
+			t.Errorf("expected cpu count %d, got %d", vsv1alpha.DefaultCPUCount, got.Spec.Resources.CPU.Count)
+		}
+		if !got.Spec.Resources.Memory.Equal(resource.MustParse(vsv1alpha.DefaultMemory)) {
+			t.Errorf("expected memory %s, got %s", vsv1alpha.DefaultMemory, got.Spec.Resources.Memory.String())
+		}
+		if got.Spec.Storage.Root.VolumeMode != corev1.PersistentVolumeBlock {
+			t.Errorf("expected volume mode %s, got %s", corev1.PersistentVolumeBlock, got.Spec.Storage.Root.VolumeMode)
+		}
+		if got.Spec.Storage.Root.AccessMode != corev1.ReadWriteOnce {
+			t.Errorf("expected access mode %s, got %s", corev1.ReadWriteOnce, got.Spec.Storage.Root.AccessMode)
+		}
+	}
+}
+
+func TestSchemeDefaultsPreserveValues(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := vsv1alpha.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	vs.SetCPUCount(8)
+	vs.SetMemory("32Gi")
+	vs.Spec.Storage.Root.VolumeMode = corev1.PersistentVolumeFilesystem
+	scheme.Default(vs)
+
+	if vs.Spec.Resources.CPU.Count != 8 {
+		t.Errorf("expected cpu count 8, got %d", vs.Spec.Resources.CPU.Count)
+	}
+	if !vs.Spec.Resources.Memory.Equal(resource.MustParse("32Gi")) {
+		t.Errorf("expected memory 32Gi, got %s", vs.Spec.Resources.Memory.String())
+	}
+	if vs.Spec.Storage.Root.VolumeMode != corev1.PersistentVolumeFilesystem {
+		t.Errorf("expected volume mode %s, got %s", corev1.PersistentVolumeFilesystem, vs.Spec.Storage.Root.VolumeMode)
+	}
+}
@@ -0,0 +1,4 @@
+// +k8s:conversion-gen=github.com/coreweave/virtual-server/api/v1beta1
+// +groupName=virtualservers.coreweave.com
+
+package v1alpha1
@@ -28,9 +28,20 @@ var (
 	// GroupVersion is group version used to register these objects
 	GroupVersion = schema.GroupVersion{Group: "virtualservers.coreweave.com", Version: "v1alpha1"}
 
+	// SchemeGroupVersion is an alias of GroupVersion, as expected by the generated clientset
+	SchemeGroupVersion = GroupVersion
+
 	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
 	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
 
 	// AddToScheme adds the types in this group-version to the given scheme.
 	AddToScheme = SchemeBuilder.AddToScheme
+
+	// localSchemeBuilder is used by the generated defaulting and conversion functions to register with the scheme
+	localSchemeBuilder = &SchemeBuilder.SchemeBuilder
 )
+
+// Resource takes an unqualified resource and returns a Group qualified GroupResource
+func Resource(resource string) schema.GroupResource {
+	return GroupVersion.WithResource(resource).GroupResource()
+}
@@ -0,0 +1,298 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	statusTrue    = []metav1.ConditionStatus{metav1.ConditionTrue}
+	statusFalse   = []metav1.ConditionStatus{metav1.ConditionFalse}
+	statusUnknown = []metav1.ConditionStatus{metav1.ConditionUnknown}
+	// statusNotTrue is used by reasons that may either be in progress or have stalled
+	statusNotTrue = []metav1.ConditionStatus{metav1.ConditionUnknown, metav1.ConditionFalse}
+)
+
+// conditionReasons lists the reasons that are valid for each condition type,
+// along with the statuses a condition may have with that reason
+var conditionReasons = map[VirtualServerConditionType]map[VirtualServerConditionReason][]metav1.ConditionStatus{
+	VSConditionTypeReady: {
+		VSConditionReasonInitializing:         statusUnknown,
+		VSConditionReasonPending:              statusUnknown,
+		VSConditionReasonReady:                statusTrue,
+		VSConditionReasonStopped:              statusFalse,
+		VSConditionReasonVMIShutdown:          statusFalse,
+		VSConditionReasonWaitingForServices:   statusNotTrue,
+		VSConditionReasonWaitingForSecrets:    statusNotTrue,
+		VSConditionReasonResizeInProgress:     statusNotTrue,
+		VSConditionReasonVMNameTaken:          statusFalse,
+		VSConditionReasonDefinitionDeprecated: statusFalse,
+		VSConditionReasonFailed:               statusFalse,
+		VSConditionReasonTerminating:          statusNotTrue,
+	},
+	VSConditionTypeStarted: {
+		VSConditionReasonInitializing: statusUnknown,
+		VSConditionReasonPending:      statusUnknown,
+		VSConditionReasonStarted:      statusTrue,
+		VSConditionReasonStopped:      statusFalse,
+		VSConditionReasonVMIShutdown:  statusFalse,
+		VSConditionReasonFailed:       statusFalse,
+		VSConditionReasonTerminating:  statusNotTrue,
+	},
+	VSConditionTypeServicesReady: {
+		VSConditionReasonInitializing:       statusUnknown,
+		VSConditionReasonPending:            statusUnknown,
+		VSConditionReasonServicesCreated:    statusNotTrue,
+		VSConditionReasonWaitingForServices: statusNotTrue,
+		VSConditionReasonServicesReady:      statusTrue,
+		VSConditionReasonFailed:             statusFalse,
+		VSConditionReasonTerminating:        statusNotTrue,
+	},
+	VSConditionTypeVMReady: {
+		VSConditionReasonInitializing:         statusUnknown,
+		VSConditionReasonPending:              statusUnknown,
+		VSConditionReasonVMReady:              statusTrue,
+		VSConditionReasonStopped:              statusFalse,
+		VSConditionReasonVMIShutdown:          statusFalse,
+		VSConditionReasonResizeInProgress:     statusNotTrue,
+		VSConditionReasonVMNameTaken:          statusFalse,
+		VSConditionReasonDefinitionDeprecated: statusFalse,
+		VSConditionReasonFailed:               statusFalse,
+		VSConditionReasonTerminating:          statusNotTrue,
+	},
+	VSConditionTypeSecretReady: {
+		VSConditionReasonInitializing:      statusUnknown,
+		VSConditionReasonPending:           statusUnknown,
+		VSConditionReasonSecretCreated:     statusTrue,
+		VSConditionReasonWaitingForSecrets: statusNotTrue,
+		VSConditionReasonFailed:            statusFalse,
+		VSConditionReasonTerminating:       statusNotTrue,
+	},
+}
+
+// readyDependencies are the conditions the Ready condition is derived from, in the order they are checked
+var readyDependencies = []VirtualServerConditionType{
+	VSConditionTypeSecretReady,
+	VSConditionTypeServicesReady,
+	VSConditionTypeVMReady,
+	VSConditionTypeStarted,
+}
+
+// waitingReasons are the reasons of the Ready condition while a dependency is not ready,
+// used when the reason of the dependency itself is not valid for the Ready condition
+var waitingReasons = map[VirtualServerConditionType]VirtualServerConditionReason{
+	VSConditionTypeSecretReady:   VSConditionReasonWaitingForSecrets,
+	VSConditionTypeServicesReady: VSConditionReasonWaitingForServices,
+	VSConditionTypeVMReady:       VSConditionReasonPending,
+	VSConditionTypeStarted:       VSConditionReasonPending,
+}
+
+// IsFailureReason returns true if the reason indicates that the VirtualServer cannot become ready without intervention
+func IsFailureReason(reason VirtualServerConditionReason) bool {
+	switch reason {
+	case VSConditionReasonFailed, VSConditionReasonVMNameTaken, VSConditionReasonDefinitionDeprecated:
+		return true
+	}
+	return false
+}
+
+// ValidConditionReasons returns the reasons that are valid for the condition type, sorted by name
+func ValidConditionReasons(conditionType VirtualServerConditionType) []VirtualServerConditionReason {
+	reasons := make([]VirtualServerConditionReason, 0, len(conditionReasons[conditionType]))
+	for reason := range conditionReasons[conditionType] {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool { return reasons[i] < reasons[j] })
+	return reasons
+}
+
+// ValidateConditionTransition returns an error if the condition cannot replace the current condition of the same type.
+// current is nil if the condition is not yet set.
+// Conditions cannot return to Initializing once they have left it, and cannot leave Terminating.
+func ValidateConditionTransition(current *metav1.Condition, condition metav1.Condition) error {
+	conditionType := VirtualServerConditionType(condition.Type)
+	reason := VirtualServerConditionReason(condition.Reason)
+	reasons, ok := conditionReasons[conditionType]
+	if !ok {
+		return fmt.Errorf("unknown condition type %q", conditionType)
+	}
+	statuses, ok := reasons[reason]
+	if !ok {
+		return fmt.Errorf("reason %q is not valid for condition %q", reason, conditionType)
+	}
+	if !containsStatus(statuses, condition.Status) {
+		return fmt.Errorf("condition %q with reason %q cannot have status %q", conditionType, reason, condition.Status)
+	}
+
+	if current == nil {
+		return nil
+	}
+	currentReason := VirtualServerConditionReason(current.Reason)
+	if currentReason == VSConditionReasonTerminating && reason != VSConditionReasonTerminating {
+		return fmt.Errorf("condition %q cannot transition from %q to %q", conditionType, currentReason, reason)
+	}
+	if reason == VSConditionReasonInitializing && currentReason != VSConditionReasonInitializing {
+		return fmt.Errorf("condition %q cannot transition from %q to %q", conditionType, currentReason, reason)
+	}
+	return nil
+}
+
+func containsStatus(statuses []metav1.ConditionStatus, status metav1.ConditionStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// aggregateReadyCondition derives the Ready condition from its dependencies.
+// Missing dependencies are treated as initializing.
+func (vs *VirtualServer) aggregateReadyCondition() metav1.Condition {
+	dependencies := make([]metav1.Condition, 0, len(readyDependencies))
+	for _, conditionType := range readyDependencies {
+		condition := apimeta.FindStatusCondition(vs.Status.Conditions, string(conditionType))
+		if condition == nil {
+			condition = &metav1.Condition{
+				Type:    string(conditionType),
+				Status:  metav1.ConditionUnknown,
+				Reason:  string(VSConditionReasonInitializing),
+				Message: string(VSConditionReasonInitializing),
+			}
+		}
+		dependencies = append(dependencies, *condition)
+	}
+
+	ready := func(status metav1.ConditionStatus, reason VirtualServerConditionReason, message string) metav1.Condition {
+		return metav1.Condition{
+			Type:               string(VSConditionTypeReady),
+			Status:             status,
+			ObservedGeneration: vs.Generation,
+			Reason:             string(reason),
+			Message:            message,
+		}
+	}
+
+	for _, condition := range dependencies {
+		if VirtualServerConditionReason(condition.Reason) == VSConditionReasonTerminating {
+			return ready(metav1.ConditionFalse, VSConditionReasonTerminating, condition.Message)
+		}
+	}
+	for _, condition := range dependencies {
+		if reason := VirtualServerConditionReason(condition.Reason); condition.Status == metav1.ConditionFalse && IsFailureReason(reason) {
+			return ready(metav1.ConditionFalse, reason, condition.Message)
+		}
+	}
+	for _, condition := range dependencies {
+		if reason := VirtualServerConditionReason(condition.Reason); condition.Type == string(VSConditionTypeStarted) && condition.Status == metav1.ConditionFalse {
+			return ready(metav1.ConditionFalse, reason, condition.Message)
+		}
+	}
+	for _, condition := range dependencies {
+		if condition.Status == metav1.ConditionTrue {
+			continue
+		}
+		reason := VirtualServerConditionReason(condition.Reason)
+		if !containsStatus(conditionReasons[VSConditionTypeReady][reason], metav1.ConditionUnknown) {
+			reason = waitingReasons[VirtualServerConditionType(condition.Type)]
+		}
+		return ready(metav1.ConditionUnknown, reason, condition.Message)
+	}
+	return ready(metav1.ConditionTrue, VSConditionReasonReady, string(VSConditionReasonReady))
+}
+
+// updateReadyCondition sets the Ready condition derived from its dependencies.
+// The Ready condition is left unchanged if the derived condition is not a valid transition.
+func (vs *VirtualServer) updateReadyCondition() {
+	condition := vs.aggregateReadyCondition()
+	current := apimeta.FindStatusCondition(vs.Status.Conditions, condition.Type)
+	if ValidateConditionTransition(current, condition) != nil {
+		return
+	}
+	apimeta.SetStatusCondition(&vs.Status.Conditions, condition)
+}
+
+// UpdateStatusSummary sets the phase and the summarized condition fields of the status.
+// It must be called after the conditions or deletion timestamp of the VirtualServer change.
+func (vs *VirtualServer) UpdateStatusSummary() {
+	vs.Status.UpdateSummary(vs.DeletionTimestamp != nil)
+}
+
+// UpdateSummary sets the phase and the summarized condition fields from the conditions.
+// deleting should be true if the VirtualServer has a deletion timestamp.
+func (s *VirtualServerStatus) UpdateSummary(deleting bool) {
+	s.Phase = s.phase(deleting)
+	s.Started = ""
+	if started := apimeta.FindStatusCondition(s.Conditions, string(VSConditionTypeStarted)); started != nil {
+		s.Started = started.Status
+	}
+	s.ReadyReason, s.ReadyMessage = "", ""
+	if ready := apimeta.FindStatusCondition(s.Conditions, string(VSConditionTypeReady)); ready != nil {
+		s.ReadyReason = ready.Reason
+		s.ReadyMessage = ready.Message
+	}
+}
+
+// StaleConditions returns the conditions that were set before the current generation of the VirtualServer spec
+func (vs *VirtualServer) StaleConditions() []metav1.Condition {
+	var stale []metav1.Condition
+	for _, condition := range vs.Status.Conditions {
+		if condition.ObservedGeneration < vs.Generation {
+			stale = append(stale, *condition.DeepCopy())
+		}
+	}
+	return stale
+}
+
+// IsReconciled returns true if the status reflects the current generation of the VirtualServer spec.
+// Conditions, such as Ready, should only be relied upon once the VirtualServer is reconciled.
+func (vs *VirtualServer) IsReconciled() bool {
+	return vs.Status.ObservedGeneration >= vs.Generation && len(vs.StaleConditions()) == 0
+}
+
+// SetObservedGeneration records the generation of the VirtualServer spec the status reflects
+func (s *VirtualServerStatus) SetObservedGeneration(generation int64) {
+	s.ObservedGeneration = generation
+}
+
+func (s *VirtualServerStatus) phase(deleting bool) VirtualServerPhase {
+	if deleting {
+		return VirtualServerPhaseTerminating
+	}
+	ready := apimeta.FindStatusCondition(s.Conditions, string(VSConditionTypeReady))
+	if ready == nil {
+		return VirtualServerPhasePending
+	}
+
+	for _, condition := range s.Conditions {
+		if VirtualServerConditionReason(condition.Reason) == VSConditionReasonTerminating {
+			return VirtualServerPhaseTerminating
+		}
+	}
+	for _, condition := range s.Conditions {
+		if condition.Status == metav1.ConditionFalse && IsFailureReason(VirtualServerConditionReason(condition.Reason)) {
+			return VirtualServerPhaseFailed
+		}
+	}
+	if ready.Status == metav1.ConditionTrue {
+		return VirtualServerPhaseRunning
+	}
+	if started := apimeta.FindStatusCondition(s.Conditions, string(VSConditionTypeStarted)); started != nil && started.Status == metav1.ConditionFalse {
+		return VirtualServerPhaseStopped
+	}
+	if ready.Status == metav1.ConditionFalse {
+		switch VirtualServerConditionReason(ready.Reason) {
+		case VSConditionReasonStopped, VSConditionReasonVMIShutdown:
+			return VirtualServerPhaseStopped
+		}
+	}
+	for _, condition := range s.Conditions {
+		if VirtualServerConditionReason(condition.Reason) != VSConditionReasonInitializing {
+			return VirtualServerPhaseProvisioning
+		}
+	}
+	return VirtualServerPhasePending
+}
@@ -0,0 +1,325 @@
+package v1alpha1_test
+
+import (
+	"reflect"
+	"testing"
+
+	vsv1alpha "github.com/coreweave/virtual-server/api/v1alpha1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateConditionTransition(t *testing.T) {
+	condition := func(conditionType vsv1alpha.VirtualServerConditionType, status metav1.ConditionStatus, reason vsv1alpha.VirtualServerConditionReason) metav1.Condition {
+		return metav1.Condition{Type: string(conditionType), Status: status, Reason: string(reason)}
+	}
+
+	testCases := []struct {
+		name      string
+		current   *metav1.Condition
+		condition metav1.Condition
+		valid     bool
+	}{
+		{
+			name:      "initial condition",
+			condition: condition(vsv1alpha.VSConditionTypeReady, metav1.ConditionUnknown, vsv1alpha.VSConditionReasonInitializing),
+			valid:     true,
+		},
+		{
+			name:      "unknown type",
+			condition: condition("Unknown", metav1.ConditionTrue, vsv1alpha.VSConditionReasonReady),
+		},
+		{
+			name:      "reason of another type",
+			condition: condition(vsv1alpha.VSConditionTypeServicesReady, metav1.ConditionTrue, vsv1alpha.VSConditionReasonVMReady),
+		},
+		{
+			name:      "status not valid for reason",
+			condition: condition(vsv1alpha.VSConditionTypeVMReady, metav1.ConditionFalse, vsv1alpha.VSConditionReasonVMReady),
+		},
+		{
+			name:      "waiting may be unknown or false",
+			current:   &metav1.Condition{Type: string(vsv1alpha.VSConditionTypeServicesReady), Status: metav1.ConditionUnknown, Reason: string(vsv1alpha.VSConditionReasonServicesCreated)},
+			condition: condition(vsv1alpha.VSConditionTypeServicesReady, metav1.ConditionFalse, vsv1alpha.VSConditionReasonWaitingForServices),
+			valid:     true,
+		},
+		{
+			name:      "return to initializing",
+			current:   &metav1.Condition{Type: string(vsv1alpha.VSConditionTypeVMReady), Status: metav1.ConditionTrue, Reason: string(vsv1alpha.VSConditionReasonVMReady)},
+			condition: condition(vsv1alpha.VSConditionTypeVMReady, metav1.ConditionUnknown, vsv1alpha.VSConditionReasonInitializing),
+		},
+		{
+			name:      "leave terminating",
+			current:   &metav1.Condition{Type: string(vsv1alpha.VSConditionTypeStarted), Status: metav1.ConditionFalse, Reason: string(vsv1alpha.VSConditionReasonTerminating)},
+			condition: condition(vsv1alpha.VSConditionTypeStarted, metav1.ConditionTrue, vsv1alpha.VSConditionReasonStarted),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := vsv1alpha.ValidateConditionTransition(tc.current, tc.condition)
+			if tc.valid && err != nil {
+				t.Errorf("expected a valid transition, got %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("expected an invalid transition")
+			}
+		})
+	}
+}
+
+func TestValidConditionReasons(t *testing.T) {
+	for _, conditionType := range []vsv1alpha.VirtualServerConditionType{
+		vsv1alpha.VSConditionTypeReady,
+		vsv1alpha.VSConditionTypeStarted,
+		vsv1alpha.VSConditionTypeServicesReady,
+		vsv1alpha.VSConditionTypeVMReady,
+		vsv1alpha.VSConditionTypeSecretReady,
+	} {
+		reasons := vsv1alpha.ValidConditionReasons(conditionType)
+		for _, reason := range []vsv1alpha.VirtualServerConditionReason{vsv1alpha.VSConditionReasonInitializing, vsv1alpha.VSConditionReasonTerminating} {
+			if !containsReason(reasons, reason) {
+				t.Errorf("expected %s to be a valid reason for %s", reason, conditionType)
+			}
+		}
+	}
+	if reasons := vsv1alpha.ValidConditionReasons("Unknown"); len(reasons) != 0 {
+		t.Errorf("expected no reasons for an unknown condition type, got %v", reasons)
+	}
+}
+
+func containsReason(reasons []vsv1alpha.VirtualServerConditionReason, reason vsv1alpha.VirtualServerConditionReason) bool {
+	for _, r := range reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSetConditionUnchecked(t *testing.T) {
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	vs.InitializeStatus()
+
+	condition := metav1.Condition{
+		Type:   string(vsv1alpha.VSConditionTypeSecretReady),
+		Status: metav1.ConditionTrue,
+		Reason: string(vsv1alpha.VSConditionReasonServicesReady),
+	}
+	current := apimeta.FindStatusCondition(vs.Status.Conditions, condition.Type)
+	if err := vsv1alpha.ValidateConditionTransition(current, condition); err == nil {
+		t.Error("expected an error for a reason of another condition type")
+	}
+	vs.SetCondition(vsv1alpha.VSConditionTypeSecretReady, metav1.ConditionTrue, vsv1alpha.VSConditionReasonServicesReady, nil, true)
+	if current := apimeta.FindStatusCondition(vs.Status.Conditions, condition.Type); current.Reason != condition.Reason {
+		t.Errorf("expected SetCondition to set the condition as given, got %+v", current)
+	}
+}
+
+func TestInitializeStatusKeepsConditions(t *testing.T) {
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	apimeta.SetStatusCondition(&vs.Status.Conditions, metav1.Condition{Type: "Custom", Status: metav1.ConditionTrue, Reason: "Custom"})
+	vs.InitializeStatus()
+	if apimeta.FindStatusCondition(vs.Status.Conditions, "Custom") == nil {
+		t.Errorf("expected conditions of other types to be kept, got %+v", vs.Status.Conditions)
+	}
+	if ready := vs.GetReadyStatus(); ready == nil || ready.Reason != string(vsv1alpha.VSConditionReasonInitializing) {
+		t.Errorf("expected Ready to be initialized, got %+v", ready)
+	}
+}
+
+func TestSetConditionTopLevel(t *testing.T) {
+	type step struct {
+		conditionType vsv1alpha.VirtualServerConditionType
+		status        metav1.ConditionStatus
+		reason        vsv1alpha.VirtualServerConditionReason
+	}
+
+	testCases := []struct {
+		name        string
+		steps       []step
+		readyStatus metav1.ConditionStatus
+		readyReason vsv1alpha.VirtualServerConditionReason
+		phase       vsv1alpha.VirtualServerPhase
+	}{
+		{
+			name:        "initialized",
+			readyStatus: metav1.ConditionUnknown,
+			readyReason: vsv1alpha.VSConditionReasonInitializing,
+			phase:       vsv1alpha.VirtualServerPhasePending,
+		},
+		{
+			name: "waiting for services",
+			steps: []step{
+				{vsv1alpha.VSConditionTypeSecretReady, metav1.ConditionTrue, vsv1alpha.VSConditionReasonSecretCreated},
+				{vsv1alpha.VSConditionTypeServicesReady, metav1.ConditionUnknown, vsv1alpha.VSConditionReasonServicesCreated},
+			},
+			readyStatus: metav1.ConditionUnknown,
+			readyReason: vsv1alpha.VSConditionReasonWaitingForServices,
+			phase:       vsv1alpha.VirtualServerPhaseProvisioning,
+		},
+		{
+			name: "ready",
+			steps: []step{
+				{vsv1alpha.VSConditionTypeSecretReady, metav1.ConditionTrue, vsv1alpha.VSConditionReasonSecretCreated},
+				{vsv1alpha.VSConditionTypeServicesReady, metav1.ConditionTrue, vsv1alpha.VSConditionReasonServicesReady},
+				{vsv1alpha.VSConditionTypeVMReady, metav1.ConditionTrue, vsv1alpha.VSConditionReasonVMReady},
+				{vsv1alpha.VSConditionTypeStarted, metav1.ConditionTrue, vsv1alpha.VSConditionReasonStarted},
+			},
+			readyStatus: metav1.ConditionTrue,
+			readyReason: vsv1alpha.VSConditionReasonReady,
+			phase:       vsv1alpha.VirtualServerPhaseRunning,
+		},
+		{
+			name: "stopped",
+			steps: []step{
+				{vsv1alpha.VSConditionTypeSecretReady, metav1.ConditionTrue, vsv1alpha.VSConditionReasonSecretCreated},
+				{vsv1alpha.VSConditionTypeServicesReady, metav1.ConditionTrue, vsv1alpha.VSConditionReasonServicesReady},
+				{vsv1alpha.VSConditionTypeVMReady, metav1.ConditionTrue, vsv1alpha.VSConditionReasonVMReady},
+				{vsv1alpha.VSConditionTypeStarted, metav1.ConditionFalse, vsv1alpha.VSConditionReasonStopped},
+			},
+			readyStatus: metav1.ConditionFalse,
+			readyReason: vsv1alpha.VSConditionReasonStopped,
+			phase:       vsv1alpha.VirtualServerPhaseStopped,
+		},
+		{
+			name: "failed",
+			steps: []step{
+				{vsv1alpha.VSConditionTypeServicesReady, metav1.ConditionTrue, vsv1alpha.VSConditionReasonServicesReady},
+				{vsv1alpha.VSConditionTypeVMReady, metav1.ConditionFalse, vsv1alpha.VSConditionReasonVMNameTaken},
+			},
+			readyStatus: metav1.ConditionFalse,
+			readyReason: vsv1alpha.VSConditionReasonVMNameTaken,
+			phase:       vsv1alpha.VirtualServerPhaseFailed,
+		},
+		{
+			name: "terminating",
+			steps: []step{
+				{vsv1alpha.VSConditionTypeVMReady, metav1.ConditionFalse, vsv1alpha.VSConditionReasonVMNameTaken},
+				{vsv1alpha.VSConditionTypeServicesReady, metav1.ConditionFalse, vsv1alpha.VSConditionReasonTerminating},
+			},
+			readyStatus: metav1.ConditionFalse,
+			readyReason: vsv1alpha.VSConditionReasonTerminating,
+			phase:       vsv1alpha.VirtualServerPhaseTerminating,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+			vs.InitializeStatus()
+			for _, s := range tc.steps {
+				vs.SetCondition(s.conditionType, s.status, s.reason, nil, true)
+			}
+			ready := vs.GetReadyStatus()
+			if ready.Status != tc.readyStatus || ready.Reason != string(tc.readyReason) {
+				t.Errorf("expected Ready %s/%s, got %s/%s", tc.readyStatus, tc.readyReason, ready.Status, ready.Reason)
+			}
+			if vs.Status.Phase != tc.phase {
+				t.Errorf("expected phase %s, got %s", tc.phase, vs.Status.Phase)
+			}
+			if vs.Status.ReadyReason != ready.Reason || vs.Status.ReadyMessage != ready.Message {
+				t.Errorf("expected the summarized Ready reason and message to match the condition, got %q/%q", vs.Status.ReadyReason, vs.Status.ReadyMessage)
+			}
+		})
+	}
+}
+
+func TestSetConditionWithoutReady(t *testing.T) {
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	vs.SetCondition(vsv1alpha.VSConditionTypeVMReady, metav1.ConditionTrue, vsv1alpha.VSConditionReasonVMReady, nil, true)
+	ready := vs.GetReadyStatus()
+	if ready == nil || ready.Status != metav1.ConditionUnknown || ready.Reason != string(vsv1alpha.VSConditionReasonInitializing) {
+		t.Errorf("expected Ready to be derived from the missing conditions, got %+v", ready)
+	}
+	vs.UpdateVirtualMachineStartedCondition(false)
+}
+
+func TestUpdateStatusSummaryDeleted(t *testing.T) {
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	vs.InitializeStatus()
+	now := metav1.Now()
+	vs.DeletionTimestamp = &now
+	vs.UpdateStatusSummary()
+	if vs.Status.Phase != vsv1alpha.VirtualServerPhaseTerminating {
+		t.Errorf("expected phase %s, got %s", vsv1alpha.VirtualServerPhaseTerminating, vs.Status.Phase)
+	}
+}
+
+func TestUpdateStatusSummaryConditionOrder(t *testing.T) {
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	// The summary must not depend on the position of the conditions
+	vs.Status.Conditions = []metav1.Condition{
+		{Type: string(vsv1alpha.VSConditionTypeStarted), Status: metav1.ConditionTrue, Reason: string(vsv1alpha.VSConditionReasonStarted)},
+		{Type: string(vsv1alpha.VSConditionTypeVMReady), Status: metav1.ConditionTrue, Reason: string(vsv1alpha.VSConditionReasonVMReady)},
+		{Type: string(vsv1alpha.VSConditionTypeReady), Status: metav1.ConditionUnknown, Reason: string(vsv1alpha.VSConditionReasonWaitingForServices), Message: "waiting for my-virtual-server-tcp"},
+	}
+	vs.UpdateStatusSummary()
+	vs.Status.SetObservedGeneration(3)
+
+	want := vsv1alpha.VirtualServerStatus{
+		Conditions:         vs.Status.Conditions,
+		Phase:              vsv1alpha.VirtualServerPhaseProvisioning,
+		Started:            metav1.ConditionTrue,
+		ReadyReason:        string(vsv1alpha.VSConditionReasonWaitingForServices),
+		ReadyMessage:       "waiting for my-virtual-server-tcp",
+		ObservedGeneration: 3,
+	}
+	if !reflect.DeepEqual(vs.Status, want) {
+		t.Errorf("expected status %+v, got %+v", want, vs.Status)
+	}
+}
+
+func TestIsReconciled(t *testing.T) {
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	vs.Generation = 1
+	vs.InitializeStatus()
+	if !vs.IsReconciled() {
+		t.Fatalf("expected an initialized VirtualServer to be reconciled, stale conditions: %+v", vs.StaleConditions())
+	}
+
+	// A spec update bumps the generation
+	vs.Generation = 2
+	if vs.IsReconciled() {
+		t.Error("expected the VirtualServer not to be reconciled after a spec update")
+	}
+	if stale := vs.StaleConditions(); len(stale) != len(vs.Status.Conditions) {
+		t.Errorf("expected all conditions to be stale, got %d", len(stale))
+	}
+
+	vs.SetCondition(vsv1alpha.VSConditionTypeVMReady, metav1.ConditionTrue, vsv1alpha.VSConditionReasonVMReady, nil, true)
+	if vs.Status.ObservedGeneration != 2 {
+		t.Errorf("expected observed generation 2, got %d", vs.Status.ObservedGeneration)
+	}
+	stale := vs.StaleConditions()
+	var staleTypes []string
+	for _, condition := range stale {
+		staleTypes = append(staleTypes, condition.Type)
+	}
+	want := []string{
+		string(vsv1alpha.VSConditionTypeServicesReady),
+		string(vsv1alpha.VSConditionTypeStarted),
+		string(vsv1alpha.VSConditionTypeSecretReady),
+	}
+	if !reflect.DeepEqual(staleTypes, want) {
+		t.Errorf("expected stale conditions %v, got %v", want, staleTypes)
+	}
+	if vs.IsReconciled() {
+		t.Error("expected the VirtualServer not to be reconciled while conditions are stale")
+	}
+
+	for _, s := range []struct {
+		conditionType vsv1alpha.VirtualServerConditionType
+		status        metav1.ConditionStatus
+		reason        vsv1alpha.VirtualServerConditionReason
+	}{
+		{vsv1alpha.VSConditionTypeServicesReady, metav1.ConditionTrue, vsv1alpha.VSConditionReasonServicesReady},
+		{vsv1alpha.VSConditionTypeStarted, metav1.ConditionTrue, vsv1alpha.VSConditionReasonStarted},
+		{vsv1alpha.VSConditionTypeSecretReady, metav1.ConditionTrue, vsv1alpha.VSConditionReasonSecretCreated},
+	} {
+		vs.SetCondition(s.conditionType, s.status, s.reason, nil, true)
+	}
+	if !vs.IsReconciled() {
+		t.Errorf("expected the VirtualServer to be reconciled, stale conditions: %+v", vs.StaleConditions())
+	}
+}
@@ -0,0 +1,224 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/coreweave/virtual-server/api/v1beta1"
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// SSHPublicKeysAnnotation records the SSH public keys of each user set in SSHPublicKeys rather than the deprecated
+// SSHPublicKey, as a JSON map of usernames to keys, so that converting back from v1beta1 returns each key in its field
+const SSHPublicKeysAnnotation = "vs.coreweave.com/v1alpha1-sshpublickeys"
+
+var _ conversion.Convertible = &VirtualServer{}
+
+// ConvertTo converts the v1alpha1 VirtualServer to the v1beta1 hub version
+func (src *VirtualServer) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.VirtualServer)
+	return Convert_v1alpha1_VirtualServer_To_v1beta1_VirtualServer(src, dst, nil)
+}
+
+// ConvertFrom converts the v1beta1 hub version to a v1alpha1 VirtualServer
+func (dst *VirtualServer) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.VirtualServer)
+	return Convert_v1beta1_VirtualServer_To_v1alpha1_VirtualServer(src, dst, nil)
+}
+
+var _ conversion.Convertible = &VirtualServerList{}
+
+// ConvertTo converts the v1alpha1 VirtualServerList to the v1beta1 hub version
+func (src *VirtualServerList) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.VirtualServerList)
+	return Convert_v1alpha1_VirtualServerList_To_v1beta1_VirtualServerList(src, dst, nil)
+}
+
+// ConvertFrom converts the v1beta1 hub version to a v1alpha1 VirtualServerList
+func (dst *VirtualServerList) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.VirtualServerList)
+	return Convert_v1beta1_VirtualServerList_To_v1alpha1_VirtualServerList(src, dst, nil)
+}
+
+// Convert_v1alpha1_VirtualServer_To_v1beta1_VirtualServer records the keys of SSHPublicKeys in the SSHPublicKeysAnnotation
+func Convert_v1alpha1_VirtualServer_To_v1beta1_VirtualServer(in *VirtualServer, out *v1beta1.VirtualServer, s apiconversion.Scope) error {
+	if err := autoConvert_v1alpha1_VirtualServer_To_v1beta1_VirtualServer(in, out, s); err != nil {
+		return err
+	}
+	keys := map[string][]string{}
+	for _, user := range in.Spec.Users {
+		if len(user.SSHPublicKeys) > 0 {
+			keys[user.Username] = append(keys[user.Username], user.SSHPublicKeys...)
+		}
+	}
+	annotations := copyAnnotations(in.Annotations)
+	delete(annotations, SSHPublicKeysAnnotation)
+	if len(keys) > 0 {
+		value, err := json.Marshal(keys)
+		if err != nil {
+			return err
+		}
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[SSHPublicKeysAnnotation] = string(value)
+	}
+	out.Annotations = annotations
+	return nil
+}
+
+// Convert_v1beta1_VirtualServer_To_v1alpha1_VirtualServer returns the keys recorded in the SSHPublicKeysAnnotation
+// in SSHPublicKeys, and the other keys in SSHPublicKey. The annotation is not returned.
+func Convert_v1beta1_VirtualServer_To_v1alpha1_VirtualServer(in *v1beta1.VirtualServer, out *VirtualServer, s apiconversion.Scope) error {
+	if err := autoConvert_v1beta1_VirtualServer_To_v1alpha1_VirtualServer(in, out, s); err != nil {
+		return err
+	}
+	value, ok := in.Annotations[SSHPublicKeysAnnotation]
+	if !ok {
+		return nil
+	}
+	out.Annotations = copyAnnotations(in.Annotations)
+	delete(out.Annotations, SSHPublicKeysAnnotation)
+	if len(out.Annotations) == 0 {
+		out.Annotations = nil
+	}
+	keys := map[string][]string{}
+	if err := json.Unmarshal([]byte(value), &keys); err != nil {
+		return fmt.Errorf("invalid %s annotation: %w", SSHPublicKeysAnnotation, err)
+	}
+	for i := range out.Spec.Users {
+		user := &out.Spec.Users[i]
+		present := map[string]bool{}
+		for _, key := range in.Spec.Users[i].SSHPublicKeys {
+			present[key] = true
+		}
+		// Recorded keys are returned in their recorded order, unless they were removed through v1beta1
+		recorded := map[string]bool{}
+		for _, key := range keys[user.Username] {
+			if present[key] && !recorded[key] {
+				user.SSHPublicKeys = append(user.SSHPublicKeys, key)
+			}
+			recorded[key] = true
+		}
+		var legacy []string
+		for _, key := range in.Spec.Users[i].SSHPublicKeys {
+			if !recorded[key] {
+				legacy = append(legacy, key)
+			}
+		}
+		user.SSHPublicKey = strings.Join(legacy, "\n")
+	}
+	return nil
+}
+
+func copyAnnotations(annotations map[string]string) map[string]string {
+	if annotations == nil {
+		return nil
+	}
+	copied := make(map[string]string, len(annotations))
+	for key, value := range annotations {
+		copied[key] = value
+	}
+	return copied
+}
+
+// Convert_v1alpha1_VirtualServerResources_To_v1beta1_VirtualServerResources converts the GPU type and count pointers
+// to an optional GPU request, which is only set for GPU servers
+func Convert_v1alpha1_VirtualServerResources_To_v1beta1_VirtualServerResources(in *VirtualServerResources, out *v1beta1.VirtualServerResources, s apiconversion.Scope) error {
+	if err := autoConvert_v1alpha1_VirtualServerResources_To_v1beta1_VirtualServerResources(in, out, s); err != nil {
+		return err
+	}
+	out.GPU = nil
+	if in.GPU.Type != nil || in.GPU.Count != nil {
+		out.GPU = &v1beta1.VirtualServerResourceGPU{}
+		return Convert_v1alpha1_VirtualServerResourceGPU_To_v1beta1_VirtualServerResourceGPU(&in.GPU, out.GPU, s)
+	}
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerResources_To_v1alpha1_VirtualServerResources converts the optional GPU request
+// to the GPU type and count pointers
+func Convert_v1beta1_VirtualServerResources_To_v1alpha1_VirtualServerResources(in *v1beta1.VirtualServerResources, out *VirtualServerResources, s apiconversion.Scope) error {
+	if err := autoConvert_v1beta1_VirtualServerResources_To_v1alpha1_VirtualServerResources(in, out, s); err != nil {
+		return err
+	}
+	out.GPU = VirtualServerResourceGPU{}
+	if in.GPU != nil {
+		return Convert_v1beta1_VirtualServerResourceGPU_To_v1alpha1_VirtualServerResourceGPU(in.GPU, &out.GPU, s)
+	}
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerResourceGPU_To_v1beta1_VirtualServerResourceGPU dereferences the GPU count
+func Convert_v1alpha1_VirtualServerResourceGPU_To_v1beta1_VirtualServerResourceGPU(in *VirtualServerResourceGPU, out *v1beta1.VirtualServerResourceGPU, s apiconversion.Scope) error {
+	if err := autoConvert_v1alpha1_VirtualServerResourceGPU_To_v1beta1_VirtualServerResourceGPU(in, out, s); err != nil {
+		return err
+	}
+	out.Count = 0
+	if in.Count != nil {
+		out.Count = *in.Count
+	}
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerResourceGPU_To_v1alpha1_VirtualServerResourceGPU converts an unset GPU type and count to nil
+func Convert_v1beta1_VirtualServerResourceGPU_To_v1alpha1_VirtualServerResourceGPU(in *v1beta1.VirtualServerResourceGPU, out *VirtualServerResourceGPU, s apiconversion.Scope) error {
+	if err := autoConvert_v1beta1_VirtualServerResourceGPU_To_v1alpha1_VirtualServerResourceGPU(in, out, s); err != nil {
+		return err
+	}
+	if in.Type == "" {
+		out.Type = nil
+	}
+	out.Count = nil
+	if in.Count != 0 {
+		count := in.Count
+		out.Count = &count
+	}
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerResourceCPU_To_v1alpha1_VirtualServerResourceCPU converts an unset CPU type to nil
+func Convert_v1beta1_VirtualServerResourceCPU_To_v1alpha1_VirtualServerResourceCPU(in *v1beta1.VirtualServerResourceCPU, out *VirtualServerResourceCPU, s apiconversion.Scope) error {
+	if err := autoConvert_v1beta1_VirtualServerResourceCPU_To_v1alpha1_VirtualServerResourceCPU(in, out, s); err != nil {
+		return err
+	}
+	if in.Type == "" {
+		out.Type = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerUser_To_v1beta1_VirtualServerUser merges the newline separated SSH public keys into the list of keys
+func Convert_v1alpha1_VirtualServerUser_To_v1beta1_VirtualServerUser(in *VirtualServerUser, out *v1beta1.VirtualServerUser, s apiconversion.Scope) error {
+	if err := autoConvert_v1alpha1_VirtualServerUser_To_v1beta1_VirtualServerUser(in, out, s); err != nil {
+		return err
+	}
+	out.SSHPublicKeys = in.AuthorizedKeys()
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerUser_To_v1alpha1_VirtualServerUser joins the list of SSH public keys with newlines
+// into the deprecated SSHPublicKey, for clients that do not know of SSHPublicKeys. Keys recorded in the
+// SSHPublicKeysAnnotation are moved back to SSHPublicKeys by Convert_v1beta1_VirtualServer_To_v1alpha1_VirtualServer.
+// Each key is returned in a single field, so that removing it from that field revokes it.
+func Convert_v1beta1_VirtualServerUser_To_v1alpha1_VirtualServerUser(in *v1beta1.VirtualServerUser, out *VirtualServerUser, s apiconversion.Scope) error {
+	if err := autoConvert_v1beta1_VirtualServerUser_To_v1alpha1_VirtualServerUser(in, out, s); err != nil {
+		return err
+	}
+	out.SSHPublicKey = strings.Join(in.SSHPublicKeys, "\n")
+	out.SSHPublicKeys = nil
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerNetwork_To_v1alpha1_VirtualServerNetwork converts an unset Public to its default of true
+func Convert_v1beta1_VirtualServerNetwork_To_v1alpha1_VirtualServerNetwork(in *v1beta1.VirtualServerNetwork, out *VirtualServerNetwork, s apiconversion.Scope) error {
+	if err := autoConvert_v1beta1_VirtualServerNetwork_To_v1alpha1_VirtualServerNetwork(in, out, s); err != nil {
+		return err
+	}
+	if in.Public == nil {
+		out.Public = true
+	}
+	return nil
+}
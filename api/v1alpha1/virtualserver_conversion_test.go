@@ -0,0 +1,362 @@
+package v1alpha1_test
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	vsv1alpha "github.com/coreweave/virtual-server/api/v1alpha1"
+	vsv1beta "github.com/coreweave/virtual-server/api/v1beta1"
+	fuzz "github.com/google/gofuzz"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/diff"
+)
+
+const fuzzIterations = 1000
+
+// conversionFuzzerFuncs restricts quantities to values that survive a JSON round trip. Values the conversion
+// normalizes, such as empty CPU types or blank lines between SSH public keys, are fuzzed and expected to be
+// normalized as described by normalizeSpoke and normalizeHub.
+func conversionFuzzerFuncs(_ serializer.CodecFactory) []interface{} {
+	return []interface{}{
+		func(q *resource.Quantity, c fuzz.Continue) {
+			*q = *resource.NewQuantity(c.Int63n(1<<40), resource.BinarySI)
+		},
+		func(cpu *vsv1alpha.VirtualServerResourceCPU, c fuzz.Continue) {
+			c.FuzzNoCustom(cpu)
+			if c.RandBool() {
+				empty := ""
+				cpu.Type = &empty
+			}
+		},
+		func(gpu *vsv1alpha.VirtualServerResourceGPU, c fuzz.Continue) {
+			c.FuzzNoCustom(gpu)
+			if c.RandBool() {
+				zero := uint32(0)
+				gpu.Count = &zero
+			}
+		},
+		func(user *vsv1alpha.VirtualServerUser, c fuzz.Continue) {
+			c.FuzzNoCustom(user)
+			user.SSHPublicKeys = fuzzSSHPublicKeys(c)
+			user.SSHPublicKey = strings.Join(fuzzSSHPublicKeys(c), "\n")
+			if c.RandBool() {
+				// YAML block scalars end with a newline, and may contain blank lines
+				user.SSHPublicKey = "\n" + user.SSHPublicKey + "\n\n"
+			}
+		},
+		func(gpu *vsv1beta.VirtualServerResourceGPU, c fuzz.Continue) {
+			c.FuzzNoCustom(gpu)
+			if c.RandBool() {
+				gpu.Type = ""
+				gpu.Count = 0
+			}
+		},
+		func(user *vsv1beta.VirtualServerUser, c fuzz.Continue) {
+			c.FuzzNoCustom(user)
+			user.SSHPublicKeys = fuzzSSHPublicKeys(c)
+		},
+	}
+}
+
+// fuzzSSHPublicKeys returns a list of unique non-empty keys without newlines, or nil
+func fuzzSSHPublicKeys(c fuzz.Continue) []string {
+	var keys []string
+	for i := c.Intn(3); i > 0; i-- {
+		keys = append(keys, "ssh-ed25519 "+strings.ReplaceAll(c.RandString(), "\n", "")+strconv.Itoa(i)+"key")
+	}
+	return keys
+}
+
+// normalizeSpoke applies the normalization of a v1alpha1 -> v1beta1 -> v1alpha1 round trip: empty CPU and GPU types
+// and a zero GPU count are unset, and the SSH public keys of SSHPublicKey are returned newline separated,
+// without blank lines or keys also set in SSHPublicKeys
+func normalizeSpoke(vs *vsv1alpha.VirtualServer) {
+	resources := &vs.Spec.Resources
+	if resources.CPU.Type != nil && *resources.CPU.Type == "" {
+		resources.CPU.Type = nil
+	}
+	if resources.GPU.Type != nil && *resources.GPU.Type == "" {
+		resources.GPU.Type = nil
+	}
+	if resources.GPU.Count != nil && *resources.GPU.Count == 0 {
+		resources.GPU.Count = nil
+	}
+	for i := range vs.Spec.Users {
+		user := &vs.Spec.Users[i]
+		inList := map[string]bool{}
+		for _, key := range user.SSHPublicKeys {
+			inList[key] = true
+		}
+		var legacy []string
+		for _, key := range user.AuthorizedKeys() {
+			if !inList[key] {
+				legacy = append(legacy, key)
+			}
+		}
+		user.SSHPublicKey = strings.Join(legacy, "\n")
+	}
+}
+
+// normalizeHub applies the normalization of a v1beta1 -> v1alpha1 -> v1beta1 round trip: an unset Public is
+// defaulted to true, and a GPU request without type or count is unset
+func normalizeHub(vs *vsv1beta.VirtualServer) {
+	if vs.Spec.Network.Public == nil {
+		public := true
+		vs.Spec.Network.Public = &public
+	}
+	if gpu := vs.Spec.Resources.GPU; gpu != nil && gpu.Type == "" && gpu.Count == 0 {
+		vs.Spec.Resources.GPU = nil
+	}
+}
+
+func newConversionFuzzer(t *testing.T) *fuzz.Fuzzer {
+	scheme := runtime.NewScheme()
+	if err := vsv1alpha.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := vsv1beta.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	seed := rand.Int63()
+	t.Logf("fuzzer seed: %d", seed)
+	return fuzzer.FuzzerFor(
+		fuzzer.MergeFuzzerFuncs(metafuzzer.Funcs, conversionFuzzerFuncs),
+		rand.NewSource(seed),
+		serializer.NewCodecFactory(scheme),
+	)
+}
+
+func TestConversionSpokeHubSpoke(t *testing.T) {
+	f := newConversionFuzzer(t)
+	for i := 0; i < fuzzIterations; i++ {
+		spoke := &vsv1alpha.VirtualServer{}
+		f.Fuzz(spoke)
+
+		hub := &vsv1beta.VirtualServer{}
+		if err := spoke.DeepCopy().ConvertTo(hub); err != nil {
+			t.Fatal(err)
+		}
+		got := &vsv1alpha.VirtualServer{}
+		if err := got.ConvertFrom(hub); err != nil {
+			t.Fatal(err)
+		}
+		normalizeSpoke(spoke)
+		if !apiequality.Semantic.DeepEqual(spoke, got) {
+			t.Fatalf("v1alpha1 -> v1beta1 -> v1alpha1 round trip is lossy:\n%s", diff.ObjectReflectDiff(spoke, got))
+		}
+	}
+}
+
+func TestConversionHubSpokeHub(t *testing.T) {
+	f := newConversionFuzzer(t)
+	for i := 0; i < fuzzIterations; i++ {
+		hub := &vsv1beta.VirtualServer{}
+		f.Fuzz(hub)
+
+		spoke := &vsv1alpha.VirtualServer{}
+		if err := spoke.ConvertFrom(hub.DeepCopy()); err != nil {
+			t.Fatal(err)
+		}
+		got := &vsv1beta.VirtualServer{}
+		if err := spoke.ConvertTo(got); err != nil {
+			t.Fatal(err)
+		}
+		normalizeHub(hub)
+		if !apiequality.Semantic.DeepEqual(hub, got) {
+			t.Fatalf("v1beta1 -> v1alpha1 -> v1beta1 round trip is lossy:\n%s", diff.ObjectReflectDiff(hub, got))
+		}
+	}
+}
+
+func TestConversionList(t *testing.T) {
+	f := newConversionFuzzer(t)
+	spoke := &vsv1alpha.VirtualServerList{}
+	f.Fuzz(spoke)
+
+	hub := &vsv1beta.VirtualServerList{}
+	if err := spoke.DeepCopy().ConvertTo(hub); err != nil {
+		t.Fatal(err)
+	}
+	got := &vsv1alpha.VirtualServerList{}
+	if err := got.ConvertFrom(hub); err != nil {
+		t.Fatal(err)
+	}
+	for i := range spoke.Items {
+		normalizeSpoke(&spoke.Items[i])
+	}
+	if !apiequality.Semantic.DeepEqual(spoke, got) {
+		t.Fatalf("v1alpha1 -> v1beta1 -> v1alpha1 list round trip is lossy:\n%s", diff.ObjectReflectDiff(spoke, got))
+	}
+}
+
+func TestConversionSSHPublicKeys(t *testing.T) {
+	spoke := &vsv1alpha.VirtualServer{Spec: vsv1alpha.VirtualServerSpec{
+		Users: []vsv1alpha.VirtualServerUser{{Username: "myuser", SSHPublicKey: "ssh-ed25519 AAAA one\nssh-ed25519 AAAA two"}},
+	}}
+	hub := &vsv1beta.VirtualServer{}
+	if err := spoke.ConvertTo(hub); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"ssh-ed25519 AAAA one", "ssh-ed25519 AAAA two"}
+	if !apiequality.Semantic.DeepEqual(hub.Spec.Users[0].SSHPublicKeys, want) {
+		t.Errorf("expected keys %q, got %q", want, hub.Spec.Users[0].SSHPublicKeys)
+	}
+
+	// Keys set in both fields are merged
+	spoke.Spec.Users[0].SSHPublicKeys = []string{"ssh-ed25519 AAAA two", "ssh-ed25519 AAAA three"}
+	if err := spoke.ConvertTo(hub); err != nil {
+		t.Fatal(err)
+	}
+	want = append(want, "ssh-ed25519 AAAA three")
+	if !apiequality.Semantic.DeepEqual(hub.Spec.Users[0].SSHPublicKeys, want) {
+		t.Errorf("expected keys %q, got %q", want, hub.Spec.Users[0].SSHPublicKeys)
+	}
+}
+
+func TestConversionSSHPublicKeysRoundTrip(t *testing.T) {
+	spoke := &vsv1alpha.VirtualServer{Spec: vsv1alpha.VirtualServerSpec{
+		Users: []vsv1alpha.VirtualServerUser{{Username: "myuser", SSHPublicKey: "ssh-ed25519 AAAA one", SSHPublicKeys: []string{"ssh-ed25519 AAAA two"}}},
+	}}
+	hub := &vsv1beta.VirtualServer{}
+	if err := spoke.DeepCopy().ConvertTo(hub); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := hub.Annotations[vsv1alpha.SSHPublicKeysAnnotation]; !ok {
+		t.Fatalf("expected the %s annotation to be set", vsv1alpha.SSHPublicKeysAnnotation)
+	}
+
+	// A v1alpha1 client reads back each key in the field it was written to
+	read := &vsv1alpha.VirtualServer{}
+	if err := read.ConvertFrom(hub); err != nil {
+		t.Fatal(err)
+	}
+	if !apiequality.Semantic.DeepEqual(spoke, read) {
+		t.Fatalf("expected the VirtualServer to be read back unchanged:\n%s", diff.ObjectReflectDiff(spoke, read))
+	}
+}
+
+func TestConversionSSHPublicKeyRevoked(t *testing.T) {
+	spoke := &vsv1alpha.VirtualServer{Spec: vsv1alpha.VirtualServerSpec{
+		Users: []vsv1alpha.VirtualServerUser{{Username: "myuser", SSHPublicKey: "ssh-ed25519 AAAA one", SSHPublicKeys: []string{"ssh-ed25519 AAAA two"}}},
+	}}
+	hub := &vsv1beta.VirtualServer{}
+	if err := spoke.ConvertTo(hub); err != nil {
+		t.Fatal(err)
+	}
+
+	// A v1alpha1 client reads the VirtualServer, and removes a key from each field
+	for _, remove := range []func(user *vsv1alpha.VirtualServerUser){
+		func(user *vsv1alpha.VirtualServerUser) { user.SSHPublicKeys = nil },
+		func(user *vsv1alpha.VirtualServerUser) { user.SSHPublicKey = "" },
+	} {
+		read := &vsv1alpha.VirtualServer{}
+		if err := read.ConvertFrom(hub); err != nil {
+			t.Fatal(err)
+		}
+		remove(&read.Spec.Users[0])
+		if err := read.ConvertTo(hub); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(hub.Spec.Users[0].SSHPublicKeys) != 0 {
+		t.Errorf("expected no keys after removing them, got %q", hub.Spec.Users[0].SSHPublicKeys)
+	}
+	if _, ok := hub.Annotations[vsv1alpha.SSHPublicKeysAnnotation]; ok {
+		t.Errorf("expected the %s annotation to be removed", vsv1alpha.SSHPublicKeysAnnotation)
+	}
+}
+
+func TestConversionSSHPublicKeysFromHub(t *testing.T) {
+	hub := &vsv1beta.VirtualServer{Spec: vsv1beta.VirtualServerSpec{
+		Users: []vsv1beta.VirtualServerUser{{Username: "myuser", SSHPublicKeys: []string{"ssh-ed25519 AAAA one", "ssh-ed25519 AAAA two"}}},
+	}}
+	// Keys written through v1beta1 are returned in the field known to all v1alpha1 clients
+	spoke := &vsv1alpha.VirtualServer{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatal(err)
+	}
+	if user := spoke.Spec.Users[0]; user.SSHPublicKey != "ssh-ed25519 AAAA one\nssh-ed25519 AAAA two" || len(user.SSHPublicKeys) != 0 {
+		t.Errorf("expected keys only in sshpublickey, got %q and %q", user.SSHPublicKey, user.SSHPublicKeys)
+	}
+}
+
+func TestConversionNormalization(t *testing.T) {
+	empty, zero := "", uint32(0)
+	spokeTests := []struct {
+		name  string
+		in    vsv1alpha.VirtualServerSpec
+		check func(spec *vsv1alpha.VirtualServerSpec) bool
+	}{
+		{
+			name: "sshpublickey trailing newline and blank lines",
+			in:   vsv1alpha.VirtualServerSpec{Users: []vsv1alpha.VirtualServerUser{{Username: "myuser", SSHPublicKey: "ssh-ed25519 AAAA one\n\nssh-ed25519 AAAA two\n"}}},
+			check: func(spec *vsv1alpha.VirtualServerSpec) bool {
+				return spec.Users[0].SSHPublicKey == "ssh-ed25519 AAAA one\nssh-ed25519 AAAA two"
+			},
+		},
+		{
+			name:  "empty cpu type",
+			in:    vsv1alpha.VirtualServerSpec{Resources: vsv1alpha.VirtualServerResources{CPU: vsv1alpha.VirtualServerResourceCPU{Type: &empty}}},
+			check: func(spec *vsv1alpha.VirtualServerSpec) bool { return spec.Resources.CPU.Type == nil },
+		},
+		{
+			name:  "zero gpu count",
+			in:    vsv1alpha.VirtualServerSpec{Resources: vsv1alpha.VirtualServerResources{GPU: vsv1alpha.VirtualServerResourceGPU{Count: &zero}}},
+			check: func(spec *vsv1alpha.VirtualServerSpec) bool { return spec.Resources.GPU.Count == nil },
+		},
+	}
+	for _, tt := range spokeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := &vsv1beta.VirtualServer{}
+			if err := (&vsv1alpha.VirtualServer{Spec: tt.in}).ConvertTo(hub); err != nil {
+				t.Fatal(err)
+			}
+			got := &vsv1alpha.VirtualServer{}
+			if err := got.ConvertFrom(hub); err != nil {
+				t.Fatal(err)
+			}
+			if !tt.check(&got.Spec) {
+				t.Errorf("unexpected round trip result %+v", got.Spec)
+			}
+		})
+	}
+
+	hubTests := []struct {
+		name  string
+		in    vsv1beta.VirtualServerSpec
+		check func(spec *vsv1beta.VirtualServerSpec) bool
+	}{
+		{
+			name:  "unset public",
+			in:    vsv1beta.VirtualServerSpec{},
+			check: func(spec *vsv1beta.VirtualServerSpec) bool { return spec.Network.Public != nil && *spec.Network.Public },
+		},
+		{
+			name:  "gpu without type or count",
+			in:    vsv1beta.VirtualServerSpec{Resources: vsv1beta.VirtualServerResources{GPU: &vsv1beta.VirtualServerResourceGPU{}}},
+			check: func(spec *vsv1beta.VirtualServerSpec) bool { return spec.Resources.GPU == nil },
+		},
+	}
+	for _, tt := range hubTests {
+		t.Run(tt.name, func(t *testing.T) {
+			spoke := &vsv1alpha.VirtualServer{}
+			if err := spoke.ConvertFrom(&vsv1beta.VirtualServer{Spec: tt.in}); err != nil {
+				t.Fatal(err)
+			}
+			got := &vsv1beta.VirtualServer{}
+			if err := spoke.ConvertTo(got); err != nil {
+				t.Fatal(err)
+			}
+			if !tt.check(&got.Spec) {
+				t.Errorf("unexpected round trip result %+v", got.Spec)
+			}
+		})
+	}
+}
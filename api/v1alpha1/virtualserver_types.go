@@ -21,6 +21,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,6 +43,8 @@ type VirtualServerSpec struct {
 	// +optional
 	ReadinessProbe *kvv1.Probe `json:"readinessProbe,omitempty"`
 	// +optional
+	// +listType=map
+	// +listMapKey=username
 	Users []VirtualServerUser `json:"users,omitempty"`
 	// +optional
 	Network VirtualServerNetwork `json:"network"`
@@ -48,6 +52,17 @@ type VirtualServerSpec struct {
 	InitializeRunning bool `json:"initializeRunning,omitempty"`
 	// +optional
 	CloudInit string `json:"cloudInit,omitempty"`
+	// Ignition is an Ignition v3 config in JSON format, merged into the config generated for the users, filesystem mounts and hostname.
+	// It may only be set when the provisioner is ignition.
+	// +optional
+	Ignition string `json:"ignition,omitempty"`
+	// NetworkData is the cloud-init network-config, in version 1 or version 2 (netplan) format.
+	// It is stored in the cloud-init Secret with the user data, and shares its size limit.
+	// +optional
+	NetworkData string `json:"networkData,omitempty"`
+	// MetaData configures the cloud-init meta-data
+	// +optional
+	MetaData *VirtualServerMetaData `json:"metaData,omitempty"`
 	// +kubebuilder:validation:Enum=Always;RerunOnFailure;Manual;Halted
 	RunStrategy *kvv1.VirtualMachineRunStrategy `json:"runStrategy,omitempty"`
 	// +optional
@@ -57,6 +72,18 @@ type VirtualServerSpec struct {
 	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
 }
 
+// VirtualServerMetaData configures the cloud-init meta-data of the Virtual Server.
+// Only the hostname is configurable. The instance-id is not: KubeVirt generates the NoCloud meta-data with the
+// instance-id <VirtualMachine name>.<namespace>, and provides no field to override it.
+type VirtualServerMetaData struct {
+	// LocalHostname is the hostname of the Virtual Server, a DNS label.
+	// It is set as the hostname of the VirtualMachineInstance, which KubeVirt passes as the local-hostname of the meta-data.
+	// Defaults to the VirtualServer name.
+	// +optional
+	// +kubebuilder:validation:MaxLength=63
+	LocalHostname string `json:"localHostname,omitempty"`
+}
+
 type Firmware struct {
 	// UUID reported by the vmi bios.
 	// Defaults to a random generated uid.
@@ -69,16 +96,41 @@ type Firmware struct {
 
 // VirtualServerStatus defines the observed state of VirtualServer
 type VirtualServerStatus struct {
-	Conditions []metav1.Condition         `json:"conditions,omitempty"`
-	Network    VirtualServerNetworkStatus `json:"network,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Phase summarizes the conditions of the VirtualServer.
+	// It is computed from the conditions and should not be set directly.
+	// +optional
+	Phase VirtualServerPhase `json:"phase,omitempty"`
+	// Started is the status of the VirtualServerStarted condition
+	// +optional
+	Started metav1.ConditionStatus `json:"started,omitempty"`
+	// ReadyReason is the reason of the Ready condition
+	// +optional
+	ReadyReason string `json:"readyReason,omitempty"`
+	// ReadyMessage is the message of the Ready condition
+	// +optional
+	ReadyMessage string `json:"readyMessage,omitempty"`
+	// ObservedGeneration is the most recent generation of the VirtualServer spec observed by the controller
+	// +optional
+	ObservedGeneration int64                      `json:"observedGeneration,omitempty"`
+	Network            VirtualServerNetworkStatus `json:"network,omitempty"`
+	// RootDisk is the observed state of the root disk, including the progress of a resize
+	// +optional
+	RootDisk *VirtualServerRootDiskStatus `json:"rootDisk,omitempty"`
 }
 
+// +genclient
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName=vserver;vs
+// +k8s:defaulter-gen=true
 // +kubebuilder:subresource:status
-// +kubebuilder:printcolumn:JSONPath=".status.conditions[0].reason",name=status,type=string
-// +kubebuilder:printcolumn:JSONPath=".status.conditions[0].message",name=reason,type=string
-// +kubebuilder:printcolumn:JSONPath=".status.conditions[3].status",name=started,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.readyReason",name=status,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.readyMessage",name=reason,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.started",name=started,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.phase",name=Phase,type=string
 // +kubebuilder:printcolumn:JSONPath=".status.network.internalIP",name=Internal IP,type=string
 // +kubebuilder:printcolumn:JSONPath=".status.network.externalIP",name=External IP,type=string
 
@@ -94,6 +146,7 @@ type VirtualServer struct {
 }
 
 // +kubebuilder:object:root=true
+// +k8s:defaulter-gen=true
 
 // VirtualServerList contains a list of VirtualServer
 type VirtualServerList struct {
@@ -123,6 +176,43 @@ type VirtualServerOS struct {
 	// Configure the Virtual Server use a UEFI bootloader
 	// +optional
 	EnableUEFIBoot bool `json:"enableUEFIBoot,omitempty"`
+	// Provisioner is the first boot provisioning agent of the image, cloud-init by default.
+	// Fedora CoreOS and Flatcar images are provisioned with ignition: the users, filesystem mounts and hostname are
+	// rendered into an Ignition config, merged with the VirtualServer ignition config.
+	// +optional
+	// +kubebuilder:validation:Enum=cloud-init;ignition
+	Provisioner VirtualServerProvisioner `json:"provisioner,omitempty"`
+	// Windows configures the answer file (unattend.xml) applied when a Windows Virtual Server is first started.
+	// It may only be set for the windows operating system.
+	// +optional
+	Windows *VirtualServerWindows `json:"windows,omitempty"`
+}
+
+// VirtualServerWindows personalizes a Windows Virtual Server started from a generalized (sysprepped) image
+type VirtualServerWindows struct {
+	// ComputerName is the NetBIOS name of the Virtual Server, up to 15 letters, digits and hyphens.
+	// Windows generates a random name when empty.
+	// +optional
+	// +kubebuilder:validation:MaxLength=15
+	ComputerName string `json:"computerName,omitempty"`
+	// TimeZone is the Windows time zone ID, such as Pacific Standard Time
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// Locale is the language and region, such as en-US, used for the input, system, UI and user locales
+	// +optional
+	Locale string `json:"locale,omitempty"`
+	// ProductKey is the Windows product key, in XXXXX-XXXXX-XXXXX-XXXXX-XXXXX format
+	// +optional
+	ProductKey string `json:"productKey,omitempty"`
+	// AdministratorPasswordSecretRef selects the key of a Secret in the VirtualServer namespace holding the password of the built-in Administrator account
+	// +optional
+	AdministratorPasswordSecretRef *corev1.SecretKeySelector `json:"administratorPasswordSecretRef,omitempty"`
+	// FirstLogonCommands are run in order the first time a user logs on
+	// +optional
+	FirstLogonCommands []string `json:"firstLogonCommands,omitempty"`
+	// EnableRDP enables Remote Desktop with network level authentication, and allows it through the firewall
+	// +optional
+	EnableRDP bool `json:"enableRDP,omitempty"`
 }
 
 // VirtualServerResources defines the resources requested for the VirtualServer
@@ -175,8 +265,12 @@ type VirtualServerStorage struct {
 	// Root describes the root filesystem of the VirtualServer
 	Root VirtualServerStorageRoot `json:"root"`
 	// AdditionalDisks is an array of disks devices added to the VirtualServer
+	// +listType=map
+	// +listMapKey=name
 	AdditionalDisks []VirtualServerDisks `json:"additionalDisks,omitempty"`
 	// Filesystems is an array of filesystem mounted to the VirtualServer
+	// +listType=map
+	// +listMapKey=name
 	FileSystems []VirtualServerFilesystem `json:"filesystems,omitempty"`
 	// Swap describes a swap volume of the specified size added to the VirtualServer
 	// An emptyDisk is created of the specified size to be used as the swap disk
@@ -230,6 +324,30 @@ type DiskAttributes struct {
 type VirtualServerDisks struct {
 	VirtualServerStorageVolume `json:",inline"`
 	DiskAttributes             `json:",inline"`
+	// DataVolumeTemplate describes a DataVolume created alongside the VirtualServer, such as a clone of a PVC or an
+	// imported image. The DataVolume is named after the dataVolume source of the disk spec, which must be set.
+	// +optional
+	DataVolumeTemplate *VirtualServerDataVolumeTemplate `json:"dataVolumeTemplate,omitempty"`
+}
+
+// VirtualServerDataVolumeTemplate describes the DataVolume backing an additional disk
+type VirtualServerDataVolumeTemplate struct {
+	// Size specifies the DataVolume size
+	Size resource.Quantity `json:"size"`
+	// Source describes the DataVolumeSource the disk is cloned or imported from
+	Source *cdiv1beta.DataVolumeSource `json:"source"`
+	// StorageClassName specifies the StorageClassName of the DataVolume PVC
+	StorageClassName string `json:"storageClassName"`
+	// VolumeMode specifies the VolumeMode of the DataVolume PVC.
+	// Defaults to Block
+	// +kubebuilder:default=Block
+	// +optional
+	VolumeMode corev1.PersistentVolumeMode `json:"volumeMode,omitempty"`
+	// AccessMode specifies the AccessMode of the DataVolume PVC.
+	// Defaults to ReadWriteOnce
+	// +kubebuilder:default=ReadWriteOnce
+	// +optional
+	AccessMode corev1.PersistentVolumeAccessMode `json:"accessMode,omitempty"`
 }
 
 type VirtualServerFilesystem struct {
@@ -241,19 +359,97 @@ type VirtualServerFilesystem struct {
 // VirtualServerUser defines user login information in the VirtualServer
 // The user login information will be used to configure the VirtualServer via cloudinit if supported
 type VirtualServerUser struct {
-	Username     string `json:"username"`
-	Password     string `json:"password,omitempty"`
+	Username string `json:"username"`
+	// Password is the plaintext password of the user.
+	// Mutually exclusive with HashedPassword and PasswordSecretRef.
+	// +optional
+	Password string `json:"password,omitempty"`
+	// HashedPassword is the password of the user hashed in crypt(3) format, such as $6$salt$hash.
+	// Only supported on Linux. Mutually exclusive with Password and PasswordSecretRef.
+	// +optional
+	HashedPassword string `json:"hashedPassword,omitempty"`
+	// PasswordSecretRef selects the plaintext password of the user from a Secret in the namespace of the VirtualServer.
+	// Mutually exclusive with Password and HashedPassword.
+	// +optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+	// SSHPublicKey is a newline separated list of public keys authorized to log in as the user.
+	// Deprecated: use SSHPublicKeys. The keys are merged into SSHPublicKeys when converted to v1beta1,
+	// and returned in SSHPublicKey when converted back from v1beta1, unless they were set in SSHPublicKeys.
+	// +optional
 	SSHPublicKey string `json:"sshpublickey,omitempty"`
+	// SSHPublicKeys is a list of public keys authorized to log in as the user
+	// +optional
+	SSHPublicKeys []string `json:"sshPublicKeys,omitempty"`
+	// SSHPublicKeySecretRef selects newline separated public keys authorized to log in as the user
+	// from a Secret in the namespace of the VirtualServer.
+	// Mutually exclusive with the SSH public keys set in the spec.
+	// +optional
+	SSHPublicKeySecretRef *corev1.SecretKeySelector `json:"sshPublicKeySecretRef,omitempty"`
+	// Groups the user is added to.
+	// Defaults to sudo on Linux.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+	// Sudo rules of the user on Linux.
+	// Defaults to ALL=(ALL) NOPASSWD:ALL.
+	// +optional
+	Sudo []string `json:"sudo,omitempty"`
+	// Shell is the login shell of the user on Linux.
+	// Defaults to /bin/bash.
+	// +optional
+	Shell string `json:"shell,omitempty"`
+	// LockPassword disables password login for the user.
+	// Defaults to true if no password is set.
+	// +optional
+	LockPassword *bool `json:"lockPassword,omitempty"`
+	// Administrator adds the user to the Administrators group on Windows
+	// +optional
+	Administrator bool `json:"administrator,omitempty"`
 }
 
-// GetSize returns total size of fields in VirtualServerUser struct
+// GetSize returns total size of fields in VirtualServerUser struct.
+// Values referenced from Secrets are not included.
+//
+// Deprecated: GetSize does not account for the rendered cloud-init, such as the default groups, sudo rules and shell
+// or the YAML keys, and is not counted against any limit. Use (*VirtualServer).CloudInitSecret or render.CloudInitBudget
+// for the size of the cloud-init Secret.
 func (vsu *VirtualServerUser) GetSize() int {
 	var totalSize int = 0
 	totalSize += len(vsu.Username)
 	totalSize += len(vsu.Password)
+	totalSize += len(vsu.HashedPassword)
+	totalSize += len(vsu.Shell)
+	for _, key := range vsu.AuthorizedKeys() {
+		totalSize += len(key)
+	}
+	for _, group := range vsu.Groups {
+		totalSize += len(group)
+	}
+	for _, rule := range vsu.Sudo {
+		totalSize += len(rule)
+	}
 	return totalSize
 }
 
+// AuthorizedKeys returns the keys of SSHPublicKey followed by SSHPublicKeys, without empty or duplicate keys
+func (vsu *VirtualServerUser) AuthorizedKeys() []string {
+	return mergeStrings(strings.Split(vsu.SSHPublicKey, "\n"), vsu.SSHPublicKeys)
+}
+
+// mergeStrings returns the non-empty strings of a followed by those of b, without duplicates
+func mergeStrings(a, b []string) []string {
+	var merged []string
+	seen := map[string]bool{}
+	for _, list := range [][]string{a, b} {
+		for _, s := range list {
+			if s != "" && !seen[s] {
+				merged = append(merged, s)
+				seen[s] = true
+			}
+		}
+	}
+	return merged
+}
+
 // VirtualServerNetwork defines the network configuration of the VirtualServer
 type VirtualServerNetwork struct {
 	// If enabled, a Service will be dynamically created, and its IP directly attached to the VirtualServer
@@ -261,6 +457,8 @@ type VirtualServerNetwork struct {
 	DirectAttachLoadBalancerIP bool `json:"directAttachLoadBalancerIP,omitempty"`
 	// FloatingIPs is an array of LoadBalancer Services
 	// The Services LoadBalancer IPs will be used for the floating IPs of the VirtualServer
+	// +listType=map
+	// +listMapKey=serviceName
 	FloatingIPs []VirtualServerFloatingIP `json:"floatingIPs,omitempty"`
 	// TCP describes a list of tcp ports that are exposed by the VirtualServer
 	// A Service will be dynamically created and linked to the VirtualServer
@@ -293,6 +491,8 @@ type VirtualServerNetwork struct {
 	Headless bool `json:"headless,omitempty"`
 	// List of VPC networks
 	// +optional
+	// +listType=map
+	// +listMapKey=name
 	VPCs []VirtualServerVPC `json:"vpcs,omitempty"`
 	// Disable kubernetes pod network within the Virtual Server
 	// Useful for isolating a Virtual Server in VPC networks
@@ -302,6 +502,10 @@ type VirtualServerNetwork struct {
 // VirtualServerVPC defines a VPC network for the Virtual Server to join
 type VirtualServerVPC struct {
 	Name string `json:"name"`
+	// MACAddress of the VPC interface, used to match the interface in the network data. It must be a local unicast type.
+	// +optional
+	// +kubebuilder:validation:Pattern="^[0-9a-f][26ae][:]([0-9a-f]{2}[:]){4}([0-9a-f]{2})|[0-9A-F][26AE][-]([0-9A-F]{2}[-]){4}([0-9A-F]{2})$"
+	MACAddress string `json:"macAddress,omitempty"`
 }
 
 // VirtualServerServiceTemplate defines a service created by the VirtualServer
@@ -329,6 +533,39 @@ type VirtualServerNetworkStatus struct {
 	FloatingIPs map[string]string `json:"floatingIPs,omitempty"`
 }
 
+// VirtualServerRootDiskResizeState is the state of a root disk resize
+// +kubebuilder:validation:Enum=VolumeResizing;FileSystemResizePending;GuestResizePending;Complete
+type VirtualServerRootDiskResizeState string
+
+const (
+	// The root disk volume is being expanded to the requested size
+	VirtualServerRootDiskResizeStateVolumeResizing VirtualServerRootDiskResizeState = "VolumeResizing"
+	// The root disk volume has been expanded, but the filesystem has not been resized.
+	// The guest sees the new capacity once the filesystem is resized, which may require the VirtualServer to be restarted.
+	VirtualServerRootDiskResizeStateFileSystemResizePending VirtualServerRootDiskResizeState = "FileSystemResizePending"
+	// The root disk volume has the requested capacity, but the guest does not see it yet.
+	// The guest sees the new capacity once the volume is expanded online, or the VirtualServer is restarted.
+	VirtualServerRootDiskResizeStateGuestResizePending VirtualServerRootDiskResizeState = "GuestResizePending"
+	// The root disk has the requested size and the guest sees the new capacity
+	VirtualServerRootDiskResizeStateComplete VirtualServerRootDiskResizeState = "Complete"
+)
+
+// VirtualServerRootDiskStatus describes the observed state of the root disk
+type VirtualServerRootDiskStatus struct {
+	// RequestedSize is the size of the root disk requested in the spec
+	// +optional
+	RequestedSize *resource.Quantity `json:"requestedSize,omitempty"`
+	// Capacity is the current capacity of the root disk volume
+	// +optional
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+	// GuestSize is the size of the root disk seen by the running guest, as reported by the VirtualMachineInstance
+	// +optional
+	GuestSize *resource.Quantity `json:"guestSize,omitempty"`
+	// ResizeState is the state of the last resize of the root disk, empty if the root disk was never resized
+	// +optional
+	ResizeState VirtualServerRootDiskResizeState `json:"resizeState,omitempty"`
+}
+
 type VirtualServerOSType string
 
 const (
@@ -338,6 +575,35 @@ const (
 	VirtualServerOSTypeWindows VirtualServerOSType = "windows"
 )
 
+// VirtualServerProvisioner is the first boot provisioning agent of the VirtualServer operating system
+type VirtualServerProvisioner string
+
+const (
+	// VirtualServerProvisionerCloudInit provisions the VirtualServer with cloud-init, or cloudbase-init on Windows
+	VirtualServerProvisionerCloudInit VirtualServerProvisioner = "cloud-init"
+	// VirtualServerProvisionerIgnition provisions the VirtualServer with Ignition, as used by Fedora CoreOS and Flatcar
+	VirtualServerProvisionerIgnition VirtualServerProvisioner = "ignition"
+)
+
+// VirtualServerPhase is a high level summary of the lifecycle of a VirtualServer
+// +kubebuilder:validation:Enum=Pending;Provisioning;Running;Stopped;Failed;Terminating
+type VirtualServerPhase string
+
+const (
+	// VirtualServerPhasePending indicates that the VirtualServer has not yet started provisioning
+	VirtualServerPhasePending VirtualServerPhase = "Pending"
+	// VirtualServerPhaseProvisioning indicates that the resources of the VirtualServer are being created or updated
+	VirtualServerPhaseProvisioning VirtualServerPhase = "Provisioning"
+	// VirtualServerPhaseRunning indicates that the VirtualServer is ready for use
+	VirtualServerPhaseRunning VirtualServerPhase = "Running"
+	// VirtualServerPhaseStopped indicates that the VirtualServer has been stopped
+	VirtualServerPhaseStopped VirtualServerPhase = "Stopped"
+	// VirtualServerPhaseFailed indicates that the VirtualServer could not be created or started
+	VirtualServerPhaseFailed VirtualServerPhase = "Failed"
+	// VirtualServerPhaseTerminating indicates that the VirtualServer is being deleted
+	VirtualServerPhaseTerminating VirtualServerPhase = "Terminating"
+)
+
 type VirtualServerConditionType string
 
 const (
@@ -349,7 +615,7 @@ const (
 	VSConditionTypeServicesReady VirtualServerConditionType = "ServicesReady"
 	// VSConditionTypeVMReady describes the ready state of the underlying VirtualMachine
 	VSConditionTypeVMReady VirtualServerConditionType = "VirtualMachineReady"
-	// VSConditionTypeServicesReady describes the ready state of the services dynamically created and/or those required by the VirtualServer
+	// VSConditionTypeSecretReady describes the ready state of the cloud-init secret required by the VirtualServer
 	VSConditionTypeSecretReady VirtualServerConditionType = "SecretReady"
 )
 
@@ -0,0 +1,331 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coreweave/virtual-server/cloudinit"
+	"github.com/coreweave/virtual-server/ignition"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// defaultSudo is the sudo rule of Linux users without sudo rules
+	defaultSudo = "ALL=(ALL) NOPASSWD:ALL"
+	// defaultGroup is the group of Linux users without groups
+	defaultGroup = "sudo"
+	// defaultShell is the shell of Linux users without a shell
+	defaultShell = "/bin/bash"
+	// windowsAdministratorsGroup is the group of Windows administrator users
+	windowsAdministratorsGroup = "Administrators"
+	// ignitionDefaultGroup is the group of Ignition users without groups, the administrators group of Fedora CoreOS and Flatcar
+	ignitionDefaultGroup = "wheel"
+)
+
+// SecretValueFunc returns the value of the selected Secret key in the namespace of the VirtualServer
+// +kubebuilder:object:generate=false
+type SecretValueFunc func(selector *corev1.SecretKeySelector) (string, error)
+
+// cloudInitUser is the cloud-config representation of a VirtualServerUser
+type cloudInitUser struct {
+	Name              string   `yaml:"name"`
+	Sudo              []string `yaml:"sudo"`
+	Groups            string   `yaml:"groups"`
+	Shell             string   `yaml:"shell"`
+	LockPasswd        bool     `yaml:"lock_passwd"`
+	PlainTextPasswd   string   `yaml:"plain_text_passwd,omitempty"`
+	HashedPasswd      string   `yaml:"hashed_passwd,omitempty"`
+	SSHAuthorizedKeys []string `yaml:"ssh_authorized_keys,omitempty"`
+}
+
+// windowsCloudInitUser is the cloudbase-init representation of a VirtualServerUser
+type windowsCloudInitUser struct {
+	Name              string   `yaml:"name"`
+	Groups            []string `yaml:"groups,omitempty"`
+	Passwd            string   `yaml:"passwd,omitempty"`
+	SSHAuthorizedKeys []string `yaml:"ssh_authorized_keys,omitempty"`
+}
+
+// CloudInitSecret returns the data of the cloud-init Secret of the VirtualServer, holding the user data and network data.
+// The user data is the Ignition config when the provisioner is ignition, and the cloud-config otherwise.
+// secretValue resolves the Secret references of the users; when nil, referenced values are counted as empty.
+func (vs *VirtualServer) CloudInitSecret(secretValue SecretValueFunc) (*cloudinit.Secret, error) {
+	return vs.Spec.cloudInitSecret(secretValue)
+}
+
+// cloudInitSecret returns the data of the cloud-init Secret of the spec, see VirtualServer.CloudInitSecret
+func (spec *VirtualServerSpec) cloudInitSecret(secretValue SecretValueFunc) (*cloudinit.Secret, error) {
+	if secretValue == nil {
+		secretValue = func(*corev1.SecretKeySelector) (string, error) { return "", nil }
+	}
+	usesIgnition := spec.OS.Provisioner == VirtualServerProvisionerIgnition
+	generate := spec.cloudInitUserData
+	if usesIgnition {
+		generate = spec.ignitionUserData
+	}
+	userData, err := generate(secretValue)
+	if err != nil {
+		return nil, err
+	}
+	return cloudinit.NewSecret(userData, spec.NetworkData, !usesIgnition)
+}
+
+// checkCloudInitSecret returns an error if the cloud-init Secret of the spec exceeds corev1.MaxSecretSize,
+// not counting values referenced from Secrets. Invalid cloud-init is not reported, see Validate.
+func (spec *VirtualServerSpec) checkCloudInitSecret() error {
+	secret, err := spec.cloudInitSecret(nil)
+	if err != nil {
+		return nil
+	}
+	return secret.Budget.Err()
+}
+
+// cloudInitUserData returns the cloud-config configuring the users and filesystem mounts,
+// merged with the cloud-config parts of the spec cloud-init, in order.
+// Parts are merged following their merge_how, as cloud-init does. Without merge_how, users and mounts of the part are
+// appended, and any other key replaces the configuration of the previous parts.
+// When the spec cloud-init has scripts, boothooks or jinja templates, multipart user data is returned with the
+// merged cloud-config first, followed by the other parts in order.
+func (spec *VirtualServerSpec) cloudInitUserData(secretValue SecretValueFunc) (string, error) {
+	config := map[string]interface{}{}
+
+	var users []interface{}
+	passwordAuth := false
+	for _, user := range spec.Users {
+		password, keys, err := user.secrets(secretValue)
+		if err != nil {
+			return "", err
+		}
+		if spec.OS.Type == VirtualServerOSTypeWindows {
+			users = append(users, windowsUser(user, password, keys))
+			continue
+		}
+		u := linuxUser(user, password, keys)
+		passwordAuth = passwordAuth || (u.PlainTextPasswd != "" || u.HashedPasswd != "") && !u.LockPasswd
+		users = append(users, u)
+	}
+	if len(users) > 0 {
+		config["users"] = users
+		if spec.OS.Type != VirtualServerOSTypeWindows {
+			config["ssh_pwauth"] = passwordAuth
+		}
+	}
+
+	var mounts []interface{}
+	for _, fs := range spec.Storage.FileSystems {
+		if fs.Mountpoint != nil {
+			mounts = append(mounts, []string{fs.Name, *fs.Mountpoint, "virtiofs", "defaults", "0", "0"})
+		}
+	}
+	if len(mounts) > 0 {
+		config["mounts"] = mounts
+	}
+
+	parts, err := cloudinit.ParseUserData(spec.CloudInit)
+	if err != nil {
+		return "", err
+	}
+	var others []cloudinit.Part
+	for _, part := range parts {
+		if part.ContentType != cloudinit.ContentTypeCloudConfig {
+			others = append(others, part)
+			continue
+		}
+		custom := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(part.Content), &custom); err != nil {
+			return "", err
+		}
+		how, err := cloudinit.ParseMergeHow(custom)
+		if err != nil {
+			return "", fmt.Errorf("merge_how: %w", err)
+		}
+		if how == nil {
+			how = &cloudinit.DefaultMergeHow
+			for _, key := range []string{"users", "mounts"} {
+				if list, ok := custom[key].([]interface{}); ok {
+					if generated, ok := config[key].([]interface{}); ok {
+						custom[key] = append(generated, list...)
+					}
+				}
+			}
+		}
+		cloudinit.Merge(config, custom, *how)
+	}
+
+	cloudConfig := ""
+	if len(config) > 0 {
+		out, err := yaml.Marshal(config)
+		if err != nil {
+			return "", err
+		}
+		cloudConfig = cloudinit.Header + "\n" + string(out)
+	}
+	if len(others) == 0 {
+		return cloudConfig, nil
+	}
+	if cloudConfig != "" {
+		others = append([]cloudinit.Part{{ContentType: cloudinit.ContentTypeCloudConfig, Filename: "cloud-config.txt", Content: cloudConfig}}, others...)
+	}
+	return cloudinit.MarshalMultiPart(others...)
+}
+
+// secrets returns the plaintext password and the SSH public keys of the user, resolving its Secret references
+func (vsu *VirtualServerUser) secrets(secretValue SecretValueFunc) (string, []string, error) {
+	password := vsu.Password
+	if vsu.PasswordSecretRef != nil {
+		value, err := secretValue(vsu.PasswordSecretRef)
+		if err != nil {
+			return "", nil, fmt.Errorf("password of user %s: %w", vsu.Username, err)
+		}
+		password = value
+	}
+	keys := vsu.AuthorizedKeys()
+	if vsu.SSHPublicKeySecretRef != nil {
+		value, err := secretValue(vsu.SSHPublicKeySecretRef)
+		if err != nil {
+			return "", nil, fmt.Errorf("SSH public keys of user %s: %w", vsu.Username, err)
+		}
+		keys = (&VirtualServerUser{SSHPublicKey: value}).AuthorizedKeys()
+	}
+	return password, keys, nil
+}
+
+// linuxUser returns the cloud-config user, defaulting the groups, sudo rules, shell and password lock
+func linuxUser(user VirtualServerUser, password string, keys []string) cloudInitUser {
+	u := cloudInitUser{
+		Name:              user.Username,
+		Sudo:              user.Sudo,
+		Groups:            strings.Join(user.Groups, ", "),
+		Shell:             user.Shell,
+		LockPasswd:        password == "" && user.HashedPassword == "",
+		PlainTextPasswd:   password,
+		HashedPasswd:      user.HashedPassword,
+		SSHAuthorizedKeys: keys,
+	}
+	if len(u.Sudo) == 0 {
+		u.Sudo = []string{defaultSudo}
+	}
+	if u.Groups == "" {
+		u.Groups = defaultGroup
+	}
+	if u.Shell == "" {
+		u.Shell = defaultShell
+	}
+	if user.LockPassword != nil {
+		u.LockPasswd = *user.LockPassword
+	}
+	return u
+}
+
+// windowsUser returns the cloudbase-init user, adding administrators to the Administrators group
+func windowsUser(user VirtualServerUser, password string, keys []string) windowsCloudInitUser {
+	u := windowsCloudInitUser{
+		Name:              user.Username,
+		Groups:            user.Groups,
+		Passwd:            password,
+		SSHAuthorizedKeys: keys,
+	}
+	if user.Administrator {
+		u.Groups = append(append([]string(nil), user.Groups...), windowsAdministratorsGroup)
+	}
+	return u
+}
+
+// ignitionUserData returns the Ignition config configuring the users, filesystem mounts and hostname,
+// merged with the spec ignition config. Sudo rules are written to /etc/sudoers.d, as Ignition users have none.
+// An empty string is returned when there is nothing to configure.
+func (spec *VirtualServerSpec) ignitionUserData(secretValue SecretValueFunc) (string, error) {
+	config := &ignition.Config{}
+	for _, user := range spec.Users {
+		_, keys, err := user.secrets(secretValue)
+		if err != nil {
+			return "", err
+		}
+		u := ignition.User{
+			Name:              user.Username,
+			PasswordHash:      user.HashedPassword,
+			SSHAuthorizedKeys: keys,
+			Groups:            user.Groups,
+			Shell:             user.Shell,
+		}
+		if len(u.Groups) == 0 {
+			u.Groups = []string{ignitionDefaultGroup}
+		}
+		config.AddUser(u)
+
+		sudo := user.Sudo
+		if len(sudo) == 0 {
+			sudo = []string{defaultSudo}
+		}
+		var rules strings.Builder
+		for _, rule := range sudo {
+			fmt.Fprintf(&rules, "%s %s\n", user.Username, rule)
+		}
+		config.AddFile(ignition.NewFile("/etc/sudoers.d/"+sudoersFileName(user.Username), rules.String(), 0440))
+	}
+
+	for _, fs := range spec.Storage.FileSystems {
+		if fs.Mountpoint == nil {
+			continue
+		}
+		config.AddUnit(ignition.NewUnit(mountUnitName(*fs.Mountpoint), fmt.Sprintf(`[Unit]
+Description=Mount %s
+
+[Mount]
+What=%s
+Where=%s
+Type=virtiofs
+Options=defaults
+
+[Install]
+WantedBy=local-fs.target
+`, fs.Name, fs.Name, *fs.Mountpoint)))
+	}
+
+	if spec.MetaData != nil && spec.MetaData.LocalHostname != "" {
+		config.AddFile(ignition.NewFile("/etc/hostname", spec.MetaData.LocalHostname+"\n", 0644))
+	}
+	if spec.Ignition != "" {
+		config.Merge(spec.Ignition)
+	}
+
+	if config.Passwd == nil && config.Storage == nil && config.Systemd == nil && config.Ignition.Config == nil {
+		return "", nil
+	}
+	return config.Marshal()
+}
+
+// sudoersFileName returns the name of the /etc/sudoers.d file of the user.
+// sudo skips files whose names contain a '.' or end with '~', so any character other than letters, digits, '-' and
+// '_' is replaced with '_'.
+func sudoersFileName(username string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, username)
+}
+
+// mountUnitName returns the name of the systemd mount unit of the mountpoint, escaped as systemd-escape --path does
+func mountUnitName(mountpoint string) string {
+	p := strings.Trim(mountpoint, "/")
+	if p == "" {
+		return "-.mount"
+	}
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		switch {
+		case c == '/':
+			b.WriteByte('-')
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '.' && i > 0:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, `\x%02x`, c)
+		}
+	}
+	return b.String() + ".mount"
+}
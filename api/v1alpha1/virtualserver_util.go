@@ -4,13 +4,19 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 
-	"gopkg.in/yaml.v2"
+	"github.com/coreweave/virtual-server/cloudinit"
+	"github.com/coreweave/virtual-server/ignition"
+	"github.com/coreweave/virtual-server/unattend"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	kvv1 "kubevirt.io/api/core/v1"
 	cdiv1beta "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
 )
@@ -18,6 +24,9 @@ import (
 const MacAddressRegEx = `^[0-9a-f][26ae][:]([0-9a-f]{2}[:]){4}([0-9a-f]{2})|[0-9A-F][26AE][-]([0-9A-F]{2}[-]){4}([0-9A-F]{2})$`
 const FirmwareSerialRegEx = `^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`
 
+// HashedPasswordRegEx matches passwords hashed in crypt(3) format, such as $6$salt$hash or $y$j9T$salt$hash
+const HashedPasswordRegEx = `^\$[0-9a-z]+(\$[^$:\s]+)+$`
+
 // IsGpuServer returns true if the VirtualServer is GPU enabled
 func (vs *VirtualServer) IsGpuServer() bool {
 	return vs.Spec.Resources.GPU.Type != nil
@@ -45,7 +54,11 @@ func (vs *VirtualServer) SystemType() string {
 
 // Set the status condition of the virtualServer.
 // If message is nil, the condition message will be set to a string casted form of reason.
-// If applyToTopLevelCondition is true the status and message will be applied to the top level, VSConditionTypeReady, condition as well
+// If applyToTopLevelCondition is true the top level, VSConditionTypeReady, condition is derived from the
+// ServicesReady, VirtualMachineReady, SecretReady and VirtualServerStarted conditions.
+// The condition is set as given, use ValidateConditionTransition to check it against the current condition first.
+// The status phase and summarized condition fields are updated to reflect the conditions.
+// The condition and the status are stamped with the generation of the VirtualServer.
 func (vs *VirtualServer) SetCondition(
 	conditionType VirtualServerConditionType,
 	status metav1.ConditionStatus,
@@ -58,19 +71,20 @@ func (vs *VirtualServer) SetCondition(
 		msg = *message
 	}
 	condition := metav1.Condition{
-		Type:    string(conditionType),
-		Status:  status,
-		Reason:  string(reason),
-		Message: msg,
-	}
-
-	if applyToTopLevelCondition {
-		topLevelCondition := apimeta.FindStatusCondition(vs.Status.Conditions, string(VSConditionTypeReady))
-		topLevelCondition.Status = condition.Status
-		topLevelCondition.Message = condition.Message
+		Type:               string(conditionType),
+		Status:             status,
+		ObservedGeneration: vs.Generation,
+		Reason:             string(reason),
+		Message:            msg,
 	}
 
 	apimeta.SetStatusCondition(&vs.Status.Conditions, condition)
+
+	if applyToTopLevelCondition && conditionType != VSConditionTypeReady {
+		vs.updateReadyCondition()
+	}
+	vs.Status.SetObservedGeneration(vs.Generation)
+	vs.UpdateStatusSummary()
 }
 
 // UpdateVirtualMachineStartedCondition will set the VirtualServer conditions that indicate that the underlying VirtualMachine has started
@@ -80,7 +94,7 @@ func (vs *VirtualServer) UpdateVirtualMachineStartedCondition(running bool) {
 	if running {
 		status = metav1.ConditionTrue
 		reason = VSConditionReasonStarted
-	} else if apimeta.FindStatusCondition(vs.Status.Conditions, string(VSConditionTypeReady)).Status != metav1.ConditionTrue {
+	} else if ready := vs.GetReadyStatus(); ready == nil || ready.Status != metav1.ConditionTrue {
 		status = metav1.ConditionUnknown
 		reason = VSConditionReasonPending
 	} else {
@@ -90,13 +104,19 @@ func (vs *VirtualServer) UpdateVirtualMachineStartedCondition(running bool) {
 	vs.SetCondition(VSConditionTypeStarted, status, reason, nil, false)
 }
 
-// InitializeStatus sets the default VirtualServer status and conditions
+// InitializeStatus sets the default VirtualServer status and conditions. Conditions of other types are kept.
+// The conditions are stamped with the generation of the VirtualServer.
 func (vs *VirtualServer) InitializeStatus() {
 	vs.Status.Network.FloatingIPs = make(map[string]string)
-	vs.SetCondition(VSConditionTypeReady, metav1.ConditionUnknown, VSConditionReasonInitializing, nil, false)
-	vs.SetCondition(VSConditionTypeServicesReady, metav1.ConditionUnknown, VSConditionReasonInitializing, nil, false)
-	vs.SetCondition(VSConditionTypeVMReady, metav1.ConditionUnknown, VSConditionReasonInitializing, nil, false)
-	vs.SetCondition(VSConditionTypeStarted, metav1.ConditionUnknown, VSConditionReasonInitializing, nil, false)
+	for _, conditionType := range []VirtualServerConditionType{
+		VSConditionTypeReady,
+		VSConditionTypeServicesReady,
+		VSConditionTypeVMReady,
+		VSConditionTypeStarted,
+		VSConditionTypeSecretReady,
+	} {
+		vs.SetCondition(conditionType, metav1.ConditionUnknown, VSConditionReasonInitializing, nil, false)
+	}
 }
 
 // HasNoConditions returns true if the VirtualServer has no conditions defined
@@ -119,17 +139,94 @@ func (vs *VirtualServer) SetRegion(region string) {
 	vs.Spec.Region = region
 }
 
-// Set the VirtualServer OS
+// Set the VirtualServer OS type, keeping the other OS settings such as the provisioner
 func (vs *VirtualServer) SetOS(os VirtualServerOSType) {
-	vs.Spec.OS = VirtualServerOS{
-		Type: os,
-	}
+	vs.Spec.OS.Type = os
 }
 
 func (vs *VirtualServer) EnableUEFIBoot(enable bool) {
 	vs.Spec.OS.EnableUEFIBoot = enable
 }
 
+// SetProvisioner sets the first boot provisioning agent of the VirtualServer image
+func (vs *VirtualServer) SetProvisioner(provisioner VirtualServerProvisioner) {
+	vs.Spec.OS.Provisioner = provisioner
+}
+
+// UsesIgnition returns true if the VirtualServer users, filesystem mounts and hostname are provisioned with Ignition
+func (vs *VirtualServer) UsesIgnition() bool {
+	return vs.Spec.OS.Provisioner == VirtualServerProvisionerIgnition
+}
+
+// windows returns the Windows configuration of the VirtualServer, creating it if needed
+func (vs *VirtualServer) windows() *VirtualServerWindows {
+	if vs.Spec.OS.Windows == nil {
+		vs.Spec.OS.Windows = &VirtualServerWindows{}
+	}
+	return vs.Spec.OS.Windows
+}
+
+// SetWindowsComputerName sets the NetBIOS computer name of the Windows Virtual Server
+func (vs *VirtualServer) SetWindowsComputerName(computerName string) error {
+	if errs := (&unattend.Config{ComputerName: computerName}).Validate(field.NewPath("computerName")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	vs.windows().ComputerName = computerName
+	return nil
+}
+
+// SetWindowsTimeZone sets the Windows time zone ID of the Windows Virtual Server, such as Pacific Standard Time
+func (vs *VirtualServer) SetWindowsTimeZone(timeZone string) {
+	vs.windows().TimeZone = timeZone
+}
+
+// SetWindowsLocale sets the language and region of the Windows Virtual Server, such as en-US
+func (vs *VirtualServer) SetWindowsLocale(locale string) error {
+	if errs := (&unattend.Config{Locale: locale}).Validate(field.NewPath("locale")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	vs.windows().Locale = locale
+	return nil
+}
+
+// SetWindowsProductKey sets the product key of the Windows Virtual Server
+func (vs *VirtualServer) SetWindowsProductKey(productKey string) error {
+	if errs := (&unattend.Config{ProductKey: productKey}).Validate(field.NewPath("productKey")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	vs.windows().ProductKey = productKey
+	return nil
+}
+
+// SetWindowsAdministratorPasswordSecretRef sets the password of the built-in Administrator account to the key of the Secret
+func (vs *VirtualServer) SetWindowsAdministratorPasswordSecretRef(secretName string, key string) {
+	vs.windows().AdministratorPasswordSecretRef = secretKeySelector(secretName, key)
+}
+
+// AddWindowsFirstLogonCommand adds a command run the first time a user logs on to the Windows Virtual Server
+func (vs *VirtualServer) AddWindowsFirstLogonCommand(command string) {
+	w := vs.windows()
+	w.FirstLogonCommands = append(w.FirstLogonCommands, command)
+}
+
+// EnableWindowsRDP enables Remote Desktop on the Windows Virtual Server
+func (vs *VirtualServer) EnableWindowsRDP(enable bool) {
+	vs.windows().EnableRDP = enable
+}
+
+// UnattendConfig returns the answer file configuration with the Administrator password resolved from its Secret reference
+func (w *VirtualServerWindows) UnattendConfig(administratorPassword string) *unattend.Config {
+	return &unattend.Config{
+		ComputerName:          w.ComputerName,
+		TimeZone:              w.TimeZone,
+		Locale:                w.Locale,
+		ProductKey:            w.ProductKey,
+		AdministratorPassword: administratorPassword,
+		FirstLogonCommands:    w.FirstLogonCommands,
+		EnableRDP:             w.EnableRDP,
+	}
+}
+
 // Set the VirtualServer resource definition
 func (vs *VirtualServer) SetResourceDefinition(definitionVersion string) {
 	vs.Spec.Resources.Definition = definitionVersion
@@ -180,37 +277,195 @@ func (vs *VirtualServer) SetMemory(memory string) error {
 // Add a user to the VirtualServer
 // The user will be used to configure the VirtualServer via cloudinit if supported
 func (vs *VirtualServer) AddUser(user VirtualServerUser) {
-	for i, u := range vs.Spec.Users {
-		if u.Username == user.Username {
-			vs.Spec.Users[i].Password = user.Password
-			vs.Spec.Users[i].SSHPublicKey = user.SSHPublicKey
+	for i := range vs.Spec.Users {
+		if vs.Spec.Users[i].Username == user.Username {
+			vs.Spec.Users[i].merge(user)
 			return
 		}
 	}
 	vs.Spec.Users = append(vs.Spec.Users, user)
 }
 
-//Add custom CloudInit attribute to VirtualServer
-func (vs *VirtualServer) AddCloudInit(cloudInit string) error {
-	// Current size of the VS' script is about 250 characeters
-	var totalUsersSize int = 0
+// RemoveUser removes the user, returning false if the VirtualServer has no user with the username
+func (vs *VirtualServer) RemoveUser(username string) bool {
 	for i := range vs.Spec.Users {
-		totalUsersSize += vs.Spec.Users[i].GetSize()
+		if vs.Spec.Users[i].Username == username {
+			vs.Spec.Users = append(vs.Spec.Users[:i], vs.Spec.Users[i+1:]...)
+			return true
+		}
 	}
-	MaxCustomCloudInitLength := corev1.MaxSecretSize - totalUsersSize
+	return false
+}
 
-	if len(cloudInit) <= MaxCustomCloudInitLength {
-		vs.Spec.CloudInit = cloudInit
-		return nil
+// merge adds the keys, groups and sudo rules of other to the user.
+// The password, shell and lockPassword of other replace those of the user when set.
+// A password or SSH public key Secret reference of other replaces the other forms of the user.
+func (vsu *VirtualServerUser) merge(other VirtualServerUser) {
+	if other.HasPassword() {
+		vsu.Password = other.Password
+		vsu.HashedPassword = other.HashedPassword
+		vsu.PasswordSecretRef = other.PasswordSecretRef
+	}
+	if other.SSHPublicKeySecretRef != nil {
+		vsu.SSHPublicKeySecretRef = other.SSHPublicKeySecretRef
+		vsu.SSHPublicKey = ""
+		vsu.SSHPublicKeys = nil
+	} else if len(other.AuthorizedKeys()) > 0 {
+		vsu.SSHPublicKeySecretRef = nil
+		vsu.SSHPublicKey = strings.Join(mergeStrings(strings.Split(vsu.SSHPublicKey, "\n"), strings.Split(other.SSHPublicKey, "\n")), "\n")
+		vsu.SSHPublicKeys = mergeStrings(vsu.SSHPublicKeys, other.SSHPublicKeys)
+	}
+	vsu.Groups = mergeStrings(vsu.Groups, other.Groups)
+	vsu.Sudo = mergeStrings(vsu.Sudo, other.Sudo)
+	if other.Shell != "" {
+		vsu.Shell = other.Shell
+	}
+	if other.LockPassword != nil {
+		vsu.LockPassword = other.LockPassword
+	}
+	vsu.Administrator = vsu.Administrator || other.Administrator
+}
+
+// HasPassword returns true if a plaintext, hashed or Secret referenced password is set for the user
+func (vsu *VirtualServerUser) HasPassword() bool {
+	return vsu.Password != "" || vsu.HashedPassword != "" || vsu.PasswordSecretRef != nil
+}
+
+// SetPasswordSecretRef sets the password of the user to the key of the Secret, replacing any other password
+func (vsu *VirtualServerUser) SetPasswordSecretRef(secretName string, key string) {
+	vsu.Password = ""
+	vsu.HashedPassword = ""
+	vsu.PasswordSecretRef = secretKeySelector(secretName, key)
+}
+
+// SetHashedPassword sets the crypt(3) hashed password of the user, replacing any other password
+func (vsu *VirtualServerUser) SetHashedPassword(hashedPassword string) error {
+	if !hashedPasswordRegExp.MatchString(hashedPassword) {
+		return fmt.Errorf("Hashed password must be in crypt(3) format")
+	}
+	vsu.Password = ""
+	vsu.HashedPassword = hashedPassword
+	vsu.PasswordSecretRef = nil
+	return nil
+}
+
+// SetSSHPublicKeySecretRef sets the SSH public keys of the user to the key of the Secret, replacing any keys set in the spec
+func (vsu *VirtualServerUser) SetSSHPublicKeySecretRef(secretName string, key string) {
+	vsu.SSHPublicKey = ""
+	vsu.SSHPublicKeys = nil
+	vsu.SSHPublicKeySecretRef = secretKeySelector(secretName, key)
+}
+
+func secretKeySelector(secretName string, key string) *corev1.SecretKeySelector {
+	return &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+		Key:                  key,
 	}
-	msg := fmt.Sprintf("Cloud-init script must be %d characters length or less", MaxCustomCloudInitLength)
-	return errors.New(msg)
 }
 
+// AddCloudInit sets the custom cloud-init of the VirtualServer, returning an error if the cloud-init Secret rendered
+// for the VirtualServer would exceed corev1.MaxSecretSize, see CloudInitSecret
+func (vs *VirtualServer) AddCloudInit(cloudInit string) error {
+	spec := vs.Spec.DeepCopy()
+	spec.CloudInit = cloudInit
+	if err := spec.checkCloudInitSecret(); err != nil {
+		return err
+	}
+	vs.Spec.CloudInit = cloudInit
+	return nil
+}
+
+// AddCloudConfig validates the cloud-config and sets it as the VirtualServer cloud-init, replacing any previous cloud-init
+func (vs *VirtualServer) AddCloudConfig(cfg *cloudinit.Config) error {
+	if errs := cfg.Validate(field.NewPath("cloudConfig")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	userData, err := cfg.Marshal()
+	if err != nil {
+		return err
+	}
+	return vs.AddCloudInit(userData)
+}
+
+// AddCloudInitParts validates the parts and sets them as multipart/mixed VirtualServer cloud-init, replacing any previous cloud-init.
+// Cloud-config parts are merged with the configuration generated for the VirtualServer users and filesystems, in order.
+func (vs *VirtualServer) AddCloudInitParts(parts ...cloudinit.Part) error {
+	userData, err := cloudinit.MarshalMultiPart(parts...)
+	if err != nil {
+		return err
+	}
+	if errs := cloudinit.ValidateUserData(userData, field.NewPath("cloudInit")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	return vs.AddCloudInit(userData)
+}
+
+// IsValidCloudInit checks each part of the VirtualServer cloud-init, see cloudinit.ValidateUserData
 func (vs *VirtualServer) IsValidCloudInit() error {
-	var cloudInit map[string]interface{}
-	cloudInitStr := vs.Spec.CloudInit
-	return yaml.Unmarshal([]byte(cloudInitStr), &cloudInit)
+	return cloudinit.ValidateUserData(vs.Spec.CloudInit, field.NewPath("spec", "cloudInit")).ToAggregate()
+}
+
+// SetIgnition validates the Ignition config and sets it as the VirtualServer ignition config, returning an error if
+// the Ignition config rendered for the VirtualServer would exceed the size of the cloud-init Secret
+func (vs *VirtualServer) SetIgnition(config string) error {
+	if errs := ignition.ValidateConfig(config, field.NewPath("ignition")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	spec := vs.Spec.DeepCopy()
+	spec.OS.Provisioner = VirtualServerProvisionerIgnition
+	spec.Ignition = config
+	if err := spec.checkCloudInitSecret(); err != nil {
+		return err
+	}
+	vs.Spec.Ignition = config
+	return nil
+}
+
+// AddIgnitionConfig validates the Ignition config and sets it as the VirtualServer ignition config, replacing any previous config
+func (vs *VirtualServer) AddIgnitionConfig(cfg *ignition.Config) error {
+	if errs := cfg.Validate(field.NewPath("ignitionConfig")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	config, err := cfg.Marshal()
+	if err != nil {
+		return err
+	}
+	return vs.SetIgnition(config)
+}
+
+// SetNetworkData validates the cloud-init network-config and sets it as the VirtualServer network data
+func (vs *VirtualServer) SetNetworkData(networkData string) error {
+	if errs := cloudinit.ValidateNetworkConfig(networkData, field.NewPath("networkData")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	spec := vs.Spec.DeepCopy()
+	spec.NetworkData = networkData
+	if err := spec.checkCloudInitSecret(); err != nil {
+		return err
+	}
+	vs.Spec.NetworkData = networkData
+	return nil
+}
+
+// SetNetworkConfig sets the network-config as the VirtualServer network data
+func (vs *VirtualServer) SetNetworkConfig(cfg *cloudinit.NetworkConfig) error {
+	networkData, err := cfg.Marshal()
+	if err != nil {
+		return err
+	}
+	return vs.SetNetworkData(networkData)
+}
+
+// SetLocalHostname sets the hostname of the Virtual Server in the cloud-init meta-data
+func (vs *VirtualServer) SetLocalHostname(hostname string) error {
+	if msgs := validation.IsDNS1123Label(hostname); len(msgs) > 0 {
+		return fmt.Errorf("invalid hostname %q: %s", hostname, strings.Join(msgs, ", "))
+	}
+	if vs.Spec.MetaData == nil {
+		vs.Spec.MetaData = &VirtualServerMetaData{}
+	}
+	vs.Spec.MetaData.LocalHostname = hostname
+	return nil
 }
 
 func (vs *VirtualServer) AddDNSConfig(dnsConfig *corev1.PodDNSConfig) {
@@ -237,6 +492,32 @@ func (vs *VirtualServer) AddVPC(vpcName string) {
 	vs.Spec.Network.VPCs = append(vs.Spec.Network.VPCs, VirtualServerVPC{Name: vpcName})
 }
 
+// RemoveVPC removes the VPC, returning false if the VirtualServer is not attached to the VPC
+func (vs *VirtualServer) RemoveVPC(vpcName string) bool {
+	vpcs := vs.Spec.Network.VPCs
+	for i := range vpcs {
+		if vpcs[i].Name == vpcName {
+			vs.Spec.Network.VPCs = append(vpcs[:i], vpcs[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// SetVPCMacAddress sets the MAC address of the interface of the VPC, so that it may be matched in the network data
+func (vs *VirtualServer) SetVPCMacAddress(vpcName string, macAddress string) error {
+	if !macAddressRegExp.MatchString(macAddress) {
+		return fmt.Errorf("invalid format of MAC address, it must be ff:ff:ff:ff:ff:ff or FF-FF-FF-FF-FF-FF")
+	}
+	for i := range vs.Spec.Network.VPCs {
+		if vs.Spec.Network.VPCs[i].Name == vpcName {
+			vs.Spec.Network.VPCs[i].MACAddress = macAddress
+			return nil
+		}
+	}
+	return fmt.Errorf("VPC %s not found", vpcName)
+}
+
 func (vs *VirtualServer) SetFirmwareSerial(serial string) error {
 	matched, err := regexp.MatchString(FirmwareSerialRegEx, serial)
 	if err != nil {
@@ -311,6 +592,26 @@ func (vs *VirtualServer) ExposeUDPPorts(ports []int32) error {
 	return nil
 }
 
+// UnexposeTCPPort removes the TCP port from the exposed ports, returning false if the port is not exposed
+func (vs *VirtualServer) UnexposeTCPPort(port int32) bool {
+	return unexposePort(&vs.Spec.Network.TCP.Ports, port)
+}
+
+// UnexposeUDPPort removes the UDP port from the exposed ports, returning false if the port is not exposed
+func (vs *VirtualServer) UnexposeUDPPort(port int32) bool {
+	return unexposePort(&vs.Spec.Network.UDP.Ports, port)
+}
+
+func unexposePort(ports *[]Port, port int32) bool {
+	for i, p := range *ports {
+		if int32(p) == port {
+			*ports = append((*ports)[:i], (*ports)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 func (vs *VirtualServer) exposePort(port int32, protocol corev1.Protocol) error {
 	if vs.Spec.Network.DirectAttachLoadBalancerIP != false {
 		return fmt.Errorf("Ports cannot be exposed if DirectAttachLoadBalancerIP is enabled")
@@ -323,8 +624,8 @@ func (vs *VirtualServer) exposePort(port int32, protocol corev1.Protocol) error
 		ports = &vs.Spec.Network.UDP.Ports
 	}
 
-	if len(*ports) >= 10 {
-		return fmt.Errorf("A maximum of 10 exposed ports are permitted")
+	if len(*ports) >= MaxExposedPorts {
+		return fmt.Errorf("A maximum of %d exposed ports are permitted", MaxExposedPorts)
 	}
 	for _, p := range *ports {
 		if int32(p) == port {
@@ -353,6 +654,18 @@ func (vs *VirtualServer) AddFloatingIP(loadBalancerServiceName string) {
 	})
 }
 
+// RemoveFloatingIP removes the floating IP of the load balancer Service, returning false if the VirtualServer has no such floating IP
+func (vs *VirtualServer) RemoveFloatingIP(loadBalancerServiceName string) bool {
+	fips := vs.Spec.Network.FloatingIPs
+	for i := range fips {
+		if fips[i].ServiceName == loadBalancerServiceName {
+			vs.Spec.Network.FloatingIPs = append(fips[:i], fips[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 type VirtualServerStorageRootPVCSource struct {
 	Size             string
 	PVCName          string
@@ -362,57 +675,165 @@ type VirtualServerStorageRootPVCSource struct {
 	AccessMode       corev1.PersistentVolumeAccessMode
 }
 
+func (source *VirtualServerStorageRootPVCSource) dataVolumeSource() cdiv1beta.DataVolumeSource {
+	return cdiv1beta.DataVolumeSource{
+		PVC: &cdiv1beta.DataVolumeSourcePVC{Name: source.PVCName, Namespace: source.PVCNamespace},
+	}
+}
+
 // Configure the root storage with a PVC as the source
 func (vs *VirtualServer) ConfigureStorageRootWithPVCSource(source VirtualServerStorageRootPVCSource) error {
-	sourcePVC := cdiv1beta.DataVolumeSourcePVC{
-		Name:      source.PVCName,
-		Namespace: source.PVCNamespace,
-	}
+	return vs.configureStorageRoot(source.Size, source.dataVolumeSource(), source.StorageClassName, source.VolumeMode, source.AccessMode)
+}
 
-	sz, err := resource.ParseQuantity(source.Size)
-	if err != nil {
-		return fmt.Errorf("Cound not parse size string")
+type VirtualServerStorageRootHTTPSource struct {
+	Size     string
+	ImageUrl string
+	// SecretRef is the name of a Secret with the accessKeyId and secretKey keys, the basic auth username and password
+	SecretRef string
+	// CertConfigMap is the name of a ConfigMap with the CA bundle of the HTTPS server
+	CertConfigMap    string
+	StorageClassName string
+	VolumeMode       corev1.PersistentVolumeMode
+	AccessMode       corev1.PersistentVolumeAccessMode
+}
+
+func (source *VirtualServerStorageRootHTTPSource) dataVolumeSource() cdiv1beta.DataVolumeSource {
+	return cdiv1beta.DataVolumeSource{
+		HTTP: &cdiv1beta.DataVolumeSourceHTTP{URL: source.ImageUrl, SecretRef: source.SecretRef, CertConfigMap: source.CertConfigMap},
 	}
+}
 
-	vs.Spec.Storage.Root = VirtualServerStorageRoot{
-		Size: sz,
-		Source: &cdiv1beta.DataVolumeSource{
-			PVC: &sourcePVC,
-		},
-		StorageClassName: source.StorageClassName,
-		VolumeMode:       source.VolumeMode,
-		AccessMode:       source.AccessMode,
+// Configure the root storage with an HTTP URL as the source
+func (vs *VirtualServer) ConfigureStorageRootWithHTTPSource(source VirtualServerStorageRootHTTPSource) error {
+	return vs.configureStorageRoot(source.Size, source.dataVolumeSource(), source.StorageClassName, source.VolumeMode, source.AccessMode)
+}
+
+// VirtualServerStorageRootRegistrySource describes an image imported from a container image registry
+type VirtualServerStorageRootRegistrySource struct {
+	Size string
+	// ImageUrl is the URL of the image, such as docker://quay.io/containerdisks/ubuntu:22.04
+	ImageUrl string
+	// PullSecretRef is the name of a Secret with the accessKeyId and secretKey keys, the registry username and password
+	PullSecretRef string
+	// CertConfigMap is the name of a ConfigMap with the CA bundle of the registry
+	CertConfigMap    string
+	StorageClassName string
+	VolumeMode       corev1.PersistentVolumeMode
+	AccessMode       corev1.PersistentVolumeAccessMode
+}
+
+func (source *VirtualServerStorageRootRegistrySource) dataVolumeSource() cdiv1beta.DataVolumeSource {
+	registry := &cdiv1beta.DataVolumeSourceRegistry{URL: &source.ImageUrl}
+	if source.PullSecretRef != "" {
+		registry.SecretRef = &source.PullSecretRef
 	}
-	return nil
+	if source.CertConfigMap != "" {
+		registry.CertConfigMap = &source.CertConfigMap
+	}
+	return cdiv1beta.DataVolumeSource{Registry: registry}
 }
 
-type VirtualServerStorageRootHTTPSource struct {
+// ConfigureStorageRootWithRegistrySource configures the root storage with a container image registry as the source
+func (vs *VirtualServer) ConfigureStorageRootWithRegistrySource(source VirtualServerStorageRootRegistrySource) error {
+	return vs.configureStorageRoot(source.Size, source.dataVolumeSource(), source.StorageClassName, source.VolumeMode, source.AccessMode)
+}
+
+// VirtualServerStorageRootS3Source describes an image imported from an S3 compatible bucket
+type VirtualServerStorageRootS3Source struct {
+	Size string
+	// ImageUrl is the URL of the object, such as https://object.ord1.coreweave.com/bucket/image.qcow2
+	ImageUrl string
+	// SecretRef is the name of a Secret with the accessKeyId and secretKey keys
+	SecretRef string
+	// CertConfigMap is the name of a ConfigMap with the CA bundle of the S3 endpoint
+	CertConfigMap    string
+	StorageClassName string
+	VolumeMode       corev1.PersistentVolumeMode
+	AccessMode       corev1.PersistentVolumeAccessMode
+}
+
+// ConfigureStorageRootWithS3Source configures the root storage with an S3 object as the source
+func (vs *VirtualServer) ConfigureStorageRootWithS3Source(source VirtualServerStorageRootS3Source) error {
+	return vs.configureStorageRoot(source.Size, cdiv1beta.DataVolumeSource{
+		S3: &cdiv1beta.DataVolumeSourceS3{URL: source.ImageUrl, SecretRef: source.SecretRef, CertConfigMap: source.CertConfigMap},
+	}, source.StorageClassName, source.VolumeMode, source.AccessMode)
+}
+
+// VirtualServerStorageRootBlankSource describes an empty root disk, such as the target of an installation from an ISO
+type VirtualServerStorageRootBlankSource struct {
 	Size             string
-	ImageUrl         string
 	StorageClassName string
 	VolumeMode       corev1.PersistentVolumeMode
 	AccessMode       corev1.PersistentVolumeAccessMode
 }
 
-// Configure the root storage with a url to an image as the source
-func (vs *VirtualServer) ConfigureStorageRootWithHTTPSource(source VirtualServerStorageRootHTTPSource) error {
-	sourceHTTP := cdiv1beta.DataVolumeSourceHTTP{
-		URL: source.ImageUrl,
-	}
+// ConfigureStorageRootWithBlankSource configures the root storage with a blank disk
+func (vs *VirtualServer) ConfigureStorageRootWithBlankSource(source VirtualServerStorageRootBlankSource) error {
+	return vs.configureStorageRoot(source.Size, cdiv1beta.DataVolumeSource{
+		Blank: &cdiv1beta.DataVolumeBlankImage{},
+	}, source.StorageClassName, source.VolumeMode, source.AccessMode)
+}
 
-	sz, err := resource.ParseQuantity(source.Size)
+// VirtualServerStorageRootUploadSource describes an image uploaded with virtctl image-upload
+type VirtualServerStorageRootUploadSource struct {
+	Size             string
+	StorageClassName string
+	VolumeMode       corev1.PersistentVolumeMode
+	AccessMode       corev1.PersistentVolumeAccessMode
+}
+
+// ConfigureStorageRootWithUploadSource configures the root storage to wait for an image uploaded with virtctl image-upload
+func (vs *VirtualServer) ConfigureStorageRootWithUploadSource(source VirtualServerStorageRootUploadSource) error {
+	return vs.configureStorageRoot(source.Size, cdiv1beta.DataVolumeSource{
+		Upload: &cdiv1beta.DataVolumeSourceUpload{},
+	}, source.StorageClassName, source.VolumeMode, source.AccessMode)
+}
+
+// VirtualServerStorageRootVDDKSource describes a VMware virtual machine disk imported with the VMware Virtual Disk Development Kit
+type VirtualServerStorageRootVDDKSource struct {
+	Size string
+	// Url is the URL of the vCenter or ESXi server, such as https://vcenter.example.com
+	Url string
+	// UUID is the UUID of the virtual machine
+	UUID string
+	// BackingFile is the path of the virtual disk file, such as [datastore] vm/vm.vmdk
+	BackingFile string
+	// Thumbprint is the SSL certificate thumbprint of the server
+	Thumbprint string
+	// SecretRef is the name of a Secret with the accessKeyId and secretKey keys, the vCenter or ESXi username and password
+	SecretRef        string
+	StorageClassName string
+	VolumeMode       corev1.PersistentVolumeMode
+	AccessMode       corev1.PersistentVolumeAccessMode
+}
+
+// ConfigureStorageRootWithVDDKSource configures the root storage with a VMware virtual machine disk as the source
+func (vs *VirtualServer) ConfigureStorageRootWithVDDKSource(source VirtualServerStorageRootVDDKSource) error {
+	return vs.configureStorageRoot(source.Size, cdiv1beta.DataVolumeSource{
+		VDDK: &cdiv1beta.DataVolumeSourceVDDK{
+			URL:         source.Url,
+			UUID:        source.UUID,
+			BackingFile: source.BackingFile,
+			Thumbprint:  source.Thumbprint,
+			SecretRef:   source.SecretRef,
+		},
+	}, source.StorageClassName, source.VolumeMode, source.AccessMode)
+}
+
+// configureStorageRoot replaces the root storage with a DataVolume of the source
+func (vs *VirtualServer) configureStorageRoot(size string, source cdiv1beta.DataVolumeSource, storageClassName string,
+	volumeMode corev1.PersistentVolumeMode, accessMode corev1.PersistentVolumeAccessMode) error {
+	sz, err := resource.ParseQuantity(size)
 	if err != nil {
 		return fmt.Errorf("Cound not parse size string")
 	}
-
 	vs.Spec.Storage.Root = VirtualServerStorageRoot{
-		Size: sz,
-		Source: &cdiv1beta.DataVolumeSource{
-			HTTP: &sourceHTTP,
-		},
-		StorageClassName: source.StorageClassName,
-		VolumeMode:       source.VolumeMode,
-		AccessMode:       source.AccessMode,
+		Size:             sz,
+		Source:           &source,
+		StorageClassName: storageClassName,
+		VolumeMode:       volumeMode,
+		AccessMode:       accessMode,
 	}
 	return nil
 }
@@ -423,8 +844,8 @@ func (vs *VirtualServer) AddPVCDisk(name string, pvcName string, readOnly bool)
 		ClaimName: pvcName,
 		ReadOnly:  readOnly,
 	}
-	disk := VirtualServerDisks{
-		VirtualServerStorageVolume{
+	vs.setDisk(VirtualServerDisks{
+		VirtualServerStorageVolume: VirtualServerStorageVolume{
 			Name: name,
 			Spec: kvv1.VolumeSource{
 				PersistentVolumeClaim: &kvv1.PersistentVolumeClaimVolumeSource{
@@ -432,26 +853,54 @@ func (vs *VirtualServer) AddPVCDisk(name string, pvcName string, readOnly bool)
 				},
 			},
 		},
-		DiskAttributes{
+		DiskAttributes: DiskAttributes{
 			ReadOnly: readOnly,
 		},
-	}
+	})
+}
 
-	for _, d := range vs.Spec.Storage.AdditionalDisks {
+// setDisk adds the disk to the VirtualServer, replacing the source and DataVolume template of any disk with the same name
+func (vs *VirtualServer) setDisk(disk VirtualServerDisks) {
+	for i := range vs.Spec.Storage.AdditionalDisks {
+		d := &vs.Spec.Storage.AdditionalDisks[i]
 		if d.Name == disk.Name {
 			d.Spec = disk.Spec
+			d.ReadOnly = disk.ReadOnly
+			d.DataVolumeTemplate = disk.DataVolumeTemplate
 			return
 		}
 	}
-
 	vs.Spec.Storage.AdditionalDisks = append(vs.Spec.Storage.AdditionalDisks, disk)
 }
 
+// UpdateDisk replaces the additional disk with the same name, returning false if the VirtualServer has no such disk
+func (vs *VirtualServer) UpdateDisk(disk VirtualServerDisks) bool {
+	for i := range vs.Spec.Storage.AdditionalDisks {
+		if vs.Spec.Storage.AdditionalDisks[i].Name == disk.Name {
+			vs.Spec.Storage.AdditionalDisks[i] = disk
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveDisk removes the additional disk, returning false if the VirtualServer has no disk with the name
+func (vs *VirtualServer) RemoveDisk(name string) bool {
+	disks := vs.Spec.Storage.AdditionalDisks
+	for i := range disks {
+		if disks[i].Name == name {
+			vs.Spec.Storage.AdditionalDisks = append(disks[:i], disks[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // SetDiskSerial sets the disk serial number.
 // It sets serial for root disk when name is "root" or for other additional disk name
 // when disk does not exist it returns false
 func (vs *VirtualServer) SetDiskSerial(name, serial string) bool {
-	if name == "root" {
+	if name == RootDiskName {
 		vs.Spec.Storage.Root.Serial = serial
 		return true
 	}
@@ -473,23 +922,114 @@ func (vs *VirtualServer) AddEmptyDisk(name string, size string) error {
 	emptyDisk := kvv1.EmptyDiskSource{
 		Capacity: sz,
 	}
-	disk := VirtualServerDisks{
-		VirtualServerStorageVolume{
+	vs.setDisk(VirtualServerDisks{
+		VirtualServerStorageVolume: VirtualServerStorageVolume{
 			Name: name,
 			Spec: kvv1.VolumeSource{
 				EmptyDisk: &emptyDisk,
 			},
 		},
-		DiskAttributes{},
+	})
+	return nil
+}
+
+// AddDataVolumeDiskWithPVCSource adds a disk backed by a DataVolume cloned from a PVC, created alongside the VirtualServer
+func (vs *VirtualServer) AddDataVolumeDiskWithPVCSource(name string, source VirtualServerStorageRootPVCSource) error {
+	return vs.addDataVolumeDisk(name, source.Size, source.dataVolumeSource(), source.StorageClassName, source.VolumeMode, source.AccessMode)
+}
+
+// AddDataVolumeDiskWithHTTPSource adds a disk backed by a DataVolume imported from an HTTP URL, created alongside the VirtualServer
+func (vs *VirtualServer) AddDataVolumeDiskWithHTTPSource(name string, source VirtualServerStorageRootHTTPSource) error {
+	return vs.addDataVolumeDisk(name, source.Size, source.dataVolumeSource(), source.StorageClassName, source.VolumeMode, source.AccessMode)
+}
+
+// AddDataVolumeDiskWithRegistrySource adds a disk backed by a DataVolume imported from a container image registry,
+// created alongside the VirtualServer
+func (vs *VirtualServer) AddDataVolumeDiskWithRegistrySource(name string, source VirtualServerStorageRootRegistrySource) error {
+	return vs.addDataVolumeDisk(name, source.Size, source.dataVolumeSource(), source.StorageClassName, source.VolumeMode, source.AccessMode)
+}
+
+// addDataVolumeDisk adds a disk backed by a DataVolume named <VirtualServer name>-<disk name>
+func (vs *VirtualServer) addDataVolumeDisk(name string, size string, source cdiv1beta.DataVolumeSource, storageClassName string,
+	volumeMode corev1.PersistentVolumeMode, accessMode corev1.PersistentVolumeAccessMode) error {
+	sz, err := resource.ParseQuantity(size)
+	if err != nil {
+		return fmt.Errorf("Cound not parse size string")
 	}
+	vs.setDisk(VirtualServerDisks{
+		VirtualServerStorageVolume: VirtualServerStorageVolume{
+			Name: name,
+			Spec: kvv1.VolumeSource{
+				DataVolume: &kvv1.DataVolumeSource{Name: vs.Name + "-" + name},
+			},
+		},
+		DataVolumeTemplate: &VirtualServerDataVolumeTemplate{
+			Size:             sz,
+			Source:           &source,
+			StorageClassName: storageClassName,
+			VolumeMode:       volumeMode,
+			AccessMode:       accessMode,
+		},
+	})
+	return nil
+}
 
-	for _, d := range vs.Spec.Storage.AdditionalDisks {
-		if d.Name == disk.Name {
-			d.Spec = disk.Spec
-			return nil
+// AddContainerDisk adds an ephemeral disk from a container image, such as quay.io/containerdisks/fedora:36.
+// Changes are discarded when the Virtual Server is stopped or restarted.
+func (vs *VirtualServer) AddContainerDisk(name string, image string) {
+	vs.setDisk(VirtualServerDisks{
+		VirtualServerStorageVolume: VirtualServerStorageVolume{
+			Name: name,
+			Spec: kvv1.VolumeSource{
+				ContainerDisk: &kvv1.ContainerDiskSource{Image: image},
+			},
+		},
+	})
+}
+
+// AddConfigMapDisk adds the ConfigMap as a read-only disk, an ISO image with a file per key
+func (vs *VirtualServer) AddConfigMapDisk(name string, configMapName string) {
+	vs.setDisk(VirtualServerDisks{
+		VirtualServerStorageVolume: VirtualServerStorageVolume{
+			Name: name,
+			Spec: kvv1.VolumeSource{
+				ConfigMap: &kvv1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: configMapName}},
+			},
+		},
+		DiskAttributes: DiskAttributes{ReadOnly: true},
+	})
+}
+
+// AddSecretDisk adds the Secret as a read-only disk, an ISO image with a file per key
+func (vs *VirtualServer) AddSecretDisk(name string, secretName string) {
+	vs.setDisk(VirtualServerDisks{
+		VirtualServerStorageVolume: VirtualServerStorageVolume{
+			Name: name,
+			Spec: kvv1.VolumeSource{
+				Secret: &kvv1.SecretVolumeSource{SecretName: secretName},
+			},
+		},
+		DiskAttributes: DiskAttributes{ReadOnly: true},
+	})
+}
+
+// AddHostDisk adds a disk image file on the node as a disk. The image is created with the size when diskType is
+// DiskOrCreate, the size is ignored for an existing Disk and may be empty.
+func (vs *VirtualServer) AddHostDisk(name string, path string, diskType kvv1.HostDiskType, size string) error {
+	hostDisk := &kvv1.HostDisk{Path: path, Type: diskType}
+	if size != "" {
+		sz, err := resource.ParseQuantity(size)
+		if err != nil {
+			return fmt.Errorf("Cound not parse size string")
 		}
+		hostDisk.Capacity = sz
 	}
-	vs.Spec.Storage.AdditionalDisks = append(vs.Spec.Storage.AdditionalDisks, disk)
+	vs.setDisk(VirtualServerDisks{
+		VirtualServerStorageVolume: VirtualServerStorageVolume{
+			Name: name,
+			Spec: kvv1.VolumeSource{HostDisk: hostDisk},
+		},
+	})
 	return nil
 }
 
@@ -509,17 +1049,38 @@ func (vs *VirtualServer) AddPVCFileSystem(name string, pvcName string, readOnly
 			},
 		},
 	}
-
-	for _, d := range vs.Spec.Storage.FileSystems {
-		if d.Name == fs.Name {
-			d.Spec = fs.Spec
+	for i := range vs.Spec.Storage.FileSystems {
+		if vs.Spec.Storage.FileSystems[i].Name == fs.Name {
+			vs.Spec.Storage.FileSystems[i].Spec = fs.Spec
 			return
 		}
 	}
-
 	vs.Spec.Storage.FileSystems = append(vs.Spec.Storage.FileSystems, fs)
 }
 
+// UpdateFileSystem replaces the filesystem with the same name, returning false if the VirtualServer has no such filesystem
+func (vs *VirtualServer) UpdateFileSystem(fs VirtualServerFilesystem) bool {
+	for i := range vs.Spec.Storage.FileSystems {
+		if vs.Spec.Storage.FileSystems[i].Name == fs.Name {
+			vs.Spec.Storage.FileSystems[i] = fs
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveFileSystem removes the filesystem, returning false if the VirtualServer has no filesystem with the name
+func (vs *VirtualServer) RemoveFileSystem(name string) bool {
+	filesystems := vs.Spec.Storage.FileSystems
+	for i := range filesystems {
+		if filesystems[i].Name == name {
+			vs.Spec.Storage.FileSystems = append(filesystems[:i], filesystems[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 func (vs *VirtualServer) AddSwap(size string) error {
 	sz, err := resource.ParseQuantity(size)
 	if err != nil {
@@ -529,6 +1090,119 @@ func (vs *VirtualServer) AddSwap(size string) error {
 	return nil
 }
 
+// ResizeRootDisk grows the root disk to the size. The root disk cannot shrink or be ephemeral, and storageClass,
+// the storage class of the root disk, must allow volume expansion. A root disk without a storage class name uses
+// the default storage class of the cluster, which storageClass must then be.
+// The progress of the resize is reported in Status.RootDisk once the VirtualServer is updated.
+func (vs *VirtualServer) ResizeRootDisk(newSize string, storageClass *storagev1.StorageClass) error {
+	sz, err := resource.ParseQuantity(newSize)
+	if err != nil {
+		return fmt.Errorf("Cound not parse size string")
+	}
+	root := &vs.Spec.Storage.Root
+	if root.Ephemeral {
+		return errors.New("an ephemeral root disk cannot be resized")
+	}
+	switch sz.Cmp(root.Size) {
+	case -1:
+		return fmt.Errorf("the root disk cannot shrink from %s to %s", root.Size.String(), sz.String())
+	case 0:
+		return nil
+	}
+	if root.StorageClassName == "" {
+		if storageClass == nil || !isDefaultStorageClass(storageClass) {
+			return errors.New("the default storage class of the root disk is required")
+		}
+	} else if storageClass == nil || storageClass.Name != root.StorageClassName {
+		return fmt.Errorf("the storage class %q of the root disk is required", root.StorageClassName)
+	}
+	if storageClass.AllowVolumeExpansion == nil || !*storageClass.AllowVolumeExpansion {
+		return fmt.Errorf("storage class %q does not allow volume expansion", storageClass.Name)
+	}
+	root.Size = sz
+	return nil
+}
+
+// isDefaultStorageClass returns true if the storage class is annotated as the default storage class of the cluster
+func isDefaultStorageClass(storageClass *storagev1.StorageClass) bool {
+	return storageClass.Annotations["storageclass.kubernetes.io/is-default-class"] == "true" ||
+		storageClass.Annotations["storageclass.beta.kubernetes.io/is-default-class"] == "true"
+}
+
+// rootDiskFileSystemOverhead is the fraction of a Filesystem volume reserved by CDI, in thousandths,
+// so that the guest sees a Filesystem root disk smaller than the volume capacity
+const rootDiskFileSystemOverhead = 55
+
+// SetRootDiskStatus records the capacity of the root disk volume and derives the resize state from the requested size.
+// fileSystemResizePending is true while the filesystem awaits a resize, such as reported by the FileSystemResizePending
+// condition of the root disk PVC. guestSize is the size of the root disk seen by the running guest, see
+// RootDiskGuestSize, or nil if the VirtualServer is not running.
+// A resize is only reported complete once the guest sees the requested size.
+func (vs *VirtualServer) SetRootDiskStatus(capacity resource.Quantity, fileSystemResizePending bool, guestSize *resource.Quantity) {
+	if vs.Status.RootDisk == nil {
+		vs.Status.RootDisk = &VirtualServerRootDiskStatus{}
+	}
+	status := vs.Status.RootDisk
+	requested := vs.Spec.Storage.Root.Size.DeepCopy()
+	// The root disk is resizing until the resize completes, or if the requested size changed, even if the volume
+	// was already expanded. A complete resize of the requested size stays complete, such as once the guest stops.
+	resizing := status.ResizeState != "" && status.ResizeState != VirtualServerRootDiskResizeStateComplete ||
+		status.RequestedSize != nil && status.RequestedSize.Cmp(requested) != 0
+	status.RequestedSize = &requested
+	status.Capacity = &capacity
+	status.GuestSize = nil
+	if guestSize != nil {
+		size := guestSize.DeepCopy()
+		status.GuestSize = &size
+	}
+	switch {
+	case capacity.Cmp(requested) < 0:
+		status.ResizeState = VirtualServerRootDiskResizeStateVolumeResizing
+	case fileSystemResizePending:
+		status.ResizeState = VirtualServerRootDiskResizeStateFileSystemResizePending
+	case resizing && guestSize != nil && !vs.guestSeesRootDiskSize(*guestSize, requested):
+		status.ResizeState = VirtualServerRootDiskResizeStateGuestResizePending
+	case guestSize == nil && resizing:
+		// The resize is confirmed once the guest runs
+		status.ResizeState = VirtualServerRootDiskResizeStateGuestResizePending
+	case resizing:
+		status.ResizeState = VirtualServerRootDiskResizeStateComplete
+	}
+}
+
+// guestSeesRootDiskSize returns true if the guest size of the root disk corresponds to the requested size.
+// The guest sees the whole of a Block volume, but not the overhead reserved on a Filesystem volume.
+func (vs *VirtualServer) guestSeesRootDiskSize(guestSize resource.Quantity, requested resource.Quantity) bool {
+	if vs.Spec.Storage.Root.VolumeMode == corev1.PersistentVolumeBlock {
+		return guestSize.Cmp(requested) >= 0
+	}
+	return guestSize.Value()*1000 >= requested.Value()*(1000-rootDiskFileSystemOverhead)
+}
+
+// RootDiskGuestSize returns the size of the root disk seen by the guest, from the volume status of the running
+// VirtualMachineInstance, or nil if the size is not reported
+func RootDiskGuestSize(vmi *kvv1.VirtualMachineInstance) *resource.Quantity {
+	if vmi == nil || vmi.Status.Phase != kvv1.Running {
+		return nil
+	}
+	for _, volume := range vmi.Status.VolumeStatus {
+		if volume.Name == RootDiskName && volume.Size > 0 {
+			return resource.NewQuantity(volume.Size, resource.BinarySI)
+		}
+	}
+	return nil
+}
+
+// IsRootDiskResized returns true if the status reports the root disk size requested in the spec, and any resize of the
+// root disk is complete
+func (vs *VirtualServer) IsRootDiskResized() bool {
+	status := vs.Status.RootDisk
+	if status == nil || status.RequestedSize == nil || status.RequestedSize.Cmp(vs.Spec.Storage.Root.Size) != 0 {
+		return false
+	}
+	return status.ResizeState == "" || status.ResizeState == VirtualServerRootDiskResizeStateComplete
+}
+
 func (vs *VirtualServer) GetReadyStatus() *metav1.Condition {
 	condition := apimeta.FindStatusCondition(vs.Status.Conditions, string(VSConditionTypeReady))
 	if condition == nil {
@@ -0,0 +1,658 @@
+package v1alpha1_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	vsv1alpha "github.com/coreweave/virtual-server/api/v1alpha1"
+	"github.com/coreweave/virtual-server/cloudinit"
+	"github.com/coreweave/virtual-server/ignition"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	kvv1 "kubevirt.io/api/core/v1"
+	cdiv1beta "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+func TestAddUserMerge(t *testing.T) {
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	vs.AddUser(vsv1alpha.VirtualServerUser{
+		Username:      "myuser",
+		Password:      "password",
+		SSHPublicKey:  "ssh-ed25519 AAAA one",
+		SSHPublicKeys: []string{"ssh-ed25519 AAAA two"},
+		Groups:        []string{"sudo"},
+	})
+	lockPassword := true
+	vs.AddUser(vsv1alpha.VirtualServerUser{
+		Username:      "myuser",
+		SSHPublicKey:  "ssh-ed25519 AAAA three",
+		SSHPublicKeys: []string{"ssh-ed25519 AAAA two", "ssh-ed25519 AAAA four"},
+		Groups:        []string{"docker"},
+		Shell:         "/bin/zsh",
+		LockPassword:  &lockPassword,
+	})
+
+	want := []vsv1alpha.VirtualServerUser{{
+		Username:      "myuser",
+		Password:      "password",
+		SSHPublicKey:  "ssh-ed25519 AAAA one\nssh-ed25519 AAAA three",
+		SSHPublicKeys: []string{"ssh-ed25519 AAAA two", "ssh-ed25519 AAAA four"},
+		Groups:        []string{"sudo", "docker"},
+		Shell:         "/bin/zsh",
+		LockPassword:  &lockPassword,
+	}}
+	if !reflect.DeepEqual(vs.Spec.Users, want) {
+		t.Errorf("expected users %+v, got %+v", want, vs.Spec.Users)
+	}
+
+	wantKeys := []string{"ssh-ed25519 AAAA one", "ssh-ed25519 AAAA three", "ssh-ed25519 AAAA two", "ssh-ed25519 AAAA four"}
+	if keys := vs.Spec.Users[0].AuthorizedKeys(); !reflect.DeepEqual(keys, wantKeys) {
+		t.Errorf("expected authorized keys %q, got %q", wantKeys, keys)
+	}
+}
+
+func TestSetPassword(t *testing.T) {
+	user := vsv1alpha.VirtualServerUser{Username: "myuser", Password: "password"}
+	if err := user.SetHashedPassword("password"); err == nil {
+		t.Error("expected an error for a password that is not hashed")
+	}
+	if err := user.SetHashedPassword("$6$salt$hash"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Password != "" || user.HashedPassword != "$6$salt$hash" {
+		t.Errorf("expected only the hashed password to be set, got %+v", user)
+	}
+
+	user.SetPasswordSecretRef("my-secret", "password")
+	if user.HashedPassword != "" || user.PasswordSecretRef == nil || user.PasswordSecretRef.Name != "my-secret" || user.PasswordSecretRef.Key != "password" {
+		t.Errorf("expected only the password Secret reference to be set, got %+v", user)
+	}
+	if !user.HasPassword() {
+		t.Error("expected the user to have a password")
+	}
+
+	// A Secret reference added to an existing user replaces its inline keys
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	vs.AddUser(vsv1alpha.VirtualServerUser{Username: "myuser", SSHPublicKeys: []string{"ssh-ed25519 AAAA one"}})
+	other := vsv1alpha.VirtualServerUser{Username: "myuser"}
+	other.SetSSHPublicKeySecretRef("my-secret", "authorized_keys")
+	vs.AddUser(other)
+	if got := vs.Spec.Users[0]; len(got.SSHPublicKeys) != 0 || got.SSHPublicKeySecretRef == nil {
+		t.Errorf("expected the keys to be replaced by the Secret reference, got %+v", got)
+	}
+}
+
+func TestAddCloudInitSize(t *testing.T) {
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	vs.AddUser(vsv1alpha.VirtualServerUser{
+		Username:      "myuser",
+		SSHPublicKeys: []string{strings.Repeat("k", 1000), strings.Repeat("l", 1000)},
+		Groups:        []string{"docker"},
+		Sudo:          []string{"ALL=(ALL) ALL"},
+	})
+
+	// cloud-init gzip compresses user data exceeding the Secret
+	cloudConfig := "#cloud-config\nruncmd:\n- echo " + strings.Repeat("a", 2*corev1.MaxSecretSize) + "\n"
+	if err := vs.AddCloudInit(cloudConfig); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if secret, err := vs.CloudInitSecret(nil); err != nil || !secret.Budget.Compressed || secret.Budget.Remaining < 0 {
+		t.Errorf("expected compressed user data within the Secret, got %+v, %v", secret, err)
+	}
+	if err := vs.AddCloudInit("#!/bin/sh\n# " + incompressible(2*corev1.MaxSecretSize)); err == nil {
+		t.Error("expected the cloud-init to exceed the Secret after compression")
+	}
+	if vs.Spec.CloudInit != cloudConfig {
+		t.Error("expected the cloud-init to be unchanged after an error")
+	}
+}
+
+// incompressible returns n characters of random base64, which gzip compresses to no less than 3n/4 bytes
+func incompressible(n int) string {
+	data := make([]byte, n)
+	rand.New(rand.NewSource(1)).Read(data)
+	return base64.StdEncoding.EncodeToString(data)[:n]
+}
+
+func TestAddCloudConfig(t *testing.T) {
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	if err := vs.AddCloudConfig(&cloudinit.Config{WriteFiles: []cloudinit.WriteFile{{Path: "relative"}}}); err == nil {
+		t.Error("expected an error for an invalid cloud-config")
+	}
+	if err := vs.AddCloudConfig(&cloudinit.Config{Packages: []string{"curl"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "#cloud-config\npackages:\n- curl\n"; vs.Spec.CloudInit != want {
+		t.Errorf("expected cloud-init %q, got %q", want, vs.Spec.CloudInit)
+	}
+	if err := vs.IsValidCloudInit(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAddIgnitionConfig(t *testing.T) {
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	if err := vs.AddIgnitionConfig(&ignition.Config{Storage: &ignition.Storage{Files: []ignition.File{{Path: "relative"}}}}); err == nil {
+		t.Error("expected an error for an invalid Ignition config")
+	}
+	if err := vs.SetIgnition(`{"ignition":{"version":"2.3.0"}}`); err == nil {
+		t.Error("expected an error for an Ignition v2 config")
+	}
+	cfg := &ignition.Config{}
+	cfg.AddFile(ignition.NewFile("/etc/motd", "hello\n", 0644))
+	if err := vs.AddIgnitionConfig(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if errs := ignition.ValidateConfig(vs.Spec.Ignition, field.NewPath("ignition")); len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	// The Ignition config is embedded as a base64 data URL, about 4/3 of its size, and is never compressed
+	config := vs.Spec.Ignition
+	large := fmt.Sprintf(`{"ignition":{"version":"3.3.0"},"storage":{"files":[{"path":"/etc/large","contents":{"source":"data:,%s"}}]}}`, strings.Repeat("a", corev1.MaxSecretSize*7/8))
+	if err := vs.SetIgnition(large); err == nil {
+		t.Error("expected the encoded Ignition config to exceed the Secret")
+	}
+	if vs.Spec.Ignition != config {
+		t.Error("expected the Ignition config to be unchanged after an error")
+	}
+
+	vs.SetProvisioner(vsv1alpha.VirtualServerProvisionerIgnition)
+	vs.SetOS(vsv1alpha.VirtualServerOSTypeLinux)
+	if !vs.UsesIgnition() {
+		t.Error("expected SetOS to keep the Ignition provisioner")
+	}
+}
+
+func TestSetOSKeepsWindows(t *testing.T) {
+	vs := vsv1alpha.NewVirtualServer("my-windows-server", "default")
+	if err := vs.SetWindowsComputerName("MY-WINDOWS"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	vs.AddWindowsFirstLogonCommand("ipconfig /all")
+	vs.EnableUEFIBoot(true)
+	vs.SetOS(vsv1alpha.VirtualServerOSTypeWindows)
+	windows := vs.Spec.OS.Windows
+	if windows == nil || windows.ComputerName != "MY-WINDOWS" || len(windows.FirstLogonCommands) != 1 {
+		t.Errorf("expected SetOS to keep the Windows configuration, got %+v", windows)
+	}
+	if !vs.Spec.OS.EnableUEFIBoot {
+		t.Error("expected SetOS to keep UEFI boot enabled")
+	}
+}
+
+func TestCloudInitBudget(t *testing.T) {
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	if err := vs.SetLocalHostname("my-host"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := vs.SetLocalHostname("My_Host"); err == nil {
+		t.Error("expected an error for an invalid hostname")
+	}
+	networkData := "version: 2\nethernets:\n  eth0:\n    dhcp4: true\n"
+	if err := vs.SetNetworkData(networkData); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := vs.SetNetworkData("version: 3\n"); err == nil {
+		t.Error("expected an error for invalid network data")
+	}
+
+	script := "#!/bin/sh\n# " + incompressible(corev1.MaxSecretSize*3/4)
+	if err := vs.AddCloudInit(script); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	padding := "# " + incompressible(corev1.MaxSecretSize/2) + "\n"
+	if err := vs.SetNetworkData(padding + networkData); err == nil {
+		t.Error("expected an error for network data exceeding the size left by the cloud-init")
+	}
+	if vs.Spec.NetworkData != networkData {
+		t.Error("expected the network data to be unchanged after an error")
+	}
+}
+
+func TestAddDisks(t *testing.T) {
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	vs.AddPVCDisk("data", "my-data", true)
+	if err := vs.AddDataVolumeDiskWithPVCSource("data", vsv1alpha.VirtualServerStorageRootPVCSource{
+		Size:             "10Gi",
+		PVCName:          "my-data",
+		PVCNamespace:     "vd-images",
+		StorageClassName: "block-nvme-ord1",
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := vs.AddDataVolumeDiskWithRegistrySource("image", vsv1alpha.VirtualServerStorageRootRegistrySource{
+		Size:             "10Gi",
+		ImageUrl:         "docker://quay.io/containerdisks/ubuntu:22.04",
+		StorageClassName: "block-nvme-ord1",
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := vs.AddHostDisk("host", "/var/lib/disk.img", "DiskOrCreate", "not a size"); err == nil {
+		t.Error("expected an error for an invalid size")
+	}
+	vs.AddSecretDisk("secret", "my-secret")
+
+	disks := vs.Spec.Storage.AdditionalDisks
+	if len(disks) != 3 {
+		t.Fatalf("expected the data disk to be replaced, got %d disks", len(disks))
+	}
+	data := disks[0]
+	if data.ReadOnly || data.Spec.DataVolume == nil || data.Spec.DataVolume.Name != "my-virtual-server-data" || data.DataVolumeTemplate.Source.PVC.Namespace != "vd-images" {
+		t.Errorf("expected a writable disk cloned from vd-images/my-data, got %+v", data)
+	}
+	if url := disks[1].DataVolumeTemplate.Source.Registry.URL; *url != "docker://quay.io/containerdisks/ubuntu:22.04" {
+		t.Errorf("expected a registry source, got %s", *url)
+	}
+	if !disks[2].ReadOnly || disks[2].Spec.Secret.SecretName != "my-secret" {
+		t.Errorf("expected a read-only Secret disk, got %+v", disks[2])
+	}
+}
+
+func TestConfigureStorageRoot(t *testing.T) {
+	const size, storageClassName = "40Gi", "block-nvme-ord1"
+	tests := []struct {
+		name      string
+		configure func(vs *vsv1alpha.VirtualServer) error
+		want      func(src *cdiv1beta.DataVolumeSource) bool
+	}{
+		{
+			name: "http with basic auth and cert",
+			configure: func(vs *vsv1alpha.VirtualServer) error {
+				return vs.ConfigureStorageRootWithHTTPSource(vsv1alpha.VirtualServerStorageRootHTTPSource{
+					Size: size, ImageUrl: "https://example.com/image.qcow2", SecretRef: "my-auth", CertConfigMap: "my-ca", StorageClassName: storageClassName,
+				})
+			},
+			want: func(src *cdiv1beta.DataVolumeSource) bool {
+				return src.HTTP.SecretRef == "my-auth" && src.HTTP.CertConfigMap == "my-ca"
+			},
+		},
+		{
+			name: "registry with pull secret",
+			configure: func(vs *vsv1alpha.VirtualServer) error {
+				return vs.ConfigureStorageRootWithRegistrySource(vsv1alpha.VirtualServerStorageRootRegistrySource{
+					Size: size, ImageUrl: "docker://quay.io/containerdisks/ubuntu:22.04", PullSecretRef: "my-pull-secret", StorageClassName: storageClassName,
+				})
+			},
+			want: func(src *cdiv1beta.DataVolumeSource) bool {
+				return *src.Registry.URL == "docker://quay.io/containerdisks/ubuntu:22.04" && *src.Registry.SecretRef == "my-pull-secret" && src.Registry.CertConfigMap == nil
+			},
+		},
+		{
+			name: "s3",
+			configure: func(vs *vsv1alpha.VirtualServer) error {
+				return vs.ConfigureStorageRootWithS3Source(vsv1alpha.VirtualServerStorageRootS3Source{
+					Size: size, ImageUrl: "https://object.ord1.coreweave.com/images/image.qcow2", SecretRef: "my-keys", StorageClassName: storageClassName,
+				})
+			},
+			want: func(src *cdiv1beta.DataVolumeSource) bool { return src.S3.SecretRef == "my-keys" },
+		},
+		{
+			name: "blank",
+			configure: func(vs *vsv1alpha.VirtualServer) error {
+				return vs.ConfigureStorageRootWithBlankSource(vsv1alpha.VirtualServerStorageRootBlankSource{Size: size, StorageClassName: storageClassName})
+			},
+			want: func(src *cdiv1beta.DataVolumeSource) bool { return src.Blank != nil },
+		},
+		{
+			name: "upload",
+			configure: func(vs *vsv1alpha.VirtualServer) error {
+				return vs.ConfigureStorageRootWithUploadSource(vsv1alpha.VirtualServerStorageRootUploadSource{Size: size, StorageClassName: storageClassName})
+			},
+			want: func(src *cdiv1beta.DataVolumeSource) bool { return src.Upload != nil },
+		},
+		{
+			name: "vddk",
+			configure: func(vs *vsv1alpha.VirtualServer) error {
+				return vs.ConfigureStorageRootWithVDDKSource(vsv1alpha.VirtualServerStorageRootVDDKSource{
+					Size: size, Url: "https://vcenter.example.com", UUID: "52260566-b032-36cb-55b1-79bf29e30490", BackingFile: "[datastore] vm/vm.vmdk",
+					Thumbprint: "20:6C:8A:5D:44:40:B3:79:4B:28:EA:76:13:60:90:6E:49:D9:D9:A3", SecretRef: "my-vcenter", StorageClassName: storageClassName,
+				})
+			},
+			want: func(src *cdiv1beta.DataVolumeSource) bool { return src.VDDK.BackingFile == "[datastore] vm/vm.vmdk" },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+			vs.SetOS(vsv1alpha.VirtualServerOSTypeLinux)
+			if err := tt.configure(vs); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.want(vs.Spec.Storage.Root.Source) {
+				t.Errorf("unexpected source %+v", vs.Spec.Storage.Root.Source)
+			}
+			if errs := vs.Validate(); len(errs) > 0 {
+				t.Errorf("unexpected errors: %v", errs)
+			}
+		})
+	}
+}
+
+func TestResizeRootDisk(t *testing.T) {
+	expandable, fixed := true, false
+	defaultClass := map[string]string{"storageclass.kubernetes.io/is-default-class": "true"}
+	tests := []struct {
+		name         string
+		size         string
+		ephemeral    bool
+		defaultClass bool
+		storageClass *storagev1.StorageClass
+		wantErr      bool
+		want         string
+	}{
+		{
+			name:         "grow",
+			size:         "80Gi",
+			storageClass: &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "block-nvme-ord1"}, AllowVolumeExpansion: &expandable},
+			want:         "80Gi",
+		},
+		{
+			name: "same size",
+			size: "40Gi",
+			want: "40Gi",
+		},
+		{
+			name:         "shrink",
+			size:         "20Gi",
+			storageClass: &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "block-nvme-ord1"}, AllowVolumeExpansion: &expandable},
+			wantErr:      true,
+		},
+		{
+			name:         "ephemeral",
+			size:         "80Gi",
+			ephemeral:    true,
+			storageClass: &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "block-nvme-ord1"}, AllowVolumeExpansion: &expandable},
+			wantErr:      true,
+		},
+		{
+			name:         "expansion not allowed",
+			size:         "80Gi",
+			storageClass: &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "block-nvme-ord1"}, AllowVolumeExpansion: &fixed},
+			wantErr:      true,
+		},
+		{
+			name:         "other storage class",
+			size:         "80Gi",
+			storageClass: &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "block-hdd-ord1"}, AllowVolumeExpansion: &expandable},
+			wantErr:      true,
+		},
+		{
+			name:         "default storage class",
+			size:         "80Gi",
+			defaultClass: true,
+			storageClass: &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "block-nvme-ord1", Annotations: defaultClass}, AllowVolumeExpansion: &expandable},
+			want:         "80Gi",
+		},
+		{
+			name:         "not the default storage class",
+			size:         "80Gi",
+			defaultClass: true,
+			storageClass: &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "block-nvme-ord1"}, AllowVolumeExpansion: &expandable},
+			wantErr:      true,
+		},
+		{
+			name:    "missing storage class",
+			size:    "80Gi",
+			wantErr: true,
+		},
+		{
+			name:    "invalid size",
+			size:    "big",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+			vs.ConfigureStorageRootWithPVCSource(vsv1alpha.VirtualServerStorageRootPVCSource{
+				Size: "40Gi", PVCName: "ubuntu2004-docker-master-20210601-ord1", PVCNamespace: "vd-images", StorageClassName: "block-nvme-ord1",
+			})
+			vs.Spec.Storage.Root.Ephemeral = tt.ephemeral
+			if tt.defaultClass {
+				vs.Spec.Storage.Root.StorageClassName = ""
+			}
+			err := vs.ResizeRootDisk(tt.size, tt.storageClass)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				if vs.Spec.Storage.Root.Size.String() != "40Gi" {
+					t.Errorf("expected size to be unchanged, got %s", vs.Spec.Storage.Root.Size.String())
+				}
+				return
+			}
+			if vs.Spec.Storage.Root.Size.String() != tt.want {
+				t.Errorf("expected size %s, got %s", tt.want, vs.Spec.Storage.Root.Size.String())
+			}
+		})
+	}
+}
+
+func TestSetRootDiskStatus(t *testing.T) {
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	vs.Spec.Storage.Root.Size = resource.MustParse("40Gi")
+	vs.Spec.Storage.Root.VolumeMode = corev1.PersistentVolumeBlock
+	if vs.IsRootDiskResized() {
+		t.Error("expected the root disk not to be resized before its status is set")
+	}
+
+	size := func(s string) *resource.Quantity {
+		q := resource.MustParse(s)
+		return &q
+	}
+	vs.SetRootDiskStatus(resource.MustParse("40Gi"), false, size("40Gi"))
+	if vs.Status.RootDisk.ResizeState != "" || !vs.IsRootDiskResized() {
+		t.Errorf("expected the root disk to be resized without a resize state, got %+v", vs.Status.RootDisk)
+	}
+
+	vs.Spec.Storage.Root.Size = resource.MustParse("80Gi")
+	if vs.IsRootDiskResized() {
+		t.Error("expected the root disk not to be resized before the new size is observed")
+	}
+	for _, step := range []struct {
+		capacity  string
+		pending   bool
+		guestSize *resource.Quantity
+		want      vsv1alpha.VirtualServerRootDiskResizeState
+		resized   bool
+	}{
+		{"40Gi", false, size("40Gi"), vsv1alpha.VirtualServerRootDiskResizeStateVolumeResizing, false},
+		{"80Gi", true, size("40Gi"), vsv1alpha.VirtualServerRootDiskResizeStateFileSystemResizePending, false},
+		{"80Gi", false, size("40Gi"), vsv1alpha.VirtualServerRootDiskResizeStateGuestResizePending, false},
+		{"80Gi", false, nil, vsv1alpha.VirtualServerRootDiskResizeStateGuestResizePending, false},
+		{"80Gi", false, size("80Gi"), vsv1alpha.VirtualServerRootDiskResizeStateComplete, true},
+		// A complete resize stays complete once the guest stops
+		{"80Gi", false, nil, vsv1alpha.VirtualServerRootDiskResizeStateComplete, true},
+	} {
+		vs.SetRootDiskStatus(resource.MustParse(step.capacity), step.pending, step.guestSize)
+		status := vs.Status.RootDisk
+		if status.ResizeState != step.want || vs.IsRootDiskResized() != step.resized {
+			t.Errorf("capacity %s, guest size %v: expected state %s and resized %v, got %s and %v", step.capacity, step.guestSize, step.want, step.resized, status.ResizeState, vs.IsRootDiskResized())
+		}
+		if status.RequestedSize.String() != "80Gi" || status.Capacity.String() != step.capacity {
+			t.Errorf("expected requested size 80Gi and capacity %s, got %s and %s", step.capacity, status.RequestedSize.String(), status.Capacity.String())
+		}
+		if (status.GuestSize == nil) != (step.guestSize == nil) || status.GuestSize != nil && status.GuestSize.Cmp(*step.guestSize) != 0 {
+			t.Errorf("expected guest size %v, got %v", step.guestSize, status.GuestSize)
+		}
+	}
+}
+
+func TestSetRootDiskStatusFileSystem(t *testing.T) {
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	vs.Spec.Storage.Root.Size = resource.MustParse("40Gi")
+	vs.Spec.Storage.Root.VolumeMode = corev1.PersistentVolumeFilesystem
+	vs.SetRootDiskStatus(resource.MustParse("40Gi"), false, nil)
+
+	vs.Spec.Storage.Root.Size = resource.MustParse("80Gi")
+	vs.SetRootDiskStatus(resource.MustParse("80Gi"), false, resource.NewQuantity(40<<30, resource.BinarySI))
+	if vs.Status.RootDisk.ResizeState != vsv1alpha.VirtualServerRootDiskResizeStateGuestResizePending {
+		t.Errorf("expected the guest resize to be pending, got %s", vs.Status.RootDisk.ResizeState)
+	}
+	// The guest does not see the overhead reserved on the Filesystem volume
+	vs.SetRootDiskStatus(resource.MustParse("80Gi"), false, resource.NewQuantity(80<<30*95/100, resource.BinarySI))
+	if vs.Status.RootDisk.ResizeState != vsv1alpha.VirtualServerRootDiskResizeStateComplete {
+		t.Errorf("expected the resize to be complete, got %s", vs.Status.RootDisk.ResizeState)
+	}
+}
+
+func TestRootDiskGuestSize(t *testing.T) {
+	vmi := &kvv1.VirtualMachineInstance{}
+	vmi.Status.VolumeStatus = []kvv1.VolumeStatus{{Name: "data", Size: 10 << 30}, {Name: vsv1alpha.RootDiskName, Size: 40 << 30}}
+	if size := vsv1alpha.RootDiskGuestSize(vmi); size != nil {
+		t.Errorf("expected no guest size before the VirtualMachineInstance runs, got %v", size)
+	}
+	vmi.Status.Phase = kvv1.Running
+	if size := vsv1alpha.RootDiskGuestSize(vmi); size == nil || size.String() != "40Gi" {
+		t.Errorf("expected guest size 40Gi, got %v", size)
+	}
+	if size := vsv1alpha.RootDiskGuestSize(nil); size != nil {
+		t.Errorf("expected no guest size without a VirtualMachineInstance, got %v", size)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	newVirtualServer := func() *vsv1alpha.VirtualServer {
+		vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+		vs.AddPVCDisk("data", "my-data", false)
+		vs.AddEmptyDisk("scratch", "10Gi")
+		vs.AddPVCFileSystem("shared", "my-shared", false)
+		vs.AddPVCFileSystem("models", "my-models", true)
+		vs.AddUser(vsv1alpha.VirtualServerUser{Username: "myuser"})
+		vs.AddUser(vsv1alpha.VirtualServerUser{Username: "deploy"})
+		vs.ExposeTCPPorts([]int32{22, 443})
+		vs.ExposeUDPPorts([]int32{4172, 4173})
+		vs.AddFloatingIP("my-lb")
+		vs.AddFloatingIP("my-other-lb")
+		vs.AddVPC("my-vpc")
+		vs.AddVPC("my-storage-vpc")
+		return vs
+	}
+	diskNames := func(vs *vsv1alpha.VirtualServer) interface{} {
+		var names []string
+		for _, d := range vs.Spec.Storage.AdditionalDisks {
+			names = append(names, d.Name)
+		}
+		return names
+	}
+	fsNames := func(vs *vsv1alpha.VirtualServer) interface{} {
+		var names []string
+		for _, fs := range vs.Spec.Storage.FileSystems {
+			names = append(names, fs.Name)
+		}
+		return names
+	}
+	usernames := func(vs *vsv1alpha.VirtualServer) interface{} {
+		var names []string
+		for _, u := range vs.Spec.Users {
+			names = append(names, u.Username)
+		}
+		return names
+	}
+	tcpPorts := func(vs *vsv1alpha.VirtualServer) interface{} { return vs.Spec.Network.TCP.Ports }
+	udpPorts := func(vs *vsv1alpha.VirtualServer) interface{} { return vs.Spec.Network.UDP.Ports }
+	floatingIPs := func(vs *vsv1alpha.VirtualServer) interface{} {
+		var names []string
+		for _, fip := range vs.Spec.Network.FloatingIPs {
+			names = append(names, fip.ServiceName)
+		}
+		return names
+	}
+	vpcNames := func(vs *vsv1alpha.VirtualServer) interface{} {
+		var names []string
+		for _, vpc := range vs.Spec.Network.VPCs {
+			names = append(names, vpc.Name)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name      string
+		remove    func(vs *vsv1alpha.VirtualServer) bool
+		want      bool
+		remaining func(vs *vsv1alpha.VirtualServer) interface{}
+		wantLeft  interface{}
+	}{
+		{"disk", func(vs *vsv1alpha.VirtualServer) bool { return vs.RemoveDisk("data") }, true, diskNames, []string{"scratch"}},
+		{"missing disk", func(vs *vsv1alpha.VirtualServer) bool { return vs.RemoveDisk("shared") }, false, diskNames, []string{"data", "scratch"}},
+		{"filesystem", func(vs *vsv1alpha.VirtualServer) bool { return vs.RemoveFileSystem("models") }, true, fsNames, []string{"shared"}},
+		{"missing filesystem", func(vs *vsv1alpha.VirtualServer) bool { return vs.RemoveFileSystem("data") }, false, fsNames, []string{"shared", "models"}},
+		{"user", func(vs *vsv1alpha.VirtualServer) bool { return vs.RemoveUser("myuser") }, true, usernames, []string{"deploy"}},
+		{"missing user", func(vs *vsv1alpha.VirtualServer) bool { return vs.RemoveUser("root") }, false, usernames, []string{"myuser", "deploy"}},
+		{"tcp port", func(vs *vsv1alpha.VirtualServer) bool { return vs.UnexposeTCPPort(22) }, true, tcpPorts, []vsv1alpha.Port{443}},
+		{"udp port exposed over tcp", func(vs *vsv1alpha.VirtualServer) bool { return vs.UnexposeTCPPort(4172) }, false, tcpPorts, []vsv1alpha.Port{22, 443}},
+		{"udp port", func(vs *vsv1alpha.VirtualServer) bool { return vs.UnexposeUDPPort(4173) }, true, udpPorts, []vsv1alpha.Port{4172}},
+		{"floating ip", func(vs *vsv1alpha.VirtualServer) bool { return vs.RemoveFloatingIP("my-lb") }, true, floatingIPs, []string{"my-other-lb"}},
+		{"missing floating ip", func(vs *vsv1alpha.VirtualServer) bool { return vs.RemoveFloatingIP("my-vpc") }, false, floatingIPs, []string{"my-lb", "my-other-lb"}},
+		{"vpc", func(vs *vsv1alpha.VirtualServer) bool { return vs.RemoveVPC("my-storage-vpc") }, true, vpcNames, []string{"my-vpc"}},
+		{"missing vpc", func(vs *vsv1alpha.VirtualServer) bool { return vs.RemoveVPC("my-lb") }, false, vpcNames, []string{"my-vpc", "my-storage-vpc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs := newVirtualServer()
+			if got := tt.remove(vs); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+			if got := tt.remaining(vs); !reflect.DeepEqual(got, tt.wantLeft) {
+				t.Errorf("expected %v to remain, got %v", tt.wantLeft, got)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	vs.AddPVCDisk("data", "my-data", false)
+	vs.SetDiskSerial("data", "data-serial")
+	vs.AddPVCFileSystem("shared", "my-shared", false)
+
+	vs.AddPVCFileSystem("shared", "my-other-shared", true)
+	if fs := vs.Spec.Storage.FileSystems; len(fs) != 1 || fs[0].Spec.PersistentVolumeClaim.ClaimName != "my-other-shared" {
+		t.Errorf("expected AddPVCFileSystem to replace the filesystem, got %+v", fs)
+	}
+	vs.AddPVCDisk("data", "my-other-data", true)
+	if d := vs.Spec.Storage.AdditionalDisks; len(d) != 1 || d[0].Spec.PersistentVolumeClaim.ClaimName != "my-other-data" || !d[0].ReadOnly || d[0].Serial != "data-serial" {
+		t.Errorf("expected AddPVCDisk to replace the disk source and keep its serial, got %+v", d)
+	}
+
+	disk := vs.Spec.Storage.AdditionalDisks[0]
+	disk.Serial = ""
+	if !vs.UpdateDisk(disk) || vs.Spec.Storage.AdditionalDisks[0].Serial != "" {
+		t.Errorf("expected UpdateDisk to replace the disk, got %+v", vs.Spec.Storage.AdditionalDisks[0])
+	}
+	disk.Name = "missing"
+	if vs.UpdateDisk(disk) || len(vs.Spec.Storage.AdditionalDisks) != 1 {
+		t.Error("expected UpdateDisk not to add a missing disk")
+	}
+	mountpoint := "/mnt/shared"
+	fs := vs.Spec.Storage.FileSystems[0]
+	fs.Mountpoint = &mountpoint
+	if !vs.UpdateFileSystem(fs) || vs.Spec.Storage.FileSystems[0].Mountpoint == nil {
+		t.Errorf("expected UpdateFileSystem to replace the filesystem, got %+v", vs.Spec.Storage.FileSystems[0])
+	}
+	fs.Name = "missing"
+	if vs.UpdateFileSystem(fs) || len(vs.Spec.Storage.FileSystems) != 1 {
+		t.Error("expected UpdateFileSystem not to add a missing filesystem")
+	}
+}
+
+func TestSetVPCMacAddress(t *testing.T) {
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	vs.AddVPC("my-vpc")
+	if err := vs.SetVPCMacAddress("other-vpc", "02:00:00:00:00:02"); err == nil {
+		t.Error("expected an error for an unknown VPC")
+	}
+	if err := vs.SetVPCMacAddress("my-vpc", "ff:ff:ff:ff:ff:ff"); err == nil {
+		t.Error("expected an error for a multicast MAC address")
+	}
+	if err := vs.SetVPCMacAddress("my-vpc", "02:00:00:00:00:02"); err != nil || vs.Spec.Network.VPCs[0].MACAddress != "02:00:00:00:00:02" {
+		t.Errorf("expected the MAC address to be set, got %v: %+v", err, vs.Spec.Network.VPCs)
+	}
+}
@@ -0,0 +1,674 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/coreweave/virtual-server/cloudinit"
+	"github.com/coreweave/virtual-server/ignition"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	kvv1 "kubevirt.io/api/core/v1"
+	cdiv1beta "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+// MaxExposedPorts is the maximum number of ports that may be exposed per protocol
+const MaxExposedPorts = 10
+
+// RootDiskName is the name reserved for the root disk of the VirtualServer
+const RootDiskName = "root"
+
+var (
+	macAddressRegExp     = regexp.MustCompile(MacAddressRegEx)
+	firmwareSerialRegExp = regexp.MustCompile(FirmwareSerialRegEx)
+	hashedPasswordRegExp = regexp.MustCompile(HashedPasswordRegEx)
+
+	supportedOSTypes = sets.NewString(
+		string(VirtualServerOSTypeLinux),
+		string(VirtualServerOSTypeWindows),
+	)
+	supportedProvisioners = sets.NewString(
+		string(VirtualServerProvisionerCloudInit),
+		string(VirtualServerProvisionerIgnition),
+	)
+	supportedVolumeModes = sets.NewString(
+		string(corev1.PersistentVolumeBlock),
+		string(corev1.PersistentVolumeFilesystem),
+	)
+	supportedAccessModes = sets.NewString(
+		string(corev1.ReadWriteOnce),
+		string(corev1.ReadOnlyMany),
+		string(corev1.ReadWriteMany),
+	)
+	supportedRunStrategies = sets.NewString(
+		string(kvv1.RunStrategyAlways),
+		string(kvv1.RunStrategyRerunOnFailure),
+		string(kvv1.RunStrategyManual),
+		string(kvv1.RunStrategyHalted),
+	)
+	supportedDNSPolicies = sets.NewString(
+		string(corev1.DNSClusterFirstWithHostNet),
+		string(corev1.DNSClusterFirst),
+		string(corev1.DNSDefault),
+		string(corev1.DNSNone),
+	)
+)
+
+// Validate checks the VirtualServer spec and returns every problem found.
+// Each error carries the path of the offending field, e.g. spec.network.tcp.ports[3].
+// Fields the CRD defaults, such as the CPU count or memory, are only validated when set.
+func (vs *VirtualServer) Validate() field.ErrorList {
+	return vs.Spec.Validate(field.NewPath("spec"))
+}
+
+// ValidateTransition checks an update of the VirtualServer from old. The spec is checked as by Validate, except that
+// unknown cloud-config keys are only rejected when spec.cloudInit changes, so that VirtualServers using keys unknown
+// to the cloudinit package remain updatable. In addition, the root disk cannot shrink, and an ephemeral root disk
+// cannot be resized.
+func (vs *VirtualServer) ValidateTransition(old *VirtualServer) field.ErrorList {
+	fldPath := field.NewPath("spec")
+	allErrs := vs.Spec.validate(vs.Spec.CloudInit != old.Spec.CloudInit, fldPath)
+	return append(allErrs, validateStorageRootTransition(&vs.Spec.Storage.Root, &old.Spec.Storage.Root, fldPath.Child("storage", "root"))...)
+}
+
+// Validate checks the VirtualServerSpec, reporting errors relative to fldPath
+func (spec *VirtualServerSpec) Validate(fldPath *field.Path) field.ErrorList {
+	return spec.validate(true, fldPath)
+}
+
+// validate checks the VirtualServerSpec, rejecting unknown cloud-config keys if knownCloudConfigKeysOnly is set
+func (spec *VirtualServerSpec) validate(knownCloudConfigKeysOnly bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateOS(&spec.OS, fldPath.Child("os"))...)
+	allErrs = append(allErrs, validateResources(&spec.Resources, fldPath.Child("resources"))...)
+	allErrs = append(allErrs, validateStorage(&spec.Storage, fldPath.Child("storage"))...)
+	allErrs = append(allErrs, validateNetwork(&spec.Network, fldPath.Child("network"))...)
+	allErrs = append(allErrs, validateUsers(spec.Users, &spec.OS, fldPath.Child("users"))...)
+	allErrs = append(allErrs, validateFirmware(&spec.Firmware, fldPath.Child("firmware"))...)
+	allErrs = append(allErrs, validateProbe(spec.LivenessProbe, fldPath.Child("livenessProbe"))...)
+	allErrs = append(allErrs, validateProbe(spec.ReadinessProbe, fldPath.Child("readinessProbe"))...)
+
+	if spec.OS.Provisioner == VirtualServerProvisionerIgnition {
+		if spec.CloudInit != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("cloudInit"), "may not be set when the provisioner is ignition, use ignition"))
+		}
+		if spec.NetworkData != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("networkData"), "may not be set when the provisioner is ignition"))
+		}
+	} else {
+		if spec.CloudInit != "" {
+			validateUserData := cloudinit.ValidateUserDataFormat
+			if knownCloudConfigKeysOnly {
+				validateUserData = cloudinit.ValidateUserData
+			}
+			allErrs = append(allErrs, validateUserData(spec.CloudInit, fldPath.Child("cloudInit"))...)
+		}
+		if spec.NetworkData != "" {
+			allErrs = append(allErrs, cloudinit.ValidateNetworkConfig(spec.NetworkData, fldPath.Child("networkData"))...)
+		}
+	}
+	if spec.Ignition != "" {
+		if spec.OS.Provisioner != VirtualServerProvisionerIgnition {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("ignition"), "may only be set when the provisioner is ignition"))
+		}
+		allErrs = append(allErrs, ignition.ValidateConfig(spec.Ignition, fldPath.Child("ignition"))...)
+	}
+	if err := spec.checkCloudInitSecret(); err != nil {
+		userDataPath := fldPath.Child("cloudInit")
+		if spec.OS.Provisioner == VirtualServerProvisionerIgnition {
+			userDataPath = fldPath.Child("ignition")
+		}
+		allErrs = append(allErrs, field.Forbidden(userDataPath, err.Error()))
+	}
+	if spec.MetaData != nil && spec.MetaData.LocalHostname != "" {
+		for _, msg := range validation.IsDNS1123Label(spec.MetaData.LocalHostname) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("metaData", "localHostname"), spec.MetaData.LocalHostname, msg))
+		}
+	}
+	if spec.RunStrategy != nil && !supportedRunStrategies.Has(string(*spec.RunStrategy)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("runStrategy"), *spec.RunStrategy, supportedRunStrategies.List()))
+	}
+	if spec.TerminationGracePeriodSeconds != nil && *spec.TerminationGracePeriodSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("terminationGracePeriodSeconds"), *spec.TerminationGracePeriodSeconds, "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+func validateOS(os *VirtualServerOS, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if os.Type == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("type"), ""))
+	} else if !supportedOSTypes.Has(string(os.Type)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), os.Type, supportedOSTypes.List()))
+	}
+	if os.Provisioner != "" && !supportedProvisioners.Has(string(os.Provisioner)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("provisioner"), os.Provisioner, supportedProvisioners.List()))
+	} else if os.Provisioner == VirtualServerProvisionerIgnition && os.Type == VirtualServerOSTypeWindows {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("provisioner"), "ignition is not supported for the windows operating system"))
+	}
+	if os.Windows != nil {
+		p := fldPath.Child("windows")
+		if os.Type != VirtualServerOSTypeWindows {
+			allErrs = append(allErrs, field.Forbidden(p, "may only be set for the windows operating system"))
+		}
+		allErrs = append(allErrs, os.Windows.UnattendConfig("").Validate(p)...)
+		if os.Windows.AdministratorPasswordSecretRef != nil {
+			allErrs = append(allErrs, validateSecretKeySelector(os.Windows.AdministratorPasswordSecretRef, p.Child("administratorPasswordSecretRef"))...)
+		}
+	}
+	return allErrs
+}
+
+func validateResources(res *VirtualServerResources, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	gpuPath := fldPath.Child("gpu")
+	cpuPath := fldPath.Child("cpu")
+
+	if res.GPU.Type != nil && res.CPU.Type != nil {
+		allErrs = append(allErrs, field.Forbidden(cpuPath.Child("type"), "may not be set when gpu.type is set"))
+	}
+	if res.GPU.Type != nil && *res.GPU.Type == "" {
+		allErrs = append(allErrs, field.Invalid(gpuPath.Child("type"), *res.GPU.Type, "must not be empty"))
+	}
+	if res.CPU.Type != nil && *res.CPU.Type == "" {
+		allErrs = append(allErrs, field.Invalid(cpuPath.Child("type"), *res.CPU.Type, "must not be empty"))
+	}
+	if res.GPU.Count != nil {
+		if res.GPU.Type == nil {
+			allErrs = append(allErrs, field.Required(gpuPath.Child("type"), "must be set when gpu.count is set"))
+		}
+		if *res.GPU.Count < 1 {
+			allErrs = append(allErrs, field.Invalid(gpuPath.Child("count"), *res.GPU.Count, "must be greater than or equal to 1"))
+		}
+	}
+	if res.Memory.Sign() < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("memory"), res.Memory.String(), "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func validateStorage(storage *VirtualServerStorage, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateStorageRoot(&storage.Root, fldPath.Child("root"))...)
+
+	names := sets.NewString(RootDiskName)
+	disksPath := fldPath.Child("additionalDisks")
+	for i := range storage.AdditionalDisks {
+		allErrs = append(allErrs, validateStorageVolume(&storage.AdditionalDisks[i].VirtualServerStorageVolume, names, disksPath.Index(i))...)
+		allErrs = append(allErrs, validateDiskSource(&storage.AdditionalDisks[i], disksPath.Index(i))...)
+	}
+	fsPath := fldPath.Child("filesystems")
+	for i := range storage.FileSystems {
+		allErrs = append(allErrs, validateStorageVolume(&storage.FileSystems[i].VirtualServerStorageVolume, names, fsPath.Index(i))...)
+	}
+
+	if storage.Swap != nil && storage.Swap.Sign() <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("swap"), storage.Swap.String(), "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func validateStorageRoot(root *VirtualServerStorageRoot, fldPath *field.Path) field.ErrorList {
+	allErrs := validateDataVolumeTemplate(&VirtualServerDataVolumeTemplate{
+		Size:             root.Size,
+		Source:           root.Source,
+		StorageClassName: root.StorageClassName,
+		VolumeMode:       root.VolumeMode,
+		AccessMode:       root.AccessMode,
+	}, fldPath)
+	if root.Ephemeral && root.Source != nil && root.Source.PVC == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("ephemeral"), "only a pvc source may be used with an ephemeral root disk"))
+	}
+	return allErrs
+}
+
+func validateStorageRootTransition(root *VirtualServerStorageRoot, oldRoot *VirtualServerStorageRoot, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch cmp := root.Size.Cmp(oldRoot.Size); {
+	case cmp < 0:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("size"), fmt.Sprintf("may not be less than the previous size %s", oldRoot.Size.String())))
+	case cmp > 0 && root.Ephemeral:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("size"), "may not be changed for an ephemeral root disk"))
+	}
+	return allErrs
+}
+
+// validateDataVolumeTemplate validates the size, source, storage class and modes of a DataVolume
+func validateDataVolumeTemplate(dv *VirtualServerDataVolumeTemplate, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if dv.Size.IsZero() {
+		allErrs = append(allErrs, field.Required(fldPath.Child("size"), ""))
+	} else if dv.Size.Sign() < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), dv.Size.String(), "must be greater than 0"))
+	}
+	if dv.Source == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("source"), ""))
+	} else {
+		allErrs = append(allErrs, validateDataVolumeSource(dv.Source, fldPath.Child("source"))...)
+	}
+	if dv.StorageClassName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("storageClassName"), ""))
+	}
+	if dv.VolumeMode != "" && !supportedVolumeModes.Has(string(dv.VolumeMode)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("volumeMode"), dv.VolumeMode, supportedVolumeModes.List()))
+	}
+	if dv.AccessMode != "" && !supportedAccessModes.Has(string(dv.AccessMode)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("accessMode"), dv.AccessMode, supportedAccessModes.List()))
+	}
+	return allErrs
+}
+
+// validateDataVolumeSource checks that exactly one source is set, and validates its URL and referenced Secret and ConfigMap names
+func validateDataVolumeSource(src *cdiv1beta.DataVolumeSource, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	n := 0
+	for _, set := range []bool{src.HTTP != nil, src.S3 != nil, src.Registry != nil, src.PVC != nil, src.Upload != nil, src.Blank != nil, src.Imageio != nil, src.VDDK != nil} {
+		if set {
+			n++
+		}
+	}
+	switch {
+	case n == 0:
+		allErrs = append(allErrs, field.Required(fldPath, "a DataVolume source must be specified"))
+	case n > 1:
+		allErrs = append(allErrs, field.Forbidden(fldPath, "may not specify more than 1 DataVolume source"))
+	}
+
+	if src.HTTP != nil {
+		p := fldPath.Child("http")
+		allErrs = append(allErrs, validateSourceURL(src.HTTP.URL, []string{"http", "https"}, p.Child("url"))...)
+		allErrs = append(allErrs, validateObjectName(src.HTTP.SecretRef, p.Child("secretRef"))...)
+		allErrs = append(allErrs, validateObjectName(src.HTTP.CertConfigMap, p.Child("certConfigMap"))...)
+	}
+	if src.S3 != nil {
+		p := fldPath.Child("s3")
+		allErrs = append(allErrs, validateSourceURL(src.S3.URL, []string{"http", "https", "s3"}, p.Child("url"))...)
+		allErrs = append(allErrs, validateObjectName(src.S3.SecretRef, p.Child("secretRef"))...)
+		allErrs = append(allErrs, validateObjectName(src.S3.CertConfigMap, p.Child("certConfigMap"))...)
+	}
+	if src.Registry != nil {
+		p := fldPath.Child("registry")
+		switch {
+		case src.Registry.URL == nil && src.Registry.ImageStream == nil:
+			allErrs = append(allErrs, field.Required(p.Child("url"), "one of url and imageStream must be specified"))
+		case src.Registry.URL != nil && src.Registry.ImageStream != nil:
+			allErrs = append(allErrs, field.Forbidden(p.Child("imageStream"), "may not be set with url"))
+		case src.Registry.URL != nil:
+			allErrs = append(allErrs, validateSourceURL(*src.Registry.URL, []string{cdiv1beta.RegistrySchemeDocker, cdiv1beta.RegistrySchemeOci}, p.Child("url"))...)
+		}
+		if src.Registry.SecretRef != nil {
+			allErrs = append(allErrs, validateObjectName(*src.Registry.SecretRef, p.Child("secretRef"))...)
+		}
+		if src.Registry.CertConfigMap != nil {
+			allErrs = append(allErrs, validateObjectName(*src.Registry.CertConfigMap, p.Child("certConfigMap"))...)
+		}
+	}
+	if src.PVC != nil && src.PVC.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("pvc", "name"), ""))
+	}
+	if src.VDDK != nil {
+		p := fldPath.Child("vddk")
+		allErrs = append(allErrs, validateSourceURL(src.VDDK.URL, []string{"http", "https"}, p.Child("url"))...)
+		if src.VDDK.UUID == "" {
+			allErrs = append(allErrs, field.Required(p.Child("uuid"), ""))
+		}
+		if src.VDDK.BackingFile == "" {
+			allErrs = append(allErrs, field.Required(p.Child("backingFile"), ""))
+		}
+		if src.VDDK.SecretRef == "" {
+			allErrs = append(allErrs, field.Required(p.Child("secretRef"), ""))
+		} else {
+			allErrs = append(allErrs, validateObjectName(src.VDDK.SecretRef, p.Child("secretRef"))...)
+		}
+	}
+	return allErrs
+}
+
+// validateSourceURL checks that the URL is set and uses one of the schemes
+func validateSourceURL(rawURL string, schemes []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if rawURL == "" {
+		return append(allErrs, field.Required(fldPath, ""))
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, rawURL, err.Error()))
+	}
+	if !sets.NewString(schemes...).Has(u.Scheme) || u.Host == "" && u.Path == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath, rawURL, fmt.Sprintf("must be a URL with one of the schemes %v", schemes)))
+	}
+	return allErrs
+}
+
+// validateObjectName checks that the optional Secret or ConfigMap name is a DNS subdomain
+func validateObjectName(name string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if name == "" {
+		return allErrs
+	}
+	for _, msg := range validation.IsDNS1123Subdomain(name) {
+		allErrs = append(allErrs, field.Invalid(fldPath, name, msg))
+	}
+	return allErrs
+}
+
+// validateDiskSource validates the DataVolume template and the container disk, ConfigMap, Secret and host disk sources of a disk
+func validateDiskSource(disk *VirtualServerDisks, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	specPath := fldPath.Child("spec")
+	if disk.DataVolumeTemplate != nil {
+		p := fldPath.Child("dataVolumeTemplate")
+		if disk.Spec.DataVolume == nil {
+			allErrs = append(allErrs, field.Required(specPath.Child("dataVolume"), "must name the DataVolume created from the dataVolumeTemplate"))
+		}
+		allErrs = append(allErrs, validateDataVolumeTemplate(disk.DataVolumeTemplate, p)...)
+	}
+	if src := disk.Spec.DataVolume; src != nil {
+		for _, msg := range validation.IsDNS1123Subdomain(src.Name) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("dataVolume", "name"), src.Name, msg))
+		}
+	}
+	if src := disk.Spec.ContainerDisk; src != nil && src.Image == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("containerDisk", "image"), ""))
+	}
+	if src := disk.Spec.ConfigMap; src != nil && src.Name == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("configMap", "name"), ""))
+	}
+	if src := disk.Spec.Secret; src != nil && src.SecretName == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("secret", "secretName"), ""))
+	}
+	if src := disk.Spec.HostDisk; src != nil {
+		p := specPath.Child("hostDisk")
+		if !strings.HasPrefix(src.Path, "/") {
+			allErrs = append(allErrs, field.Invalid(p.Child("path"), src.Path, "must be an absolute path"))
+		}
+		switch src.Type {
+		case kvv1.HostDiskExists:
+		case kvv1.HostDiskExistsOrCreate:
+			if src.Capacity.Sign() <= 0 {
+				allErrs = append(allErrs, field.Required(p.Child("capacity"), "must be greater than 0 when the type is DiskOrCreate"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(p.Child("type"), src.Type, []string{string(kvv1.HostDiskExists), string(kvv1.HostDiskExistsOrCreate)}))
+		}
+	}
+	return allErrs
+}
+
+// validateStorageVolume validates a named volume, recording its name in names to detect duplicates
+func validateStorageVolume(vol *VirtualServerStorageVolume, names sets.String, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	namePath := fldPath.Child("name")
+	if vol.Name == "" {
+		allErrs = append(allErrs, field.Required(namePath, ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Label(vol.Name) {
+			allErrs = append(allErrs, field.Invalid(namePath, vol.Name, msg))
+		}
+		if names.Has(vol.Name) {
+			allErrs = append(allErrs, field.Duplicate(namePath, vol.Name))
+		}
+		names.Insert(vol.Name)
+	}
+
+	switch n := countVolumeSources(&vol.Spec); {
+	case n == 0:
+		allErrs = append(allErrs, field.Required(fldPath.Child("spec"), "a volume source must be specified"))
+	case n > 1:
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("spec"), "may not specify more than 1 volume source"))
+	}
+	return allErrs
+}
+
+func countVolumeSources(src *kvv1.VolumeSource) int {
+	n := 0
+	for _, set := range []bool{
+		src.HostDisk != nil,
+		src.PersistentVolumeClaim != nil,
+		src.CloudInitNoCloud != nil,
+		src.CloudInitConfigDrive != nil,
+		src.Sysprep != nil,
+		src.ContainerDisk != nil,
+		src.Ephemeral != nil,
+		src.EmptyDisk != nil,
+		src.DataVolume != nil,
+		src.ConfigMap != nil,
+		src.Secret != nil,
+		src.DownwardAPI != nil,
+		src.ServiceAccount != nil,
+		src.DownwardMetrics != nil,
+	} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+func validateNetwork(network *VirtualServerNetwork, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validatePorts(network.TCP.Ports, fldPath.Child("tcp", "ports"))...)
+	allErrs = append(allErrs, validatePorts(network.UDP.Ports, fldPath.Child("udp", "ports"))...)
+
+	if network.DirectAttachLoadBalancerIP {
+		if len(network.TCP.Ports) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("tcp", "ports"), "may not be set when directAttachLoadBalancerIP is enabled"))
+		}
+		if len(network.UDP.Ports) > 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("udp", "ports"), "may not be set when directAttachLoadBalancerIP is enabled"))
+		}
+	}
+
+	serviceNames := sets.NewString()
+	for i, fip := range network.FloatingIPs {
+		p := fldPath.Child("floatingIPs").Index(i).Child("serviceName")
+		if fip.ServiceName == "" {
+			allErrs = append(allErrs, field.Required(p, ""))
+			continue
+		}
+		for _, msg := range validation.IsDNS1035Label(fip.ServiceName) {
+			allErrs = append(allErrs, field.Invalid(p, fip.ServiceName, msg))
+		}
+		if serviceNames.Has(fip.ServiceName) {
+			allErrs = append(allErrs, field.Duplicate(p, fip.ServiceName))
+		}
+		serviceNames.Insert(fip.ServiceName)
+	}
+
+	vpcNames := sets.NewString()
+	for i, vpc := range network.VPCs {
+		if vpc.MACAddress != "" && !macAddressRegExp.MatchString(vpc.MACAddress) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("vpcs").Index(i).Child("macAddress"), vpc.MACAddress, "must be ff:ff:ff:ff:ff:ff or FF-FF-FF-FF-FF-FF and a local unicast address"))
+		}
+		p := fldPath.Child("vpcs").Index(i).Child("name")
+		if vpc.Name == "" {
+			allErrs = append(allErrs, field.Required(p, ""))
+			continue
+		}
+		if vpcNames.Has(vpc.Name) {
+			allErrs = append(allErrs, field.Duplicate(p, vpc.Name))
+		}
+		vpcNames.Insert(vpc.Name)
+	}
+
+	if network.MACAddress != "" && !macAddressRegExp.MatchString(network.MACAddress) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("macAddress"), network.MACAddress, "must be ff:ff:ff:ff:ff:ff or FF-FF-FF-FF-FF-FF and a local unicast address"))
+	}
+	// The MAC address is set on the first interface, which is the first VPC interface without the pod network
+	if network.MACAddress != "" && network.DisableK8sNetworking && len(network.VPCs) > 0 && network.VPCs[0].MACAddress != "" {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("vpcs").Index(0).Child("macAddress"), "may not be set with macAddress when disableK8sNetworking is true"))
+	}
+	if network.DNSPolicy != nil && !supportedDNSPolicies.Has(string(*network.DNSPolicy)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("dnsPolicy"), *network.DNSPolicy, supportedDNSPolicies.List()))
+	}
+	if network.DNSPolicy != nil && *network.DNSPolicy == corev1.DNSNone &&
+		(network.DNSConfig == nil || len(network.DNSConfig.Nameservers) == 0) {
+		allErrs = append(allErrs, field.Required(fldPath.Child("dnsConfig", "nameservers"), "must provide at least one DNS nameserver when dnsPolicy is None"))
+	}
+	return allErrs
+}
+
+func validatePorts(ports []Port, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(ports) > MaxExposedPorts {
+		allErrs = append(allErrs, field.TooMany(fldPath, len(ports), MaxExposedPorts))
+	}
+	seen := map[Port]bool{}
+	for i, port := range ports {
+		for _, msg := range validation.IsValidPortNum(int(port)) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), port, msg))
+		}
+		if seen[port] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), port))
+		}
+		seen[port] = true
+	}
+	return allErrs
+}
+
+func validateUsers(users []VirtualServerUser, os *VirtualServerOS, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	usernames := sets.NewString()
+	for i, user := range users {
+		idxPath := fldPath.Index(i)
+		for j, key := range user.SSHPublicKeys {
+			p := idxPath.Child("sshPublicKeys").Index(j)
+			if key == "" {
+				allErrs = append(allErrs, field.Required(p, ""))
+			} else if strings.Contains(key, "\n") {
+				allErrs = append(allErrs, field.Invalid(p, key, "must be a single key without newlines"))
+			}
+		}
+		allErrs = append(allErrs, validateUserSecrets(&user, os, idxPath)...)
+		if user.Shell != "" && !strings.HasPrefix(user.Shell, "/") {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("shell"), user.Shell, "must be an absolute path"))
+		}
+		if user.Administrator && os.Type != VirtualServerOSTypeWindows {
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("administrator"), "may only be set for the windows operating system"))
+		}
+
+		p := idxPath.Child("username")
+		if user.Username == "" {
+			allErrs = append(allErrs, field.Required(p, ""))
+			continue
+		}
+		if usernames.Has(user.Username) {
+			allErrs = append(allErrs, field.Duplicate(p, user.Username))
+		}
+		usernames.Insert(user.Username)
+	}
+	return allErrs
+}
+
+// validateUserSecrets checks that at most one form of password and of SSH public keys is set,
+// and that the password is hashed for Ignition. Passwords are not included in the errors.
+func validateUserSecrets(user *VirtualServerUser, os *VirtualServerOS, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	passwords := 0
+	for _, set := range []bool{user.Password != "", user.HashedPassword != "", user.PasswordSecretRef != nil} {
+		if set {
+			passwords++
+		}
+	}
+	if passwords > 1 {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only one of password, hashedPassword and passwordSecretRef may be set"))
+	}
+	if user.HashedPassword != "" {
+		p := fldPath.Child("hashedPassword")
+		if os.Type == VirtualServerOSTypeWindows {
+			allErrs = append(allErrs, field.Forbidden(p, "is not supported for the windows operating system"))
+		} else if !hashedPasswordRegExp.MatchString(user.HashedPassword) {
+			allErrs = append(allErrs, field.Invalid(p, "<hashed password>", "must be in crypt(3) format, such as $6$salt$hash"))
+		}
+	}
+	if os.Provisioner == VirtualServerProvisionerIgnition {
+		if user.Password != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("password"), "is not supported when the provisioner is ignition, use hashedPassword"))
+		}
+		if user.PasswordSecretRef != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("passwordSecretRef"), "is not supported when the provisioner is ignition, use hashedPassword"))
+		}
+	}
+	if user.PasswordSecretRef != nil {
+		allErrs = append(allErrs, validateSecretKeySelector(user.PasswordSecretRef, fldPath.Child("passwordSecretRef"))...)
+	}
+	if user.SSHPublicKeySecretRef != nil {
+		p := fldPath.Child("sshPublicKeySecretRef")
+		if user.SSHPublicKey != "" || len(user.SSHPublicKeys) > 0 {
+			allErrs = append(allErrs, field.Forbidden(p, "may not be set with sshPublicKeys or sshpublickey"))
+		}
+		allErrs = append(allErrs, validateSecretKeySelector(user.SSHPublicKeySecretRef, p)...)
+	}
+	return allErrs
+}
+
+func validateSecretKeySelector(selector *corev1.SecretKeySelector, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if selector.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(selector.Name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), selector.Name, msg))
+		}
+	}
+	if selector.Key == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("key"), ""))
+	} else {
+		for _, msg := range validation.IsConfigMapKey(selector.Key) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("key"), selector.Key, msg))
+		}
+	}
+	return allErrs
+}
+
+func validateFirmware(firmware *Firmware, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if firmware.Serial != "" && !firmwareSerialRegExp.MatchString(firmware.Serial) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("serial"), firmware.Serial, "must be of the form ffffffff-ffff-ffff-ffff-ffffffffffff"))
+	}
+	return allErrs
+}
+
+func validateProbe(probe *kvv1.Probe, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if probe == nil {
+		return allErrs
+	}
+
+	handlers := 0
+	for _, set := range []bool{
+		probe.Exec != nil,
+		probe.GuestAgentPing != nil,
+		probe.HTTPGet != nil,
+		probe.TCPSocket != nil,
+	} {
+		if set {
+			handlers++
+		}
+	}
+	if handlers == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "must specify a handler type"))
+	} else if handlers > 1 {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "may not specify more than 1 handler type"))
+	}
+
+	for _, f := range []struct {
+		name  string
+		value int32
+	}{
+		{"initialDelaySeconds", probe.InitialDelaySeconds},
+		{"timeoutSeconds", probe.TimeoutSeconds},
+		{"periodSeconds", probe.PeriodSeconds},
+		{"successThreshold", probe.SuccessThreshold},
+		{"failureThreshold", probe.FailureThreshold},
+	} {
+		if f.value < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(f.name), f.value, "must be greater than or equal to 0"))
+		}
+	}
+	return allErrs
+}
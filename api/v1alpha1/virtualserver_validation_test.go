@@ -0,0 +1,445 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	vsv1alpha "github.com/coreweave/virtual-server/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	kvv1 "kubevirt.io/api/core/v1"
+	cdiv1beta "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+// newValidVirtualServer returns a minimal VirtualServer that passes validation
+func newValidVirtualServer() *vsv1alpha.VirtualServer {
+	vs := vsv1alpha.NewVirtualServer("my-virtual-server", "default")
+	vs.SetOS(vsv1alpha.VirtualServerOSTypeLinux)
+	vs.SetCPUCount(2)
+	vs.SetMemory("8Gi")
+	vs.ConfigureStorageRootWithPVCSource(vsv1alpha.VirtualServerStorageRootPVCSource{
+		Size:             "40Gi",
+		PVCName:          "ubuntu2004-docker-master-20210601-ord1",
+		PVCNamespace:     "vd-images",
+		StorageClassName: "block-nvme-ord1",
+		VolumeMode:       corev1.PersistentVolumeBlock,
+		AccessMode:       corev1.ReadWriteOnce,
+	})
+	return vs
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(vs *vsv1alpha.VirtualServer)
+		want   []string
+	}{
+		{
+			name:   "valid",
+			mutate: func(vs *vsv1alpha.VirtualServer) {},
+		},
+		{
+			name:   "missing os type",
+			mutate: func(vs *vsv1alpha.VirtualServer) { vs.Spec.OS.Type = "" },
+			want:   []string{"spec.os.type"},
+		},
+		{
+			name:   "unsupported os type",
+			mutate: func(vs *vsv1alpha.VirtualServer) { vs.Spec.OS.Type = "plan9" },
+			want:   []string{"spec.os.type"},
+		},
+		{
+			name: "gpu count without gpu type",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				count := uint32(1)
+				vs.Spec.Resources.GPU.Count = &count
+			},
+			want: []string{"spec.resources.gpu.type"},
+		},
+		{
+			name: "gpu and cpu type",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				gpu, cpu := "Quadro_RTX_4000", "amd-epyc-rome"
+				vs.Spec.Resources.GPU.Type = &gpu
+				vs.Spec.Resources.CPU.Type = &cpu
+			},
+			want: []string{"spec.resources.cpu.type"},
+		},
+		{
+			name: "ephemeral root with http source",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.ConfigureStorageRootWithHTTPSource(vsv1alpha.VirtualServerStorageRootHTTPSource{
+					Size:             "40Gi",
+					ImageUrl:         "https://example.com/image.qcow2",
+					StorageClassName: "block-nvme-ord1",
+				})
+				vs.Spec.Storage.Root.Ephemeral = true
+			},
+			want: []string{"spec.storage.root.ephemeral"},
+		},
+		{
+			name: "missing root storage",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.Spec.Storage.Root = vsv1alpha.VirtualServerStorageRoot{}
+			},
+			want: []string{"spec.storage.root.size", "spec.storage.root.source", "spec.storage.root.storageClassName"},
+		},
+		{
+			name: "duplicate disk names",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.AddEmptyDisk("scratch", "10Gi")
+				vs.AddPVCFileSystem("scratch", "data", false)
+			},
+			want: []string{"spec.storage.filesystems[0].name"},
+		},
+		{
+			name: "disk named root",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.AddPVCDisk(vsv1alpha.RootDiskName, "data", false)
+			},
+			want: []string{"spec.storage.additionalDisks[0].name"},
+		},
+		{
+			name: "disk without source",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.Spec.Storage.AdditionalDisks = append(vs.Spec.Storage.AdditionalDisks, vsv1alpha.VirtualServerDisks{
+					VirtualServerStorageVolume: vsv1alpha.VirtualServerStorageVolume{Name: "data"},
+				})
+			},
+			want: []string{"spec.storage.additionalDisks[0].spec"},
+		},
+		{
+			name: "invalid disk sources",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.AddContainerDisk("tools", "")
+				vs.AddSecretDisk("secret", "")
+				vs.AddHostDisk("host", "disk.img", kvv1.HostDiskExistsOrCreate, "")
+				vs.AddHostDisk("existing", "/var/lib/disk.img", "File", "")
+			},
+			want: []string{
+				"spec.storage.additionalDisks[0].spec.containerDisk.image",
+				"spec.storage.additionalDisks[1].spec.secret.secretName",
+				"spec.storage.additionalDisks[2].spec.hostDisk.path",
+				"spec.storage.additionalDisks[2].spec.hostDisk.capacity",
+				"spec.storage.additionalDisks[3].spec.hostDisk.type",
+			},
+		},
+		{
+			name: "invalid dataVolumeTemplate",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.AddDataVolumeDiskWithHTTPSource("dataset", vsv1alpha.VirtualServerStorageRootHTTPSource{
+					Size:       "10Gi",
+					ImageUrl:   "https://example.com/dataset.qcow2",
+					AccessMode: "ReadWriteAll",
+				})
+				vs.Spec.Storage.AdditionalDisks[0].DataVolumeTemplate.Source = nil
+				vs.Spec.Storage.AdditionalDisks[0].Spec = kvv1.VolumeSource{ContainerDisk: &kvv1.ContainerDiskSource{Image: "tools"}}
+			},
+			want: []string{
+				"spec.storage.additionalDisks[0].spec.dataVolume",
+				"spec.storage.additionalDisks[0].dataVolumeTemplate.source",
+				"spec.storage.additionalDisks[0].dataVolumeTemplate.storageClassName",
+				"spec.storage.additionalDisks[0].dataVolumeTemplate.accessMode",
+			},
+		},
+		{
+			name: "root with two sources",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.Spec.Storage.Root.Source.Blank = &cdiv1beta.DataVolumeBlankImage{}
+			},
+			want: []string{"spec.storage.root.source"},
+		},
+		{
+			name: "root registry source with invalid url and pull secret",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.ConfigureStorageRootWithRegistrySource(vsv1alpha.VirtualServerStorageRootRegistrySource{
+					Size:             "40Gi",
+					ImageUrl:         "quay.io/containerdisks/ubuntu:22.04",
+					PullSecretRef:    "My_Secret",
+					StorageClassName: "block-nvme-ord1",
+				})
+			},
+			want: []string{"spec.storage.root.source.registry.url", "spec.storage.root.source.registry.secretRef"},
+		},
+		{
+			name: "root vddk source without uuid and secret",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.ConfigureStorageRootWithVDDKSource(vsv1alpha.VirtualServerStorageRootVDDKSource{
+					Size:             "40Gi",
+					Url:              "https://vcenter.example.com",
+					BackingFile:      "[datastore] vm/vm.vmdk",
+					StorageClassName: "block-nvme-ord1",
+				})
+			},
+			want: []string{"spec.storage.root.source.vddk.uuid", "spec.storage.root.source.vddk.secretRef"},
+		},
+		{
+			name: "invalid and duplicate ports",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.Spec.Network.TCP.Ports = []vsv1alpha.Port{22, 443, 22, 0}
+			},
+			want: []string{"spec.network.tcp.ports[2]", "spec.network.tcp.ports[3]"},
+		},
+		{
+			name: "too many ports",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				for p := 1; p <= vsv1alpha.MaxExposedPorts+1; p++ {
+					vs.Spec.Network.UDP.Ports = append(vs.Spec.Network.UDP.Ports, vsv1alpha.Port(p))
+				}
+			},
+			want: []string{"spec.network.udp.ports"},
+		},
+		{
+			name: "direct attach with ports",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.ExposeTCPPort(22)
+				vs.DirectAttachLoadBalancerIP(true)
+			},
+			want: []string{"spec.network.tcp.ports"},
+		},
+		{
+			name: "invalid mac address",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.Spec.Network.MACAddress = "ff:ff:ff:ff:ff:ff"
+			},
+			want: []string{"spec.network.macAddress"},
+		},
+		{
+			name: "duplicate floating ips and vpcs",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.Spec.Network.FloatingIPs = []vsv1alpha.VirtualServerFloatingIP{{ServiceName: "fip"}, {ServiceName: "fip"}}
+				vs.AddVPC("vpc")
+				vs.AddVPC("vpc")
+			},
+			want: []string{"spec.network.floatingIPs[1].serviceName", "spec.network.vpcs[1].name"},
+		},
+		{
+			name: "dns policy none without nameservers",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				policy := corev1.DNSNone
+				vs.AddDNSPolicy(&policy)
+			},
+			want: []string{"spec.network.dnsConfig.nameservers"},
+		},
+		{
+			name: "duplicate and empty users",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.Spec.Users = []vsv1alpha.VirtualServerUser{{Username: "myuser"}, {Username: "myuser"}, {}}
+			},
+			want: []string{"spec.users[1].username", "spec.users[2].username"},
+		},
+		{
+			name: "invalid user keys, shell and administrator",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.AddUser(vsv1alpha.VirtualServerUser{
+					Username:      "myuser",
+					SSHPublicKeys: []string{"ssh-ed25519 AAAA one", "", "ssh-ed25519 AAAA two\nssh-ed25519 AAAA three"},
+					Shell:         "bash",
+					Administrator: true,
+				})
+			},
+			want: []string{"spec.users[0].sshPublicKeys[1]", "spec.users[0].sshPublicKeys[2]", "spec.users[0].shell", "spec.users[0].administrator"},
+		},
+		{
+			name: "multiple passwords and invalid secret references",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.AddUser(vsv1alpha.VirtualServerUser{
+					Username:              "myuser",
+					Password:              "password",
+					HashedPassword:        "not a hash",
+					SSHPublicKeys:         []string{"ssh-ed25519 AAAA one"},
+					SSHPublicKeySecretRef: &corev1.SecretKeySelector{Key: "bad/key"},
+				})
+			},
+			want: []string{"spec.users[0]", "spec.users[0].hashedPassword", "spec.users[0].sshPublicKeySecretRef", "spec.users[0].sshPublicKeySecretRef.name", "spec.users[0].sshPublicKeySecretRef.key"},
+		},
+		{
+			name: "hashed password on windows",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.SetOS(vsv1alpha.VirtualServerOSTypeWindows)
+				vs.AddUser(vsv1alpha.VirtualServerUser{Username: "myuser", HashedPassword: "$6$salt$hash"})
+			},
+			want: []string{"spec.users[0].hashedPassword"},
+		},
+		{
+			name: "windows configuration on linux",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.EnableWindowsRDP(true)
+			},
+			want: []string{"spec.os.windows"},
+		},
+		{
+			name: "invalid windows configuration",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.SetOS(vsv1alpha.VirtualServerOSTypeWindows)
+				vs.Spec.OS.Windows = &vsv1alpha.VirtualServerWindows{
+					ComputerName:       "my-very-long-computer-name",
+					ProductKey:         "not-a-key",
+					FirstLogonCommands: []string{" "},
+				}
+				vs.SetWindowsAdministratorPasswordSecretRef("", "password")
+			},
+			want: []string{"spec.os.windows.computerName", "spec.os.windows.productKey", "spec.os.windows.firstLogonCommands[0]", "spec.os.windows.administratorPasswordSecretRef.name"},
+		},
+		{
+			name: "cloud-init exceeding the Secret after compression",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.Spec.CloudInit = "#!/bin/sh\n# " + incompressible(2*corev1.MaxSecretSize)
+			},
+			want: []string{"spec.cloudInit"},
+		},
+		{
+			name: "ignition without the ignition provisioner",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.Spec.Ignition = `{"ignition":{"version":"3.3.0"}}`
+			},
+			want: []string{"spec.ignition"},
+		},
+		{
+			name: "cloud-init, network data and passwords with the ignition provisioner",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.SetProvisioner(vsv1alpha.VirtualServerProvisionerIgnition)
+				vs.Spec.CloudInit = "packages:\n- curl\n"
+				vs.Spec.NetworkData = "version: 2\n"
+				vs.Spec.Ignition = `{"ignition":{"version":"2.2.0"}}`
+				vs.AddUser(vsv1alpha.VirtualServerUser{Username: "core", Password: "password"})
+			},
+			want: []string{"spec.users[0].password", "spec.cloudInit", "spec.networkData", "spec.ignition.ignition.version"},
+		},
+		{
+			name: "ignition provisioner on windows",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.SetOS(vsv1alpha.VirtualServerOSTypeWindows)
+				vs.SetProvisioner(vsv1alpha.VirtualServerProvisionerIgnition)
+			},
+			want: []string{"spec.os.provisioner"},
+		},
+		{
+			name:   "invalid cloud-init",
+			mutate: func(vs *vsv1alpha.VirtualServer) { vs.Spec.CloudInit = "- not\n- a map" },
+			want:   []string{"spec.cloudInit"},
+		},
+		{
+			name:   "unknown cloud-init key",
+			mutate: func(vs *vsv1alpha.VirtualServer) { vs.Spec.CloudInit = "#cloud-config\npackges:\n- curl\n" },
+			want:   []string{"spec.cloudInit[packges]"},
+		},
+		{
+			name: "invalid network data, hostname and vpc mac address",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.Spec.NetworkData = "version: 3\n"
+				vs.Spec.MetaData = &vsv1alpha.VirtualServerMetaData{LocalHostname: "My_Host"}
+				vs.Spec.Network.VPCs = []vsv1alpha.VirtualServerVPC{{Name: "my-vpc", MACAddress: "ff:ff:ff:ff:ff:ff"}}
+			},
+			want: []string{"spec.network.vpcs[0].macAddress", "spec.networkData[version]", "spec.metaData.localHostname"},
+		},
+		{
+			name: "mac address on the first vpc and first interface",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.AddVPC("my-vpc")
+				vs.SetMacAddress("02:00:00:00:00:01")
+				vs.SetVPCMacAddress("my-vpc", "02:00:00:00:00:02")
+				vs.Spec.Network.DisableK8sNetworking = true
+			},
+			want: []string{"spec.network.vpcs[0].macAddress"},
+		},
+		{
+			name:   "invalid firmware serial",
+			mutate: func(vs *vsv1alpha.VirtualServer) { vs.Spec.Firmware.Serial = "1234" },
+			want:   []string{"spec.firmware.serial"},
+		},
+		{
+			name: "probe without handler",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.Spec.LivenessProbe = &kvv1.Probe{PeriodSeconds: -1}
+			},
+			want: []string{"spec.livenessProbe", "spec.livenessProbe.periodSeconds"},
+		},
+		{
+			name: "unsupported run strategy",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.RunStrategy(kvv1.RunStrategyOnce)
+			},
+			want: []string{"spec.runStrategy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs := newValidVirtualServer()
+			tt.mutate(vs)
+			assertFieldErrors(t, vs.Validate(), tt.want)
+		})
+	}
+}
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		name   string
+		old    func(vs *vsv1alpha.VirtualServer)
+		mutate func(vs *vsv1alpha.VirtualServer)
+		want   []string
+	}{
+		{
+			name:   "root disk grown",
+			mutate: func(vs *vsv1alpha.VirtualServer) { vs.Spec.Storage.Root.Size = resource.MustParse("80Gi") },
+		},
+		{
+			name:   "root disk shrunk",
+			mutate: func(vs *vsv1alpha.VirtualServer) { vs.Spec.Storage.Root.Size = resource.MustParse("20Gi") },
+			want:   []string{"spec.storage.root.size"},
+		},
+		{
+			name:   "unchanged unknown cloud-config key",
+			old:    func(vs *vsv1alpha.VirtualServer) { vs.Spec.CloudInit = "#cloud-config\nfuture_module: true\n" },
+			mutate: func(vs *vsv1alpha.VirtualServer) { vs.InitializeRunning(true) },
+		},
+		{
+			name: "changed unknown cloud-config key",
+			old:  func(vs *vsv1alpha.VirtualServer) { vs.Spec.CloudInit = "#cloud-config\nfuture_module: true\n" },
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.Spec.CloudInit = "#cloud-config\nfuture_module: false\n"
+			},
+			want: []string{"spec.cloudInit[future_module]"},
+		},
+		{
+			name: "ephemeral root disk grown",
+			mutate: func(vs *vsv1alpha.VirtualServer) {
+				vs.Spec.Storage.Root.Ephemeral = true
+				vs.Spec.Storage.Root.Size = resource.MustParse("80Gi")
+			},
+			want: []string{"spec.storage.root.size"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := newValidVirtualServer()
+			if tt.old != nil {
+				tt.old(old)
+			}
+			vs := old.DeepCopy()
+			tt.mutate(vs)
+			assertFieldErrors(t, vs.ValidateTransition(old), tt.want)
+			if err := vs.ValidateUpdate(old); (err != nil) != (len(tt.want) > 0) {
+				t.Errorf("unexpected ValidateUpdate result %v", err)
+			}
+		})
+	}
+}
+
+func assertFieldErrors(t *testing.T, errs field.ErrorList, want []string) {
+	t.Helper()
+	got := make([]string, 0, len(errs))
+	for _, err := range errs {
+		got = append(got, err.Field)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected errors for %v, got %v", want, errs)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected error %d for %s, got %s", i, want[i], errs[i])
+		}
+	}
+}
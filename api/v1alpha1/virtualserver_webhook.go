@@ -0,0 +1,72 @@
+package v1alpha1
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager registers the VirtualServer mutating and validating webhooks with the manager
+func (vs *VirtualServer) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(vs).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-virtualservers-coreweave-com-v1alpha1-virtualserver,mutating=true,failurePolicy=fail,sideEffects=None,groups=virtualservers.coreweave.com,resources=virtualservers,verbs=create;update,versions=v1alpha1,name=mvirtualserver.virtualservers.coreweave.com,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &VirtualServer{}
+
+// Default implements webhook.Defaulter.
+// It applies the CRD defaults, followed by the defaults that depend on other fields and therefore cannot be expressed in the CRD schema.
+func (vs *VirtualServer) Default() {
+	SetObjectDefaults_VirtualServer(vs)
+
+	gpu := &vs.Spec.Resources.GPU
+	if gpu.Type != nil && gpu.Count == nil {
+		count := uint32(1)
+		gpu.Count = &count
+	}
+}
+
+// +kubebuilder:webhook:path=/validate-virtualservers-coreweave-com-v1alpha1-virtualserver,mutating=false,failurePolicy=fail,sideEffects=None,groups=virtualservers.coreweave.com,resources=virtualservers,verbs=create;update,versions=v1alpha1,name=vvirtualserver.virtualservers.coreweave.com,admissionReviewVersions=v1
+
+var _ webhook.Validator = &VirtualServer{}
+
+// ValidateCreate implements webhook.Validator
+func (vs *VirtualServer) ValidateCreate() error {
+	return vs.validationError()
+}
+
+// ValidateUpdate implements webhook.Validator.
+// Updates to a VirtualServer that is being deleted are always permitted so that finalizers can be removed.
+func (vs *VirtualServer) ValidateUpdate(old runtime.Object) error {
+	if vs.DeletionTimestamp != nil {
+		return nil
+	}
+	oldVS, ok := old.(*VirtualServer)
+	if !ok {
+		return vs.validationError()
+	}
+	return vs.invalidError(vs.ValidateTransition(oldVS))
+}
+
+// ValidateDelete implements webhook.Validator
+func (vs *VirtualServer) ValidateDelete() error {
+	return nil
+}
+
+// validationError returns an Invalid API error listing every validation failure, or nil if the VirtualServer is valid
+func (vs *VirtualServer) validationError() error {
+	return vs.invalidError(vs.Validate())
+}
+
+// invalidError returns an Invalid API error listing the errors, or nil if there are none
+func (vs *VirtualServer) invalidError(allErrs field.ErrorList) error {
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("VirtualServer").GroupKind(), vs.Name, allErrs)
+}
@@ -0,0 +1,1035 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by conversion-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	unsafe "unsafe"
+
+	v1beta1 "github.com/coreweave/virtual-server/api/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	conversion "k8s.io/apimachinery/pkg/conversion"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	types "k8s.io/apimachinery/pkg/types"
+	corev1 "kubevirt.io/api/core/v1"
+	corev1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+func init() {
+	localSchemeBuilder.Register(RegisterConversions)
+}
+
+// RegisterConversions adds conversion functions to the given scheme.
+// Public to allow building arbitrary schemes.
+func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*DiskAttributes)(nil), (*v1beta1.DiskAttributes)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DiskAttributes_To_v1beta1_DiskAttributes(a.(*DiskAttributes), b.(*v1beta1.DiskAttributes), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.DiskAttributes)(nil), (*DiskAttributes)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DiskAttributes_To_v1alpha1_DiskAttributes(a.(*v1beta1.DiskAttributes), b.(*DiskAttributes), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Firmware)(nil), (*v1beta1.Firmware)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Firmware_To_v1beta1_Firmware(a.(*Firmware), b.(*v1beta1.Firmware), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.Firmware)(nil), (*Firmware)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Firmware_To_v1alpha1_Firmware(a.(*v1beta1.Firmware), b.(*Firmware), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerDataVolumeTemplate)(nil), (*v1beta1.VirtualServerDataVolumeTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerDataVolumeTemplate_To_v1beta1_VirtualServerDataVolumeTemplate(a.(*VirtualServerDataVolumeTemplate), b.(*v1beta1.VirtualServerDataVolumeTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VirtualServerDataVolumeTemplate)(nil), (*VirtualServerDataVolumeTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerDataVolumeTemplate_To_v1alpha1_VirtualServerDataVolumeTemplate(a.(*v1beta1.VirtualServerDataVolumeTemplate), b.(*VirtualServerDataVolumeTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerDisks)(nil), (*v1beta1.VirtualServerDisks)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerDisks_To_v1beta1_VirtualServerDisks(a.(*VirtualServerDisks), b.(*v1beta1.VirtualServerDisks), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VirtualServerDisks)(nil), (*VirtualServerDisks)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerDisks_To_v1alpha1_VirtualServerDisks(a.(*v1beta1.VirtualServerDisks), b.(*VirtualServerDisks), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerFilesystem)(nil), (*v1beta1.VirtualServerFilesystem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerFilesystem_To_v1beta1_VirtualServerFilesystem(a.(*VirtualServerFilesystem), b.(*v1beta1.VirtualServerFilesystem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VirtualServerFilesystem)(nil), (*VirtualServerFilesystem)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerFilesystem_To_v1alpha1_VirtualServerFilesystem(a.(*v1beta1.VirtualServerFilesystem), b.(*VirtualServerFilesystem), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerFloatingIP)(nil), (*v1beta1.VirtualServerFloatingIP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerFloatingIP_To_v1beta1_VirtualServerFloatingIP(a.(*VirtualServerFloatingIP), b.(*v1beta1.VirtualServerFloatingIP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VirtualServerFloatingIP)(nil), (*VirtualServerFloatingIP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerFloatingIP_To_v1alpha1_VirtualServerFloatingIP(a.(*v1beta1.VirtualServerFloatingIP), b.(*VirtualServerFloatingIP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerList)(nil), (*v1beta1.VirtualServerList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerList_To_v1beta1_VirtualServerList(a.(*VirtualServerList), b.(*v1beta1.VirtualServerList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VirtualServerList)(nil), (*VirtualServerList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerList_To_v1alpha1_VirtualServerList(a.(*v1beta1.VirtualServerList), b.(*VirtualServerList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerMetaData)(nil), (*v1beta1.VirtualServerMetaData)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerMetaData_To_v1beta1_VirtualServerMetaData(a.(*VirtualServerMetaData), b.(*v1beta1.VirtualServerMetaData), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VirtualServerMetaData)(nil), (*VirtualServerMetaData)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerMetaData_To_v1alpha1_VirtualServerMetaData(a.(*v1beta1.VirtualServerMetaData), b.(*VirtualServerMetaData), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerNetwork)(nil), (*v1beta1.VirtualServerNetwork)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerNetwork_To_v1beta1_VirtualServerNetwork(a.(*VirtualServerNetwork), b.(*v1beta1.VirtualServerNetwork), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerNetworkStatus)(nil), (*v1beta1.VirtualServerNetworkStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerNetworkStatus_To_v1beta1_VirtualServerNetworkStatus(a.(*VirtualServerNetworkStatus), b.(*v1beta1.VirtualServerNetworkStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VirtualServerNetworkStatus)(nil), (*VirtualServerNetworkStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerNetworkStatus_To_v1alpha1_VirtualServerNetworkStatus(a.(*v1beta1.VirtualServerNetworkStatus), b.(*VirtualServerNetworkStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerOS)(nil), (*v1beta1.VirtualServerOS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerOS_To_v1beta1_VirtualServerOS(a.(*VirtualServerOS), b.(*v1beta1.VirtualServerOS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VirtualServerOS)(nil), (*VirtualServerOS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerOS_To_v1alpha1_VirtualServerOS(a.(*v1beta1.VirtualServerOS), b.(*VirtualServerOS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerResourceCPU)(nil), (*v1beta1.VirtualServerResourceCPU)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerResourceCPU_To_v1beta1_VirtualServerResourceCPU(a.(*VirtualServerResourceCPU), b.(*v1beta1.VirtualServerResourceCPU), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerRootDiskStatus)(nil), (*v1beta1.VirtualServerRootDiskStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerRootDiskStatus_To_v1beta1_VirtualServerRootDiskStatus(a.(*VirtualServerRootDiskStatus), b.(*v1beta1.VirtualServerRootDiskStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VirtualServerRootDiskStatus)(nil), (*VirtualServerRootDiskStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerRootDiskStatus_To_v1alpha1_VirtualServerRootDiskStatus(a.(*v1beta1.VirtualServerRootDiskStatus), b.(*VirtualServerRootDiskStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerServiceTemplate)(nil), (*v1beta1.VirtualServerServiceTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerServiceTemplate_To_v1beta1_VirtualServerServiceTemplate(a.(*VirtualServerServiceTemplate), b.(*v1beta1.VirtualServerServiceTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VirtualServerServiceTemplate)(nil), (*VirtualServerServiceTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerServiceTemplate_To_v1alpha1_VirtualServerServiceTemplate(a.(*v1beta1.VirtualServerServiceTemplate), b.(*VirtualServerServiceTemplate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerSpec)(nil), (*v1beta1.VirtualServerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerSpec_To_v1beta1_VirtualServerSpec(a.(*VirtualServerSpec), b.(*v1beta1.VirtualServerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VirtualServerSpec)(nil), (*VirtualServerSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerSpec_To_v1alpha1_VirtualServerSpec(a.(*v1beta1.VirtualServerSpec), b.(*VirtualServerSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerStatus)(nil), (*v1beta1.VirtualServerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerStatus_To_v1beta1_VirtualServerStatus(a.(*VirtualServerStatus), b.(*v1beta1.VirtualServerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VirtualServerStatus)(nil), (*VirtualServerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerStatus_To_v1alpha1_VirtualServerStatus(a.(*v1beta1.VirtualServerStatus), b.(*VirtualServerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerStorage)(nil), (*v1beta1.VirtualServerStorage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerStorage_To_v1beta1_VirtualServerStorage(a.(*VirtualServerStorage), b.(*v1beta1.VirtualServerStorage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VirtualServerStorage)(nil), (*VirtualServerStorage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerStorage_To_v1alpha1_VirtualServerStorage(a.(*v1beta1.VirtualServerStorage), b.(*VirtualServerStorage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerStorageRoot)(nil), (*v1beta1.VirtualServerStorageRoot)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerStorageRoot_To_v1beta1_VirtualServerStorageRoot(a.(*VirtualServerStorageRoot), b.(*v1beta1.VirtualServerStorageRoot), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VirtualServerStorageRoot)(nil), (*VirtualServerStorageRoot)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerStorageRoot_To_v1alpha1_VirtualServerStorageRoot(a.(*v1beta1.VirtualServerStorageRoot), b.(*VirtualServerStorageRoot), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerStorageVolume)(nil), (*v1beta1.VirtualServerStorageVolume)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerStorageVolume_To_v1beta1_VirtualServerStorageVolume(a.(*VirtualServerStorageVolume), b.(*v1beta1.VirtualServerStorageVolume), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VirtualServerStorageVolume)(nil), (*VirtualServerStorageVolume)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerStorageVolume_To_v1alpha1_VirtualServerStorageVolume(a.(*v1beta1.VirtualServerStorageVolume), b.(*VirtualServerStorageVolume), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerVPC)(nil), (*v1beta1.VirtualServerVPC)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerVPC_To_v1beta1_VirtualServerVPC(a.(*VirtualServerVPC), b.(*v1beta1.VirtualServerVPC), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VirtualServerVPC)(nil), (*VirtualServerVPC)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerVPC_To_v1alpha1_VirtualServerVPC(a.(*v1beta1.VirtualServerVPC), b.(*VirtualServerVPC), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualServerWindows)(nil), (*v1beta1.VirtualServerWindows)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerWindows_To_v1beta1_VirtualServerWindows(a.(*VirtualServerWindows), b.(*v1beta1.VirtualServerWindows), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.VirtualServerWindows)(nil), (*VirtualServerWindows)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerWindows_To_v1alpha1_VirtualServerWindows(a.(*v1beta1.VirtualServerWindows), b.(*VirtualServerWindows), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*VirtualServerResourceGPU)(nil), (*v1beta1.VirtualServerResourceGPU)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerResourceGPU_To_v1beta1_VirtualServerResourceGPU(a.(*VirtualServerResourceGPU), b.(*v1beta1.VirtualServerResourceGPU), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*VirtualServerResources)(nil), (*v1beta1.VirtualServerResources)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerResources_To_v1beta1_VirtualServerResources(a.(*VirtualServerResources), b.(*v1beta1.VirtualServerResources), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*VirtualServerUser)(nil), (*v1beta1.VirtualServerUser)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServerUser_To_v1beta1_VirtualServerUser(a.(*VirtualServerUser), b.(*v1beta1.VirtualServerUser), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*VirtualServer)(nil), (*v1beta1.VirtualServer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualServer_To_v1beta1_VirtualServer(a.(*VirtualServer), b.(*v1beta1.VirtualServer), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.VirtualServerNetwork)(nil), (*VirtualServerNetwork)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerNetwork_To_v1alpha1_VirtualServerNetwork(a.(*v1beta1.VirtualServerNetwork), b.(*VirtualServerNetwork), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.VirtualServerResourceCPU)(nil), (*VirtualServerResourceCPU)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerResourceCPU_To_v1alpha1_VirtualServerResourceCPU(a.(*v1beta1.VirtualServerResourceCPU), b.(*VirtualServerResourceCPU), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.VirtualServerResourceGPU)(nil), (*VirtualServerResourceGPU)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerResourceGPU_To_v1alpha1_VirtualServerResourceGPU(a.(*v1beta1.VirtualServerResourceGPU), b.(*VirtualServerResourceGPU), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.VirtualServerResources)(nil), (*VirtualServerResources)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerResources_To_v1alpha1_VirtualServerResources(a.(*v1beta1.VirtualServerResources), b.(*VirtualServerResources), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.VirtualServerUser)(nil), (*VirtualServerUser)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServerUser_To_v1alpha1_VirtualServerUser(a.(*v1beta1.VirtualServerUser), b.(*VirtualServerUser), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.VirtualServer)(nil), (*VirtualServer)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VirtualServer_To_v1alpha1_VirtualServer(a.(*v1beta1.VirtualServer), b.(*VirtualServer), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_DiskAttributes_To_v1beta1_DiskAttributes(in *DiskAttributes, out *v1beta1.DiskAttributes, s conversion.Scope) error {
+	out.ReadOnly = in.ReadOnly
+	out.Serial = in.Serial
+	return nil
+}
+
+// Convert_v1alpha1_DiskAttributes_To_v1beta1_DiskAttributes is an autogenerated conversion function.
+func Convert_v1alpha1_DiskAttributes_To_v1beta1_DiskAttributes(in *DiskAttributes, out *v1beta1.DiskAttributes, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DiskAttributes_To_v1beta1_DiskAttributes(in, out, s)
+}
+
+func autoConvert_v1beta1_DiskAttributes_To_v1alpha1_DiskAttributes(in *v1beta1.DiskAttributes, out *DiskAttributes, s conversion.Scope) error {
+	out.ReadOnly = in.ReadOnly
+	out.Serial = in.Serial
+	return nil
+}
+
+// Convert_v1beta1_DiskAttributes_To_v1alpha1_DiskAttributes is an autogenerated conversion function.
+func Convert_v1beta1_DiskAttributes_To_v1alpha1_DiskAttributes(in *v1beta1.DiskAttributes, out *DiskAttributes, s conversion.Scope) error {
+	return autoConvert_v1beta1_DiskAttributes_To_v1alpha1_DiskAttributes(in, out, s)
+}
+
+func autoConvert_v1alpha1_Firmware_To_v1beta1_Firmware(in *Firmware, out *v1beta1.Firmware, s conversion.Scope) error {
+	out.UUID = types.UID(in.UUID)
+	out.Serial = in.Serial
+	return nil
+}
+
+// Convert_v1alpha1_Firmware_To_v1beta1_Firmware is an autogenerated conversion function.
+func Convert_v1alpha1_Firmware_To_v1beta1_Firmware(in *Firmware, out *v1beta1.Firmware, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Firmware_To_v1beta1_Firmware(in, out, s)
+}
+
+func autoConvert_v1beta1_Firmware_To_v1alpha1_Firmware(in *v1beta1.Firmware, out *Firmware, s conversion.Scope) error {
+	out.UUID = types.UID(in.UUID)
+	out.Serial = in.Serial
+	return nil
+}
+
+// Convert_v1beta1_Firmware_To_v1alpha1_Firmware is an autogenerated conversion function.
+func Convert_v1beta1_Firmware_To_v1alpha1_Firmware(in *v1beta1.Firmware, out *Firmware, s conversion.Scope) error {
+	return autoConvert_v1beta1_Firmware_To_v1alpha1_Firmware(in, out, s)
+}
+
+func autoConvert_v1alpha1_VirtualServer_To_v1beta1_VirtualServer(in *VirtualServer, out *v1beta1.VirtualServer, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_VirtualServerSpec_To_v1beta1_VirtualServerSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_VirtualServerStatus_To_v1beta1_VirtualServerStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1beta1_VirtualServer_To_v1alpha1_VirtualServer(in *v1beta1.VirtualServer, out *VirtualServer, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_VirtualServerSpec_To_v1alpha1_VirtualServerSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_VirtualServerStatus_To_v1alpha1_VirtualServerStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_VirtualServerDataVolumeTemplate_To_v1beta1_VirtualServerDataVolumeTemplate(in *VirtualServerDataVolumeTemplate, out *v1beta1.VirtualServerDataVolumeTemplate, s conversion.Scope) error {
+	out.Size = in.Size
+	out.Source = (*corev1beta1.DataVolumeSource)(unsafe.Pointer(in.Source))
+	out.StorageClassName = in.StorageClassName
+	out.VolumeMode = v1.PersistentVolumeMode(in.VolumeMode)
+	out.AccessMode = v1.PersistentVolumeAccessMode(in.AccessMode)
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerDataVolumeTemplate_To_v1beta1_VirtualServerDataVolumeTemplate is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerDataVolumeTemplate_To_v1beta1_VirtualServerDataVolumeTemplate(in *VirtualServerDataVolumeTemplate, out *v1beta1.VirtualServerDataVolumeTemplate, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerDataVolumeTemplate_To_v1beta1_VirtualServerDataVolumeTemplate(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerDataVolumeTemplate_To_v1alpha1_VirtualServerDataVolumeTemplate(in *v1beta1.VirtualServerDataVolumeTemplate, out *VirtualServerDataVolumeTemplate, s conversion.Scope) error {
+	out.Size = in.Size
+	out.Source = (*corev1beta1.DataVolumeSource)(unsafe.Pointer(in.Source))
+	out.StorageClassName = in.StorageClassName
+	out.VolumeMode = v1.PersistentVolumeMode(in.VolumeMode)
+	out.AccessMode = v1.PersistentVolumeAccessMode(in.AccessMode)
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerDataVolumeTemplate_To_v1alpha1_VirtualServerDataVolumeTemplate is an autogenerated conversion function.
+func Convert_v1beta1_VirtualServerDataVolumeTemplate_To_v1alpha1_VirtualServerDataVolumeTemplate(in *v1beta1.VirtualServerDataVolumeTemplate, out *VirtualServerDataVolumeTemplate, s conversion.Scope) error {
+	return autoConvert_v1beta1_VirtualServerDataVolumeTemplate_To_v1alpha1_VirtualServerDataVolumeTemplate(in, out, s)
+}
+
+func autoConvert_v1alpha1_VirtualServerDisks_To_v1beta1_VirtualServerDisks(in *VirtualServerDisks, out *v1beta1.VirtualServerDisks, s conversion.Scope) error {
+	if err := Convert_v1alpha1_VirtualServerStorageVolume_To_v1beta1_VirtualServerStorageVolume(&in.VirtualServerStorageVolume, &out.VirtualServerStorageVolume, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_DiskAttributes_To_v1beta1_DiskAttributes(&in.DiskAttributes, &out.DiskAttributes, s); err != nil {
+		return err
+	}
+	out.DataVolumeTemplate = (*v1beta1.VirtualServerDataVolumeTemplate)(unsafe.Pointer(in.DataVolumeTemplate))
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerDisks_To_v1beta1_VirtualServerDisks is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerDisks_To_v1beta1_VirtualServerDisks(in *VirtualServerDisks, out *v1beta1.VirtualServerDisks, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerDisks_To_v1beta1_VirtualServerDisks(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerDisks_To_v1alpha1_VirtualServerDisks(in *v1beta1.VirtualServerDisks, out *VirtualServerDisks, s conversion.Scope) error {
+	if err := Convert_v1beta1_VirtualServerStorageVolume_To_v1alpha1_VirtualServerStorageVolume(&in.VirtualServerStorageVolume, &out.VirtualServerStorageVolume, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_DiskAttributes_To_v1alpha1_DiskAttributes(&in.DiskAttributes, &out.DiskAttributes, s); err != nil {
+		return err
+	}
+	out.DataVolumeTemplate = (*VirtualServerDataVolumeTemplate)(unsafe.Pointer(in.DataVolumeTemplate))
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerDisks_To_v1alpha1_VirtualServerDisks is an autogenerated conversion function.
+func Convert_v1beta1_VirtualServerDisks_To_v1alpha1_VirtualServerDisks(in *v1beta1.VirtualServerDisks, out *VirtualServerDisks, s conversion.Scope) error {
+	return autoConvert_v1beta1_VirtualServerDisks_To_v1alpha1_VirtualServerDisks(in, out, s)
+}
+
+func autoConvert_v1alpha1_VirtualServerFilesystem_To_v1beta1_VirtualServerFilesystem(in *VirtualServerFilesystem, out *v1beta1.VirtualServerFilesystem, s conversion.Scope) error {
+	if err := Convert_v1alpha1_VirtualServerStorageVolume_To_v1beta1_VirtualServerStorageVolume(&in.VirtualServerStorageVolume, &out.VirtualServerStorageVolume, s); err != nil {
+		return err
+	}
+	out.Mountpoint = (*string)(unsafe.Pointer(in.Mountpoint))
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerFilesystem_To_v1beta1_VirtualServerFilesystem is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerFilesystem_To_v1beta1_VirtualServerFilesystem(in *VirtualServerFilesystem, out *v1beta1.VirtualServerFilesystem, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerFilesystem_To_v1beta1_VirtualServerFilesystem(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerFilesystem_To_v1alpha1_VirtualServerFilesystem(in *v1beta1.VirtualServerFilesystem, out *VirtualServerFilesystem, s conversion.Scope) error {
+	if err := Convert_v1beta1_VirtualServerStorageVolume_To_v1alpha1_VirtualServerStorageVolume(&in.VirtualServerStorageVolume, &out.VirtualServerStorageVolume, s); err != nil {
+		return err
+	}
+	out.Mountpoint = (*string)(unsafe.Pointer(in.Mountpoint))
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerFilesystem_To_v1alpha1_VirtualServerFilesystem is an autogenerated conversion function.
+func Convert_v1beta1_VirtualServerFilesystem_To_v1alpha1_VirtualServerFilesystem(in *v1beta1.VirtualServerFilesystem, out *VirtualServerFilesystem, s conversion.Scope) error {
+	return autoConvert_v1beta1_VirtualServerFilesystem_To_v1alpha1_VirtualServerFilesystem(in, out, s)
+}
+
+func autoConvert_v1alpha1_VirtualServerFloatingIP_To_v1beta1_VirtualServerFloatingIP(in *VirtualServerFloatingIP, out *v1beta1.VirtualServerFloatingIP, s conversion.Scope) error {
+	out.ServiceName = in.ServiceName
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerFloatingIP_To_v1beta1_VirtualServerFloatingIP is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerFloatingIP_To_v1beta1_VirtualServerFloatingIP(in *VirtualServerFloatingIP, out *v1beta1.VirtualServerFloatingIP, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerFloatingIP_To_v1beta1_VirtualServerFloatingIP(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerFloatingIP_To_v1alpha1_VirtualServerFloatingIP(in *v1beta1.VirtualServerFloatingIP, out *VirtualServerFloatingIP, s conversion.Scope) error {
+	out.ServiceName = in.ServiceName
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerFloatingIP_To_v1alpha1_VirtualServerFloatingIP is an autogenerated conversion function.
+func Convert_v1beta1_VirtualServerFloatingIP_To_v1alpha1_VirtualServerFloatingIP(in *v1beta1.VirtualServerFloatingIP, out *VirtualServerFloatingIP, s conversion.Scope) error {
+	return autoConvert_v1beta1_VirtualServerFloatingIP_To_v1alpha1_VirtualServerFloatingIP(in, out, s)
+}
+
+func autoConvert_v1alpha1_VirtualServerList_To_v1beta1_VirtualServerList(in *VirtualServerList, out *v1beta1.VirtualServerList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1beta1.VirtualServer, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_VirtualServer_To_v1beta1_VirtualServer(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerList_To_v1beta1_VirtualServerList is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerList_To_v1beta1_VirtualServerList(in *VirtualServerList, out *v1beta1.VirtualServerList, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerList_To_v1beta1_VirtualServerList(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerList_To_v1alpha1_VirtualServerList(in *v1beta1.VirtualServerList, out *VirtualServerList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualServer, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_VirtualServer_To_v1alpha1_VirtualServer(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerList_To_v1alpha1_VirtualServerList is an autogenerated conversion function.
+func Convert_v1beta1_VirtualServerList_To_v1alpha1_VirtualServerList(in *v1beta1.VirtualServerList, out *VirtualServerList, s conversion.Scope) error {
+	return autoConvert_v1beta1_VirtualServerList_To_v1alpha1_VirtualServerList(in, out, s)
+}
+
+func autoConvert_v1alpha1_VirtualServerMetaData_To_v1beta1_VirtualServerMetaData(in *VirtualServerMetaData, out *v1beta1.VirtualServerMetaData, s conversion.Scope) error {
+	out.LocalHostname = in.LocalHostname
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerMetaData_To_v1beta1_VirtualServerMetaData is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerMetaData_To_v1beta1_VirtualServerMetaData(in *VirtualServerMetaData, out *v1beta1.VirtualServerMetaData, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerMetaData_To_v1beta1_VirtualServerMetaData(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerMetaData_To_v1alpha1_VirtualServerMetaData(in *v1beta1.VirtualServerMetaData, out *VirtualServerMetaData, s conversion.Scope) error {
+	out.LocalHostname = in.LocalHostname
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerMetaData_To_v1alpha1_VirtualServerMetaData is an autogenerated conversion function.
+func Convert_v1beta1_VirtualServerMetaData_To_v1alpha1_VirtualServerMetaData(in *v1beta1.VirtualServerMetaData, out *VirtualServerMetaData, s conversion.Scope) error {
+	return autoConvert_v1beta1_VirtualServerMetaData_To_v1alpha1_VirtualServerMetaData(in, out, s)
+}
+
+func autoConvert_v1alpha1_VirtualServerNetwork_To_v1beta1_VirtualServerNetwork(in *VirtualServerNetwork, out *v1beta1.VirtualServerNetwork, s conversion.Scope) error {
+	out.DirectAttachLoadBalancerIP = in.DirectAttachLoadBalancerIP
+	out.FloatingIPs = *(*[]v1beta1.VirtualServerFloatingIP)(unsafe.Pointer(&in.FloatingIPs))
+	if err := Convert_v1alpha1_VirtualServerServiceTemplate_To_v1beta1_VirtualServerServiceTemplate(&in.TCP, &out.TCP, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_VirtualServerServiceTemplate_To_v1beta1_VirtualServerServiceTemplate(&in.UDP, &out.UDP, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_bool_To_Pointer_bool(&in.Public, &out.Public, s); err != nil {
+		return err
+	}
+	out.DNSConfig = (*v1.PodDNSConfig)(unsafe.Pointer(in.DNSConfig))
+	out.DNSPolicy = (*v1.DNSPolicy)(unsafe.Pointer(in.DNSPolicy))
+	out.MACAddress = in.MACAddress
+	out.Headless = in.Headless
+	out.VPCs = *(*[]v1beta1.VirtualServerVPC)(unsafe.Pointer(&in.VPCs))
+	out.DisableK8sNetworking = in.DisableK8sNetworking
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerNetwork_To_v1beta1_VirtualServerNetwork is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerNetwork_To_v1beta1_VirtualServerNetwork(in *VirtualServerNetwork, out *v1beta1.VirtualServerNetwork, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerNetwork_To_v1beta1_VirtualServerNetwork(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerNetwork_To_v1alpha1_VirtualServerNetwork(in *v1beta1.VirtualServerNetwork, out *VirtualServerNetwork, s conversion.Scope) error {
+	out.DirectAttachLoadBalancerIP = in.DirectAttachLoadBalancerIP
+	out.FloatingIPs = *(*[]VirtualServerFloatingIP)(unsafe.Pointer(&in.FloatingIPs))
+	if err := Convert_v1beta1_VirtualServerServiceTemplate_To_v1alpha1_VirtualServerServiceTemplate(&in.TCP, &out.TCP, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_VirtualServerServiceTemplate_To_v1alpha1_VirtualServerServiceTemplate(&in.UDP, &out.UDP, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_bool_To_bool(&in.Public, &out.Public, s); err != nil {
+		return err
+	}
+	out.DNSConfig = (*v1.PodDNSConfig)(unsafe.Pointer(in.DNSConfig))
+	out.DNSPolicy = (*v1.DNSPolicy)(unsafe.Pointer(in.DNSPolicy))
+	out.MACAddress = in.MACAddress
+	out.Headless = in.Headless
+	out.VPCs = *(*[]VirtualServerVPC)(unsafe.Pointer(&in.VPCs))
+	out.DisableK8sNetworking = in.DisableK8sNetworking
+	return nil
+}
+
+func autoConvert_v1alpha1_VirtualServerNetworkStatus_To_v1beta1_VirtualServerNetworkStatus(in *VirtualServerNetworkStatus, out *v1beta1.VirtualServerNetworkStatus, s conversion.Scope) error {
+	out.InternalIP = (*string)(unsafe.Pointer(in.InternalIP))
+	out.ExternalIP = (*string)(unsafe.Pointer(in.ExternalIP))
+	out.ServiceIP = (*string)(unsafe.Pointer(in.ServiceIP))
+	out.FloatingIPs = *(*map[string]string)(unsafe.Pointer(&in.FloatingIPs))
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerNetworkStatus_To_v1beta1_VirtualServerNetworkStatus is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerNetworkStatus_To_v1beta1_VirtualServerNetworkStatus(in *VirtualServerNetworkStatus, out *v1beta1.VirtualServerNetworkStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerNetworkStatus_To_v1beta1_VirtualServerNetworkStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerNetworkStatus_To_v1alpha1_VirtualServerNetworkStatus(in *v1beta1.VirtualServerNetworkStatus, out *VirtualServerNetworkStatus, s conversion.Scope) error {
+	out.InternalIP = (*string)(unsafe.Pointer(in.InternalIP))
+	out.ExternalIP = (*string)(unsafe.Pointer(in.ExternalIP))
+	out.ServiceIP = (*string)(unsafe.Pointer(in.ServiceIP))
+	out.FloatingIPs = *(*map[string]string)(unsafe.Pointer(&in.FloatingIPs))
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerNetworkStatus_To_v1alpha1_VirtualServerNetworkStatus is an autogenerated conversion function.
+func Convert_v1beta1_VirtualServerNetworkStatus_To_v1alpha1_VirtualServerNetworkStatus(in *v1beta1.VirtualServerNetworkStatus, out *VirtualServerNetworkStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_VirtualServerNetworkStatus_To_v1alpha1_VirtualServerNetworkStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_VirtualServerOS_To_v1beta1_VirtualServerOS(in *VirtualServerOS, out *v1beta1.VirtualServerOS, s conversion.Scope) error {
+	out.Type = v1beta1.VirtualServerOSType(in.Type)
+	out.Definition = in.Definition
+	out.EnableUEFIBoot = in.EnableUEFIBoot
+	out.Provisioner = v1beta1.VirtualServerProvisioner(in.Provisioner)
+	out.Windows = (*v1beta1.VirtualServerWindows)(unsafe.Pointer(in.Windows))
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerOS_To_v1beta1_VirtualServerOS is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerOS_To_v1beta1_VirtualServerOS(in *VirtualServerOS, out *v1beta1.VirtualServerOS, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerOS_To_v1beta1_VirtualServerOS(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerOS_To_v1alpha1_VirtualServerOS(in *v1beta1.VirtualServerOS, out *VirtualServerOS, s conversion.Scope) error {
+	out.Type = VirtualServerOSType(in.Type)
+	out.Definition = in.Definition
+	out.EnableUEFIBoot = in.EnableUEFIBoot
+	out.Provisioner = VirtualServerProvisioner(in.Provisioner)
+	out.Windows = (*VirtualServerWindows)(unsafe.Pointer(in.Windows))
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerOS_To_v1alpha1_VirtualServerOS is an autogenerated conversion function.
+func Convert_v1beta1_VirtualServerOS_To_v1alpha1_VirtualServerOS(in *v1beta1.VirtualServerOS, out *VirtualServerOS, s conversion.Scope) error {
+	return autoConvert_v1beta1_VirtualServerOS_To_v1alpha1_VirtualServerOS(in, out, s)
+}
+
+func autoConvert_v1alpha1_VirtualServerResourceCPU_To_v1beta1_VirtualServerResourceCPU(in *VirtualServerResourceCPU, out *v1beta1.VirtualServerResourceCPU, s conversion.Scope) error {
+	if err := metav1.Convert_Pointer_string_To_string(&in.Type, &out.Type, s); err != nil {
+		return err
+	}
+	out.Count = in.Count
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerResourceCPU_To_v1beta1_VirtualServerResourceCPU is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerResourceCPU_To_v1beta1_VirtualServerResourceCPU(in *VirtualServerResourceCPU, out *v1beta1.VirtualServerResourceCPU, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerResourceCPU_To_v1beta1_VirtualServerResourceCPU(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerResourceCPU_To_v1alpha1_VirtualServerResourceCPU(in *v1beta1.VirtualServerResourceCPU, out *VirtualServerResourceCPU, s conversion.Scope) error {
+	if err := metav1.Convert_string_To_Pointer_string(&in.Type, &out.Type, s); err != nil {
+		return err
+	}
+	out.Count = in.Count
+	return nil
+}
+
+func autoConvert_v1alpha1_VirtualServerResourceGPU_To_v1beta1_VirtualServerResourceGPU(in *VirtualServerResourceGPU, out *v1beta1.VirtualServerResourceGPU, s conversion.Scope) error {
+	if err := metav1.Convert_Pointer_string_To_string(&in.Type, &out.Type, s); err != nil {
+		return err
+	}
+	// WARNING: in.Count requires manual conversion: inconvertible types (*uint32 vs uint32)
+	return nil
+}
+
+func autoConvert_v1beta1_VirtualServerResourceGPU_To_v1alpha1_VirtualServerResourceGPU(in *v1beta1.VirtualServerResourceGPU, out *VirtualServerResourceGPU, s conversion.Scope) error {
+	if err := metav1.Convert_string_To_Pointer_string(&in.Type, &out.Type, s); err != nil {
+		return err
+	}
+	// WARNING: in.Count requires manual conversion: inconvertible types (uint32 vs *uint32)
+	return nil
+}
+
+func autoConvert_v1alpha1_VirtualServerResources_To_v1beta1_VirtualServerResources(in *VirtualServerResources, out *v1beta1.VirtualServerResources, s conversion.Scope) error {
+	out.Definition = in.Definition
+	// WARNING: in.GPU requires manual conversion: inconvertible types (github.com/coreweave/virtual-server/api/v1alpha1.VirtualServerResourceGPU vs *github.com/coreweave/virtual-server/api/v1beta1.VirtualServerResourceGPU)
+	if err := Convert_v1alpha1_VirtualServerResourceCPU_To_v1beta1_VirtualServerResourceCPU(&in.CPU, &out.CPU, s); err != nil {
+		return err
+	}
+	out.Memory = in.Memory
+	return nil
+}
+
+func autoConvert_v1beta1_VirtualServerResources_To_v1alpha1_VirtualServerResources(in *v1beta1.VirtualServerResources, out *VirtualServerResources, s conversion.Scope) error {
+	out.Definition = in.Definition
+	// WARNING: in.GPU requires manual conversion: inconvertible types (*github.com/coreweave/virtual-server/api/v1beta1.VirtualServerResourceGPU vs github.com/coreweave/virtual-server/api/v1alpha1.VirtualServerResourceGPU)
+	if err := Convert_v1beta1_VirtualServerResourceCPU_To_v1alpha1_VirtualServerResourceCPU(&in.CPU, &out.CPU, s); err != nil {
+		return err
+	}
+	out.Memory = in.Memory
+	return nil
+}
+
+func autoConvert_v1alpha1_VirtualServerRootDiskStatus_To_v1beta1_VirtualServerRootDiskStatus(in *VirtualServerRootDiskStatus, out *v1beta1.VirtualServerRootDiskStatus, s conversion.Scope) error {
+	out.RequestedSize = (*resource.Quantity)(unsafe.Pointer(in.RequestedSize))
+	out.Capacity = (*resource.Quantity)(unsafe.Pointer(in.Capacity))
+	out.GuestSize = (*resource.Quantity)(unsafe.Pointer(in.GuestSize))
+	out.ResizeState = v1beta1.VirtualServerRootDiskResizeState(in.ResizeState)
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerRootDiskStatus_To_v1beta1_VirtualServerRootDiskStatus is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerRootDiskStatus_To_v1beta1_VirtualServerRootDiskStatus(in *VirtualServerRootDiskStatus, out *v1beta1.VirtualServerRootDiskStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerRootDiskStatus_To_v1beta1_VirtualServerRootDiskStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerRootDiskStatus_To_v1alpha1_VirtualServerRootDiskStatus(in *v1beta1.VirtualServerRootDiskStatus, out *VirtualServerRootDiskStatus, s conversion.Scope) error {
+	out.RequestedSize = (*resource.Quantity)(unsafe.Pointer(in.RequestedSize))
+	out.Capacity = (*resource.Quantity)(unsafe.Pointer(in.Capacity))
+	out.GuestSize = (*resource.Quantity)(unsafe.Pointer(in.GuestSize))
+	out.ResizeState = VirtualServerRootDiskResizeState(in.ResizeState)
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerRootDiskStatus_To_v1alpha1_VirtualServerRootDiskStatus is an autogenerated conversion function.
+func Convert_v1beta1_VirtualServerRootDiskStatus_To_v1alpha1_VirtualServerRootDiskStatus(in *v1beta1.VirtualServerRootDiskStatus, out *VirtualServerRootDiskStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_VirtualServerRootDiskStatus_To_v1alpha1_VirtualServerRootDiskStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_VirtualServerServiceTemplate_To_v1beta1_VirtualServerServiceTemplate(in *VirtualServerServiceTemplate, out *v1beta1.VirtualServerServiceTemplate, s conversion.Scope) error {
+	out.Ports = *(*[]v1beta1.Port)(unsafe.Pointer(&in.Ports))
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerServiceTemplate_To_v1beta1_VirtualServerServiceTemplate is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerServiceTemplate_To_v1beta1_VirtualServerServiceTemplate(in *VirtualServerServiceTemplate, out *v1beta1.VirtualServerServiceTemplate, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerServiceTemplate_To_v1beta1_VirtualServerServiceTemplate(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerServiceTemplate_To_v1alpha1_VirtualServerServiceTemplate(in *v1beta1.VirtualServerServiceTemplate, out *VirtualServerServiceTemplate, s conversion.Scope) error {
+	out.Ports = *(*[]Port)(unsafe.Pointer(&in.Ports))
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerServiceTemplate_To_v1alpha1_VirtualServerServiceTemplate is an autogenerated conversion function.
+func Convert_v1beta1_VirtualServerServiceTemplate_To_v1alpha1_VirtualServerServiceTemplate(in *v1beta1.VirtualServerServiceTemplate, out *VirtualServerServiceTemplate, s conversion.Scope) error {
+	return autoConvert_v1beta1_VirtualServerServiceTemplate_To_v1alpha1_VirtualServerServiceTemplate(in, out, s)
+}
+
+func autoConvert_v1alpha1_VirtualServerSpec_To_v1beta1_VirtualServerSpec(in *VirtualServerSpec, out *v1beta1.VirtualServerSpec, s conversion.Scope) error {
+	out.Region = in.Region
+	out.Affinity = (*v1.Affinity)(unsafe.Pointer(in.Affinity))
+	if err := Convert_v1alpha1_VirtualServerOS_To_v1beta1_VirtualServerOS(&in.OS, &out.OS, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_VirtualServerResources_To_v1beta1_VirtualServerResources(&in.Resources, &out.Resources, s); err != nil {
+		return err
+	}
+	if err := Convert_v1alpha1_VirtualServerStorage_To_v1beta1_VirtualServerStorage(&in.Storage, &out.Storage, s); err != nil {
+		return err
+	}
+	out.LivenessProbe = (*corev1.Probe)(unsafe.Pointer(in.LivenessProbe))
+	out.ReadinessProbe = (*corev1.Probe)(unsafe.Pointer(in.ReadinessProbe))
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]v1beta1.VirtualServerUser, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_VirtualServerUser_To_v1beta1_VirtualServerUser(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Users = nil
+	}
+	if err := Convert_v1alpha1_VirtualServerNetwork_To_v1beta1_VirtualServerNetwork(&in.Network, &out.Network, s); err != nil {
+		return err
+	}
+	out.InitializeRunning = in.InitializeRunning
+	out.CloudInit = in.CloudInit
+	out.Ignition = in.Ignition
+	out.NetworkData = in.NetworkData
+	out.MetaData = (*v1beta1.VirtualServerMetaData)(unsafe.Pointer(in.MetaData))
+	out.RunStrategy = (*corev1.VirtualMachineRunStrategy)(unsafe.Pointer(in.RunStrategy))
+	if err := Convert_v1alpha1_Firmware_To_v1beta1_Firmware(&in.Firmware, &out.Firmware, s); err != nil {
+		return err
+	}
+	out.UseVirtioTransitional = (*bool)(unsafe.Pointer(in.UseVirtioTransitional))
+	out.TerminationGracePeriodSeconds = (*int64)(unsafe.Pointer(in.TerminationGracePeriodSeconds))
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerSpec_To_v1beta1_VirtualServerSpec is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerSpec_To_v1beta1_VirtualServerSpec(in *VirtualServerSpec, out *v1beta1.VirtualServerSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerSpec_To_v1beta1_VirtualServerSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerSpec_To_v1alpha1_VirtualServerSpec(in *v1beta1.VirtualServerSpec, out *VirtualServerSpec, s conversion.Scope) error {
+	out.Region = in.Region
+	out.Affinity = (*v1.Affinity)(unsafe.Pointer(in.Affinity))
+	if err := Convert_v1beta1_VirtualServerOS_To_v1alpha1_VirtualServerOS(&in.OS, &out.OS, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_VirtualServerResources_To_v1alpha1_VirtualServerResources(&in.Resources, &out.Resources, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_VirtualServerStorage_To_v1alpha1_VirtualServerStorage(&in.Storage, &out.Storage, s); err != nil {
+		return err
+	}
+	out.LivenessProbe = (*corev1.Probe)(unsafe.Pointer(in.LivenessProbe))
+	out.ReadinessProbe = (*corev1.Probe)(unsafe.Pointer(in.ReadinessProbe))
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]VirtualServerUser, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_VirtualServerUser_To_v1alpha1_VirtualServerUser(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Users = nil
+	}
+	if err := Convert_v1beta1_VirtualServerNetwork_To_v1alpha1_VirtualServerNetwork(&in.Network, &out.Network, s); err != nil {
+		return err
+	}
+	out.InitializeRunning = in.InitializeRunning
+	out.CloudInit = in.CloudInit
+	out.Ignition = in.Ignition
+	out.NetworkData = in.NetworkData
+	out.MetaData = (*VirtualServerMetaData)(unsafe.Pointer(in.MetaData))
+	out.RunStrategy = (*corev1.VirtualMachineRunStrategy)(unsafe.Pointer(in.RunStrategy))
+	if err := Convert_v1beta1_Firmware_To_v1alpha1_Firmware(&in.Firmware, &out.Firmware, s); err != nil {
+		return err
+	}
+	out.UseVirtioTransitional = (*bool)(unsafe.Pointer(in.UseVirtioTransitional))
+	out.TerminationGracePeriodSeconds = (*int64)(unsafe.Pointer(in.TerminationGracePeriodSeconds))
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerSpec_To_v1alpha1_VirtualServerSpec is an autogenerated conversion function.
+func Convert_v1beta1_VirtualServerSpec_To_v1alpha1_VirtualServerSpec(in *v1beta1.VirtualServerSpec, out *VirtualServerSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_VirtualServerSpec_To_v1alpha1_VirtualServerSpec(in, out, s)
+}
+
+func autoConvert_v1alpha1_VirtualServerStatus_To_v1beta1_VirtualServerStatus(in *VirtualServerStatus, out *v1beta1.VirtualServerStatus, s conversion.Scope) error {
+	out.Conditions = *(*[]metav1.Condition)(unsafe.Pointer(&in.Conditions))
+	out.Phase = v1beta1.VirtualServerPhase(in.Phase)
+	out.Started = metav1.ConditionStatus(in.Started)
+	out.ReadyReason = in.ReadyReason
+	out.ReadyMessage = in.ReadyMessage
+	out.ObservedGeneration = in.ObservedGeneration
+	if err := Convert_v1alpha1_VirtualServerNetworkStatus_To_v1beta1_VirtualServerNetworkStatus(&in.Network, &out.Network, s); err != nil {
+		return err
+	}
+	out.RootDisk = (*v1beta1.VirtualServerRootDiskStatus)(unsafe.Pointer(in.RootDisk))
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerStatus_To_v1beta1_VirtualServerStatus is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerStatus_To_v1beta1_VirtualServerStatus(in *VirtualServerStatus, out *v1beta1.VirtualServerStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerStatus_To_v1beta1_VirtualServerStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerStatus_To_v1alpha1_VirtualServerStatus(in *v1beta1.VirtualServerStatus, out *VirtualServerStatus, s conversion.Scope) error {
+	out.Conditions = *(*[]metav1.Condition)(unsafe.Pointer(&in.Conditions))
+	out.Phase = VirtualServerPhase(in.Phase)
+	out.Started = metav1.ConditionStatus(in.Started)
+	out.ReadyReason = in.ReadyReason
+	out.ReadyMessage = in.ReadyMessage
+	out.ObservedGeneration = in.ObservedGeneration
+	if err := Convert_v1beta1_VirtualServerNetworkStatus_To_v1alpha1_VirtualServerNetworkStatus(&in.Network, &out.Network, s); err != nil {
+		return err
+	}
+	out.RootDisk = (*VirtualServerRootDiskStatus)(unsafe.Pointer(in.RootDisk))
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerStatus_To_v1alpha1_VirtualServerStatus is an autogenerated conversion function.
+func Convert_v1beta1_VirtualServerStatus_To_v1alpha1_VirtualServerStatus(in *v1beta1.VirtualServerStatus, out *VirtualServerStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_VirtualServerStatus_To_v1alpha1_VirtualServerStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_VirtualServerStorage_To_v1beta1_VirtualServerStorage(in *VirtualServerStorage, out *v1beta1.VirtualServerStorage, s conversion.Scope) error {
+	if err := Convert_v1alpha1_VirtualServerStorageRoot_To_v1beta1_VirtualServerStorageRoot(&in.Root, &out.Root, s); err != nil {
+		return err
+	}
+	out.AdditionalDisks = *(*[]v1beta1.VirtualServerDisks)(unsafe.Pointer(&in.AdditionalDisks))
+	out.FileSystems = *(*[]v1beta1.VirtualServerFilesystem)(unsafe.Pointer(&in.FileSystems))
+	out.Swap = (*resource.Quantity)(unsafe.Pointer(in.Swap))
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerStorage_To_v1beta1_VirtualServerStorage is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerStorage_To_v1beta1_VirtualServerStorage(in *VirtualServerStorage, out *v1beta1.VirtualServerStorage, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerStorage_To_v1beta1_VirtualServerStorage(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerStorage_To_v1alpha1_VirtualServerStorage(in *v1beta1.VirtualServerStorage, out *VirtualServerStorage, s conversion.Scope) error {
+	if err := Convert_v1beta1_VirtualServerStorageRoot_To_v1alpha1_VirtualServerStorageRoot(&in.Root, &out.Root, s); err != nil {
+		return err
+	}
+	out.AdditionalDisks = *(*[]VirtualServerDisks)(unsafe.Pointer(&in.AdditionalDisks))
+	out.FileSystems = *(*[]VirtualServerFilesystem)(unsafe.Pointer(&in.FileSystems))
+	out.Swap = (*resource.Quantity)(unsafe.Pointer(in.Swap))
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerStorage_To_v1alpha1_VirtualServerStorage is an autogenerated conversion function.
+func Convert_v1beta1_VirtualServerStorage_To_v1alpha1_VirtualServerStorage(in *v1beta1.VirtualServerStorage, out *VirtualServerStorage, s conversion.Scope) error {
+	return autoConvert_v1beta1_VirtualServerStorage_To_v1alpha1_VirtualServerStorage(in, out, s)
+}
+
+func autoConvert_v1alpha1_VirtualServerStorageRoot_To_v1beta1_VirtualServerStorageRoot(in *VirtualServerStorageRoot, out *v1beta1.VirtualServerStorageRoot, s conversion.Scope) error {
+	out.Size = in.Size
+	out.Source = (*corev1beta1.DataVolumeSource)(unsafe.Pointer(in.Source))
+	out.StorageClassName = in.StorageClassName
+	out.VolumeMode = v1.PersistentVolumeMode(in.VolumeMode)
+	out.AccessMode = v1.PersistentVolumeAccessMode(in.AccessMode)
+	out.Ephemeral = in.Ephemeral
+	out.Serial = in.Serial
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerStorageRoot_To_v1beta1_VirtualServerStorageRoot is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerStorageRoot_To_v1beta1_VirtualServerStorageRoot(in *VirtualServerStorageRoot, out *v1beta1.VirtualServerStorageRoot, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerStorageRoot_To_v1beta1_VirtualServerStorageRoot(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerStorageRoot_To_v1alpha1_VirtualServerStorageRoot(in *v1beta1.VirtualServerStorageRoot, out *VirtualServerStorageRoot, s conversion.Scope) error {
+	out.Size = in.Size
+	out.Source = (*corev1beta1.DataVolumeSource)(unsafe.Pointer(in.Source))
+	out.StorageClassName = in.StorageClassName
+	out.VolumeMode = v1.PersistentVolumeMode(in.VolumeMode)
+	out.AccessMode = v1.PersistentVolumeAccessMode(in.AccessMode)
+	out.Ephemeral = in.Ephemeral
+	out.Serial = in.Serial
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerStorageRoot_To_v1alpha1_VirtualServerStorageRoot is an autogenerated conversion function.
+func Convert_v1beta1_VirtualServerStorageRoot_To_v1alpha1_VirtualServerStorageRoot(in *v1beta1.VirtualServerStorageRoot, out *VirtualServerStorageRoot, s conversion.Scope) error {
+	return autoConvert_v1beta1_VirtualServerStorageRoot_To_v1alpha1_VirtualServerStorageRoot(in, out, s)
+}
+
+func autoConvert_v1alpha1_VirtualServerStorageVolume_To_v1beta1_VirtualServerStorageVolume(in *VirtualServerStorageVolume, out *v1beta1.VirtualServerStorageVolume, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Spec = in.Spec
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerStorageVolume_To_v1beta1_VirtualServerStorageVolume is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerStorageVolume_To_v1beta1_VirtualServerStorageVolume(in *VirtualServerStorageVolume, out *v1beta1.VirtualServerStorageVolume, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerStorageVolume_To_v1beta1_VirtualServerStorageVolume(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerStorageVolume_To_v1alpha1_VirtualServerStorageVolume(in *v1beta1.VirtualServerStorageVolume, out *VirtualServerStorageVolume, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Spec = in.Spec
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerStorageVolume_To_v1alpha1_VirtualServerStorageVolume is an autogenerated conversion function.
+func Convert_v1beta1_VirtualServerStorageVolume_To_v1alpha1_VirtualServerStorageVolume(in *v1beta1.VirtualServerStorageVolume, out *VirtualServerStorageVolume, s conversion.Scope) error {
+	return autoConvert_v1beta1_VirtualServerStorageVolume_To_v1alpha1_VirtualServerStorageVolume(in, out, s)
+}
+
+func autoConvert_v1alpha1_VirtualServerUser_To_v1beta1_VirtualServerUser(in *VirtualServerUser, out *v1beta1.VirtualServerUser, s conversion.Scope) error {
+	out.Username = in.Username
+	out.Password = in.Password
+	out.HashedPassword = in.HashedPassword
+	out.PasswordSecretRef = (*v1.SecretKeySelector)(unsafe.Pointer(in.PasswordSecretRef))
+	// WARNING: in.SSHPublicKey requires manual conversion: does not exist in peer-type
+	out.SSHPublicKeys = *(*[]string)(unsafe.Pointer(&in.SSHPublicKeys))
+	out.SSHPublicKeySecretRef = (*v1.SecretKeySelector)(unsafe.Pointer(in.SSHPublicKeySecretRef))
+	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
+	out.Sudo = *(*[]string)(unsafe.Pointer(&in.Sudo))
+	out.Shell = in.Shell
+	out.LockPassword = (*bool)(unsafe.Pointer(in.LockPassword))
+	out.Administrator = in.Administrator
+	return nil
+}
+
+func autoConvert_v1beta1_VirtualServerUser_To_v1alpha1_VirtualServerUser(in *v1beta1.VirtualServerUser, out *VirtualServerUser, s conversion.Scope) error {
+	out.Username = in.Username
+	out.Password = in.Password
+	out.HashedPassword = in.HashedPassword
+	out.PasswordSecretRef = (*v1.SecretKeySelector)(unsafe.Pointer(in.PasswordSecretRef))
+	out.SSHPublicKeys = *(*[]string)(unsafe.Pointer(&in.SSHPublicKeys))
+	out.SSHPublicKeySecretRef = (*v1.SecretKeySelector)(unsafe.Pointer(in.SSHPublicKeySecretRef))
+	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
+	out.Sudo = *(*[]string)(unsafe.Pointer(&in.Sudo))
+	out.Shell = in.Shell
+	out.LockPassword = (*bool)(unsafe.Pointer(in.LockPassword))
+	out.Administrator = in.Administrator
+	return nil
+}
+
+func autoConvert_v1alpha1_VirtualServerVPC_To_v1beta1_VirtualServerVPC(in *VirtualServerVPC, out *v1beta1.VirtualServerVPC, s conversion.Scope) error {
+	out.Name = in.Name
+	out.MACAddress = in.MACAddress
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerVPC_To_v1beta1_VirtualServerVPC is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerVPC_To_v1beta1_VirtualServerVPC(in *VirtualServerVPC, out *v1beta1.VirtualServerVPC, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerVPC_To_v1beta1_VirtualServerVPC(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerVPC_To_v1alpha1_VirtualServerVPC(in *v1beta1.VirtualServerVPC, out *VirtualServerVPC, s conversion.Scope) error {
+	out.Name = in.Name
+	out.MACAddress = in.MACAddress
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerVPC_To_v1alpha1_VirtualServerVPC is an autogenerated conversion function.
+func Convert_v1beta1_VirtualServerVPC_To_v1alpha1_VirtualServerVPC(in *v1beta1.VirtualServerVPC, out *VirtualServerVPC, s conversion.Scope) error {
+	return autoConvert_v1beta1_VirtualServerVPC_To_v1alpha1_VirtualServerVPC(in, out, s)
+}
+
+func autoConvert_v1alpha1_VirtualServerWindows_To_v1beta1_VirtualServerWindows(in *VirtualServerWindows, out *v1beta1.VirtualServerWindows, s conversion.Scope) error {
+	out.ComputerName = in.ComputerName
+	out.TimeZone = in.TimeZone
+	out.Locale = in.Locale
+	out.ProductKey = in.ProductKey
+	out.AdministratorPasswordSecretRef = (*v1.SecretKeySelector)(unsafe.Pointer(in.AdministratorPasswordSecretRef))
+	out.FirstLogonCommands = *(*[]string)(unsafe.Pointer(&in.FirstLogonCommands))
+	out.EnableRDP = in.EnableRDP
+	return nil
+}
+
+// Convert_v1alpha1_VirtualServerWindows_To_v1beta1_VirtualServerWindows is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualServerWindows_To_v1beta1_VirtualServerWindows(in *VirtualServerWindows, out *v1beta1.VirtualServerWindows, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualServerWindows_To_v1beta1_VirtualServerWindows(in, out, s)
+}
+
+func autoConvert_v1beta1_VirtualServerWindows_To_v1alpha1_VirtualServerWindows(in *v1beta1.VirtualServerWindows, out *VirtualServerWindows, s conversion.Scope) error {
+	out.ComputerName = in.ComputerName
+	out.TimeZone = in.TimeZone
+	out.Locale = in.Locale
+	out.ProductKey = in.ProductKey
+	out.AdministratorPasswordSecretRef = (*v1.SecretKeySelector)(unsafe.Pointer(in.AdministratorPasswordSecretRef))
+	out.FirstLogonCommands = *(*[]string)(unsafe.Pointer(&in.FirstLogonCommands))
+	out.EnableRDP = in.EnableRDP
+	return nil
+}
+
+// Convert_v1beta1_VirtualServerWindows_To_v1alpha1_VirtualServerWindows is an autogenerated conversion function.
+func Convert_v1beta1_VirtualServerWindows_To_v1alpha1_VirtualServerWindows(in *v1beta1.VirtualServerWindows, out *VirtualServerWindows, s conversion.Scope) error {
+	return autoConvert_v1beta1_VirtualServerWindows_To_v1alpha1_VirtualServerWindows(in, out, s)
+}
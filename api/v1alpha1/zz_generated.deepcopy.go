@@ -86,11 +86,37 @@ func (in *VirtualServer) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualServerDataVolumeTemplate) DeepCopyInto(out *VirtualServerDataVolumeTemplate) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(v1beta1.DataVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualServerDataVolumeTemplate.
+func (in *VirtualServerDataVolumeTemplate) DeepCopy() *VirtualServerDataVolumeTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualServerDataVolumeTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualServerDisks) DeepCopyInto(out *VirtualServerDisks) {
 	*out = *in
 	in.VirtualServerStorageVolume.DeepCopyInto(&out.VirtualServerStorageVolume)
 	out.DiskAttributes = in.DiskAttributes
+	if in.DataVolumeTemplate != nil {
+		in, out := &in.DataVolumeTemplate, &out.DataVolumeTemplate
+		*out = new(VirtualServerDataVolumeTemplate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualServerDisks.
@@ -171,6 +197,21 @@ func (in *VirtualServerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualServerMetaData) DeepCopyInto(out *VirtualServerMetaData) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualServerMetaData.
+func (in *VirtualServerMetaData) DeepCopy() *VirtualServerMetaData {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualServerMetaData)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualServerNetwork) DeepCopyInto(out *VirtualServerNetwork) {
 	*out = *in
@@ -248,6 +289,11 @@ func (in *VirtualServerNetworkStatus) DeepCopy() *VirtualServerNetworkStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualServerOS) DeepCopyInto(out *VirtualServerOS) {
 	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = new(VirtualServerWindows)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualServerOS.
@@ -323,6 +369,36 @@ func (in *VirtualServerResources) DeepCopy() *VirtualServerResources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualServerRootDiskStatus) DeepCopyInto(out *VirtualServerRootDiskStatus) {
+	*out = *in
+	if in.RequestedSize != nil {
+		in, out := &in.RequestedSize, &out.RequestedSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.GuestSize != nil {
+		in, out := &in.GuestSize, &out.GuestSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualServerRootDiskStatus.
+func (in *VirtualServerRootDiskStatus) DeepCopy() *VirtualServerRootDiskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualServerRootDiskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualServerServiceTemplate) DeepCopyInto(out *VirtualServerServiceTemplate) {
 	*out = *in
@@ -351,7 +427,7 @@ func (in *VirtualServerSpec) DeepCopyInto(out *VirtualServerSpec) {
 		*out = new(v1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
-	out.OS = in.OS
+	in.OS.DeepCopyInto(&out.OS)
 	in.Resources.DeepCopyInto(&out.Resources)
 	in.Storage.DeepCopyInto(&out.Storage)
 	if in.LivenessProbe != nil {
@@ -367,9 +443,16 @@ func (in *VirtualServerSpec) DeepCopyInto(out *VirtualServerSpec) {
 	if in.Users != nil {
 		in, out := &in.Users, &out.Users
 		*out = make([]VirtualServerUser, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	in.Network.DeepCopyInto(&out.Network)
+	if in.MetaData != nil {
+		in, out := &in.MetaData, &out.MetaData
+		*out = new(VirtualServerMetaData)
+		**out = **in
+	}
 	if in.RunStrategy != nil {
 		in, out := &in.RunStrategy, &out.RunStrategy
 		*out = new(corev1.VirtualMachineRunStrategy)
@@ -409,6 +492,11 @@ func (in *VirtualServerStatus) DeepCopyInto(out *VirtualServerStatus) {
 		}
 	}
 	in.Network.DeepCopyInto(&out.Network)
+	if in.RootDisk != nil {
+		in, out := &in.RootDisk, &out.RootDisk
+		*out = new(VirtualServerRootDiskStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualServerStatus.
@@ -477,6 +565,21 @@ func (in *VirtualServerStorageRoot) DeepCopy() *VirtualServerStorageRoot {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualServerStorageRootBlankSource) DeepCopyInto(out *VirtualServerStorageRootBlankSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualServerStorageRootBlankSource.
+func (in *VirtualServerStorageRootBlankSource) DeepCopy() *VirtualServerStorageRootBlankSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualServerStorageRootBlankSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualServerStorageRootHTTPSource) DeepCopyInto(out *VirtualServerStorageRootHTTPSource) {
 	*out = *in
@@ -507,6 +610,66 @@ func (in *VirtualServerStorageRootPVCSource) DeepCopy() *VirtualServerStorageRoo
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualServerStorageRootRegistrySource) DeepCopyInto(out *VirtualServerStorageRootRegistrySource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualServerStorageRootRegistrySource.
+func (in *VirtualServerStorageRootRegistrySource) DeepCopy() *VirtualServerStorageRootRegistrySource {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualServerStorageRootRegistrySource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualServerStorageRootS3Source) DeepCopyInto(out *VirtualServerStorageRootS3Source) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualServerStorageRootS3Source.
+func (in *VirtualServerStorageRootS3Source) DeepCopy() *VirtualServerStorageRootS3Source {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualServerStorageRootS3Source)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualServerStorageRootUploadSource) DeepCopyInto(out *VirtualServerStorageRootUploadSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualServerStorageRootUploadSource.
+func (in *VirtualServerStorageRootUploadSource) DeepCopy() *VirtualServerStorageRootUploadSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualServerStorageRootUploadSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualServerStorageRootVDDKSource) DeepCopyInto(out *VirtualServerStorageRootVDDKSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualServerStorageRootVDDKSource.
+func (in *VirtualServerStorageRootVDDKSource) DeepCopy() *VirtualServerStorageRootVDDKSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualServerStorageRootVDDKSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualServerStorageVolume) DeepCopyInto(out *VirtualServerStorageVolume) {
 	*out = *in
@@ -526,6 +689,36 @@ func (in *VirtualServerStorageVolume) DeepCopy() *VirtualServerStorageVolume {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualServerUser) DeepCopyInto(out *VirtualServerUser) {
 	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSHPublicKeys != nil {
+		in, out := &in.SSHPublicKeys, &out.SSHPublicKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SSHPublicKeySecretRef != nil {
+		in, out := &in.SSHPublicKeySecretRef, &out.SSHPublicKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sudo != nil {
+		in, out := &in.Sudo, &out.Sudo
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LockPassword != nil {
+		in, out := &in.LockPassword, &out.LockPassword
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualServerUser.
@@ -552,3 +745,28 @@ func (in *VirtualServerVPC) DeepCopy() *VirtualServerVPC {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualServerWindows) DeepCopyInto(out *VirtualServerWindows) {
+	*out = *in
+	if in.AdministratorPasswordSecretRef != nil {
+		in, out := &in.AdministratorPasswordSecretRef, &out.AdministratorPasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FirstLogonCommands != nil {
+		in, out := &in.FirstLogonCommands, &out.FirstLogonCommands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualServerWindows.
+func (in *VirtualServerWindows) DeepCopy() *VirtualServerWindows {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualServerWindows)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,3 @@
+// +groupName=virtualservers.coreweave.com
+
+package v1beta1
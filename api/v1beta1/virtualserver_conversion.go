@@ -0,0 +1,8 @@
+package v1beta1
+
+// Hub marks the v1beta1 VirtualServer as the conversion hub.
+// All other VirtualServer versions are converted to and from v1beta1.
+func (*VirtualServer) Hub() {}
+
+// Hub marks the v1beta1 VirtualServerList as the conversion hub
+func (*VirtualServerList) Hub() {}
@@ -0,0 +1,613 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1beta1 defines the VirtualServer types.
+// The VirtualServer facilitates the creation and management of Virtual Server instances on the Coreweave Cloud kubernetes platform.
+//
+// v1beta1 is the storage version of the VirtualServer, and v1alpha1 VirtualServers are converted to and from it.
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kvv1 "kubevirt.io/api/core/v1"
+	cdiv1beta "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+// VirtualServerSpec defines the desired state of VirtualServer
+type VirtualServerSpec struct {
+	Region    string                 `json:"region,omitempty"`
+	Affinity  *corev1.Affinity       `json:"affinity,omitempty"`
+	OS        VirtualServerOS        `json:"os"`
+	Resources VirtualServerResources `json:"resources"`
+	Storage   VirtualServerStorage   `json:"storage"`
+	// +optional
+	LivenessProbe *kvv1.Probe `json:"livenessProbe,omitempty"`
+	// +optional
+	ReadinessProbe *kvv1.Probe `json:"readinessProbe,omitempty"`
+	// +optional
+	// +listType=map
+	// +listMapKey=username
+	Users []VirtualServerUser `json:"users,omitempty"`
+	// +optional
+	Network VirtualServerNetwork `json:"network,omitempty"`
+	// +optional
+	InitializeRunning bool `json:"initializeRunning,omitempty"`
+	// +optional
+	CloudInit string `json:"cloudInit,omitempty"`
+	// Ignition is an Ignition v3 config in JSON format, merged into the config generated for the users, filesystem mounts and hostname.
+	// It may only be set when the provisioner is ignition.
+	// +optional
+	Ignition string `json:"ignition,omitempty"`
+	// NetworkData is the cloud-init network-config, in version 1 or version 2 (netplan) format.
+	// It is stored in the cloud-init Secret with the user data, and shares its size limit.
+	// +optional
+	NetworkData string `json:"networkData,omitempty"`
+	// MetaData configures the cloud-init meta-data
+	// +optional
+	MetaData *VirtualServerMetaData `json:"metaData,omitempty"`
+	// +kubebuilder:validation:Enum=Always;RerunOnFailure;Manual;Halted
+	RunStrategy *kvv1.VirtualMachineRunStrategy `json:"runStrategy,omitempty"`
+	// +optional
+	Firmware Firmware `json:"firmware,omitempty"`
+	// +optional
+	UseVirtioTransitional         *bool  `json:"useVirtioTransitional,omitempty"`
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+}
+
+// VirtualServerMetaData configures the cloud-init meta-data of the Virtual Server.
+// Only the hostname is configurable. The instance-id is not: KubeVirt generates the NoCloud meta-data with the
+// instance-id <VirtualMachine name>.<namespace>, and provides no field to override it.
+type VirtualServerMetaData struct {
+	// LocalHostname is the hostname of the Virtual Server, a DNS label.
+	// It is set as the hostname of the VirtualMachineInstance, which KubeVirt passes as the local-hostname of the meta-data.
+	// Defaults to the VirtualServer name.
+	// +optional
+	// +kubebuilder:validation:MaxLength=63
+	LocalHostname string `json:"localHostname,omitempty"`
+}
+
+type Firmware struct {
+	// UUID reported by the vmi bios.
+	// Defaults to a random generated uid.
+	// +optional
+	UUID types.UID `json:"uuid,omitempty"`
+	// The system-serial-number in SMBIOS
+	// +optional
+	Serial string `json:"serial,omitempty"`
+}
+
+// VirtualServerStatus defines the observed state of VirtualServer
+type VirtualServerStatus struct {
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Phase summarizes the conditions of the VirtualServer.
+	// It is computed from the conditions and should not be set directly.
+	// +optional
+	Phase VirtualServerPhase `json:"phase,omitempty"`
+	// Started is the status of the VirtualServerStarted condition
+	// +optional
+	Started metav1.ConditionStatus `json:"started,omitempty"`
+	// ReadyReason is the reason of the Ready condition
+	// +optional
+	ReadyReason string `json:"readyReason,omitempty"`
+	// ReadyMessage is the message of the Ready condition
+	// +optional
+	ReadyMessage string `json:"readyMessage,omitempty"`
+	// ObservedGeneration is the most recent generation of the VirtualServer spec observed by the controller
+	// +optional
+	ObservedGeneration int64                      `json:"observedGeneration,omitempty"`
+	Network            VirtualServerNetworkStatus `json:"network,omitempty"`
+	// RootDisk is the observed state of the root disk, including the progress of a resize
+	// +optional
+	RootDisk *VirtualServerRootDiskStatus `json:"rootDisk,omitempty"`
+}
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=vserver;vs
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".status.readyReason",name=status,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.readyMessage",name=reason,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.started",name=started,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.phase",name=Phase,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.network.internalIP",name=Internal IP,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.network.externalIP",name=External IP,type=string
+
+// VirtualServer is the Schema for the virtualservers API.
+// It allows for configuring a Virtual Server instance on the Coreweave Cloud kubernetes platform.
+// The VirtualServer handles the creation and lifecycle of a VirtualMachine and Services.
+type VirtualServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualServerSpec   `json:"spec,omitempty"`
+	Status VirtualServerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualServerList contains a list of VirtualServer
+type VirtualServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualServer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VirtualServer{}, &VirtualServerList{})
+}
+
+// Virtual Server Types
+
+// VirtualServerOS defines the Operating System of the VirtualServer
+type VirtualServerOS struct {
+	// The Operating System run in the Virtual Server
+	// VirtualServerOSType may be "windows" or "linux"
+	// +kubebuilder:validation:Enum=windows;linux
+	Type VirtualServerOSType `json:"type"`
+	// The operating system configuration definition for internal use
+	// See https://docs.coreweave.com/virtual-desktop for details on which definition value best suits your configuration.
+	// Defaults to "a"
+	// +optional
+	// +kubebuilder:default=a
+	Definition string `json:"definition,omitempty"`
+	// Configure the Virtual Server use a UEFI bootloader
+	// +optional
+	EnableUEFIBoot bool `json:"enableUEFIBoot,omitempty"`
+	// Provisioner is the first boot provisioning agent of the image, cloud-init by default.
+	// Fedora CoreOS and Flatcar images are provisioned with ignition: the users, filesystem mounts and hostname are
+	// rendered into an Ignition config, merged with the VirtualServer ignition config.
+	// +optional
+	// +kubebuilder:validation:Enum=cloud-init;ignition
+	Provisioner VirtualServerProvisioner `json:"provisioner,omitempty"`
+	// Windows configures the answer file (unattend.xml) applied when a Windows Virtual Server is first started.
+	// It may only be set for the windows operating system.
+	// +optional
+	Windows *VirtualServerWindows `json:"windows,omitempty"`
+}
+
+// VirtualServerWindows personalizes a Windows Virtual Server started from a generalized (sysprepped) image
+type VirtualServerWindows struct {
+	// ComputerName is the NetBIOS name of the Virtual Server, up to 15 letters, digits and hyphens.
+	// Windows generates a random name when empty.
+	// +optional
+	// +kubebuilder:validation:MaxLength=15
+	ComputerName string `json:"computerName,omitempty"`
+	// TimeZone is the Windows time zone ID, such as Pacific Standard Time
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// Locale is the language and region, such as en-US, used for the input, system, UI and user locales
+	// +optional
+	Locale string `json:"locale,omitempty"`
+	// ProductKey is the Windows product key, in XXXXX-XXXXX-XXXXX-XXXXX-XXXXX format
+	// +optional
+	ProductKey string `json:"productKey,omitempty"`
+	// AdministratorPasswordSecretRef selects the key of a Secret in the VirtualServer namespace holding the password of the built-in Administrator account
+	// +optional
+	AdministratorPasswordSecretRef *corev1.SecretKeySelector `json:"administratorPasswordSecretRef,omitempty"`
+	// FirstLogonCommands are run in order the first time a user logs on
+	// +optional
+	FirstLogonCommands []string `json:"firstLogonCommands,omitempty"`
+	// EnableRDP enables Remote Desktop with network level authentication, and allows it through the firewall
+	// +optional
+	EnableRDP bool `json:"enableRDP,omitempty"`
+}
+
+// VirtualServerResources defines the resources requested for the VirtualServer
+type VirtualServerResources struct {
+	// The resource configuration definition for internal use
+	// See https://docs.coreweave.com/virtual-desktop for details on which definition value best suits your configuration.
+	// Defaults to "a"
+	// +optional
+	// +kubebuilder:default=a
+	Definition string `json:"definition,omitempty"`
+	// GPU describes the GPU resource request.
+	// The VirtualServer is a GPU server if set, and a CPU server otherwise
+	// +optional
+	GPU *VirtualServerResourceGPU `json:"gpu,omitempty"`
+	// CPU describes the CPU resource request
+	// +optional
+	// +kubebuilder:default={count: 2}
+	CPU VirtualServerResourceCPU `json:"cpu,omitempty"`
+	// Memory describes the memory resource request
+	// +optional
+	// +kubebuilder:default="8Gi"
+	Memory resource.Quantity `json:"memory,omitempty"`
+}
+
+// VirtualServerResourceCPU describes the CPU request for the VirtualServer
+type VirtualServerResourceCPU struct {
+	// Type is the CPU type to request
+	// See Coreweave Metadata API for available CPU types
+	// The CPU type may not be set on a GPU server
+	// +optional
+	Type string `json:"type,omitempty"`
+	// The number of CPU cores to request
+	// +optional
+	// +kubebuilder:default=2
+	// +kubebuilder:validation:Minimum=1
+	Count uint32 `json:"count"`
+}
+
+// VirtualServerResourceGPU describes the GPU request for the VirtualServer
+type VirtualServerResourceGPU struct {
+	// Type is the GPU type to request
+	// See Coreweave Metadata API for available GPU types
+	Type string `json:"type"`
+	// The number of GPUs to request.
+	// Defaults to 1
+	// +optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	Count uint32 `json:"count,omitempty"`
+}
+
+// VirtualServerStorage describes the Storage request for the VirtualServer
+type VirtualServerStorage struct {
+	// Root describes the root filesystem of the VirtualServer
+	Root VirtualServerStorageRoot `json:"root"`
+	// AdditionalDisks is an array of disks devices added to the VirtualServer
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	AdditionalDisks []VirtualServerDisks `json:"additionalDisks,omitempty"`
+	// Filesystems is an array of filesystem mounted to the VirtualServer
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	FileSystems []VirtualServerFilesystem `json:"filesystems,omitempty"`
+	// Swap describes a swap volume of the specified size added to the VirtualServer
+	// An emptyDisk is created of the specified size to be used as the swap disk
+	Swap *resource.Quantity `json:"swap,omitempty"`
+}
+
+// VirtualServerStorageRoot describes the Storage request for root filesystem of the VirtualServer
+type VirtualServerStorageRoot struct {
+	// Size specifies the root filesystem volume size
+	Size resource.Quantity `json:"size"`
+	// Source describes the DataVolumeSource for the root filesystem DataVolume
+	// A DataVolume will be dynamically created alongside the VirtualServer, and the underlying PVC will be mounted as the root filesystem
+	Source *cdiv1beta.DataVolumeSource `json:"source"`
+	// StorageClassName specifies the StorageClassName of the root filesystem PVC
+	StorageClassName string `json:"storageClassName"`
+	// VolumeMode specifies the VolumeMode of the root filesystem PVC.
+	// Defaults to Block
+	// +kubebuilder:default=Block
+	// +optional
+	VolumeMode corev1.PersistentVolumeMode `json:"volumeMode,omitempty"`
+	// AccessMode specifies the AccessMode of the root filesystem PVC.
+	// Defaults to ReadWriteOnce
+	// +kubebuilder:default=ReadWriteOnce
+	// +optional
+	AccessMode corev1.PersistentVolumeAccessMode `json:"accessMode,omitempty"`
+	// Ephemeral, if true, will disable disk persistence for the root filesystem.
+	// A local image will be used to write changes, and will be discared when the Virtual Server is stopped or restarted.
+	// Only a PVC source may be specified
+	Ephemeral bool `json:"ephemeral,omitempty"`
+	// Disk serial number
+	// +optional
+	Serial string `json:"serial,omitempty"`
+}
+
+// VirtualServerStorageVolume describes a named volume in the VirtualServer
+type VirtualServerStorageVolume struct {
+	Name string            `json:"name"`
+	Spec kvv1.VolumeSource `json:"spec"`
+}
+
+// DiskAttributes describes disk sttributes
+type DiskAttributes struct {
+	// ReadOnly
+	// +optional
+	ReadOnly bool `json:"readOnly,omitempty"`
+	// Disk serial number
+	// +optional
+	Serial string `json:"serial,omitempty"`
+}
+
+type VirtualServerDisks struct {
+	VirtualServerStorageVolume `json:",inline"`
+	DiskAttributes             `json:",inline"`
+	// DataVolumeTemplate describes a DataVolume created alongside the VirtualServer, such as a clone of a PVC or an
+	// imported image. The DataVolume is named after the dataVolume source of the disk spec, which must be set.
+	// +optional
+	DataVolumeTemplate *VirtualServerDataVolumeTemplate `json:"dataVolumeTemplate,omitempty"`
+}
+
+// VirtualServerDataVolumeTemplate describes the DataVolume backing an additional disk
+type VirtualServerDataVolumeTemplate struct {
+	// Size specifies the DataVolume size
+	Size resource.Quantity `json:"size"`
+	// Source describes the DataVolumeSource the disk is cloned or imported from
+	Source *cdiv1beta.DataVolumeSource `json:"source"`
+	// StorageClassName specifies the StorageClassName of the DataVolume PVC
+	StorageClassName string `json:"storageClassName"`
+	// VolumeMode specifies the VolumeMode of the DataVolume PVC.
+	// Defaults to Block
+	// +kubebuilder:default=Block
+	// +optional
+	VolumeMode corev1.PersistentVolumeMode `json:"volumeMode,omitempty"`
+	// AccessMode specifies the AccessMode of the DataVolume PVC.
+	// Defaults to ReadWriteOnce
+	// +kubebuilder:default=ReadWriteOnce
+	// +optional
+	AccessMode corev1.PersistentVolumeAccessMode `json:"accessMode,omitempty"`
+}
+
+type VirtualServerFilesystem struct {
+	VirtualServerStorageVolume `json:",inline"`
+	// +optional
+	Mountpoint *string `json:"mountPoint,omitempty"`
+}
+
+// VirtualServerUser defines user login information in the VirtualServer
+// The user login information will be used to configure the VirtualServer via cloudinit if supported
+type VirtualServerUser struct {
+	Username string `json:"username"`
+	// Password is the plaintext password of the user.
+	// Mutually exclusive with HashedPassword and PasswordSecretRef.
+	// +optional
+	Password string `json:"password,omitempty"`
+	// HashedPassword is the password of the user hashed in crypt(3) format, such as $6$salt$hash.
+	// Only supported on Linux. Mutually exclusive with Password and PasswordSecretRef.
+	// +optional
+	HashedPassword string `json:"hashedPassword,omitempty"`
+	// PasswordSecretRef selects the plaintext password of the user from a Secret in the namespace of the VirtualServer.
+	// Mutually exclusive with Password and HashedPassword.
+	// +optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+	// SSHPublicKeys is a list of public keys authorized to log in as the user
+	// +optional
+	SSHPublicKeys []string `json:"sshPublicKeys,omitempty"`
+	// SSHPublicKeySecretRef selects newline separated public keys authorized to log in as the user
+	// from a Secret in the namespace of the VirtualServer.
+	// Mutually exclusive with the SSH public keys set in the spec.
+	// +optional
+	SSHPublicKeySecretRef *corev1.SecretKeySelector `json:"sshPublicKeySecretRef,omitempty"`
+	// Groups the user is added to.
+	// Defaults to sudo on Linux.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+	// Sudo rules of the user on Linux.
+	// Defaults to ALL=(ALL) NOPASSWD:ALL.
+	// +optional
+	Sudo []string `json:"sudo,omitempty"`
+	// Shell is the login shell of the user on Linux.
+	// Defaults to /bin/bash.
+	// +optional
+	Shell string `json:"shell,omitempty"`
+	// LockPassword disables password login for the user.
+	// Defaults to true if no password is set.
+	// +optional
+	LockPassword *bool `json:"lockPassword,omitempty"`
+	// Administrator adds the user to the Administrators group on Windows
+	// +optional
+	Administrator bool `json:"administrator,omitempty"`
+}
+
+// VirtualServerNetwork defines the network configuration of the VirtualServer
+type VirtualServerNetwork struct {
+	// If enabled, a Service will be dynamically created, and its IP directly attached to the VirtualServer
+	// DirectAttachLoadBalancerIP may not be set if UDP or TCP VirtualServerPorts are defined
+	DirectAttachLoadBalancerIP bool `json:"directAttachLoadBalancerIP,omitempty"`
+	// FloatingIPs is an array of LoadBalancer Services
+	// The Services LoadBalancer IPs will be used for the floating IPs of the VirtualServer
+	// +listType=map
+	// +listMapKey=serviceName
+	FloatingIPs []VirtualServerFloatingIP `json:"floatingIPs,omitempty"`
+	// TCP describes a list of tcp ports that are exposed by the VirtualServer
+	// A Service will be dynamically created and linked to the VirtualServer
+	// A maximum of 10 ports may be defined
+	TCP VirtualServerServiceTemplate `json:"tcp,omitempty"`
+	// UDP describes a list of udp ports that are exposed by the VirtualServer
+	// A Service will be dynamically created and linked to the VirtualServer
+	// A maximum of 10 ports may be defined
+	UDP VirtualServerServiceTemplate `json:"udp,omitempty"`
+	// If Public is true a public IP will be assigned to the created Services
+	// Defaults to true
+	// +optional
+	// +kubebuilder:default=true
+	Public *bool `json:"public,omitempty"`
+	// DNSConfig defines the DNS parameters of a VMI in addition to those generated from DNSPolicy.
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+	// Set DNS policy for the VMI. Defaults to "ClusterFirst".
+	// Valid values are 'ClusterFirstWithHostNet', 'ClusterFirst', 'Default' or 'None'.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	DNSPolicy *corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+	// Set MAC address for the VMI. It must be a local unicast type.
+	// +optional
+	// +kubebuilder:validation:Pattern="^[0-9a-f][26ae][:]([0-9a-f]{2}[:]){4}([0-9a-f]{2})|[0-9A-F][26AE][-]([0-9A-F]{2}[-]){4}([0-9A-F]{2})$"
+	MACAddress string `json:"macAddress,omitempty"`
+	// When DirectAttachLoadBalancerIP is false or no ports are specified, create a headless service. Defaults to false.
+	// +optional
+	// +kubebuilder:default=false
+	Headless bool `json:"headless,omitempty"`
+	// List of VPC networks
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	VPCs []VirtualServerVPC `json:"vpcs,omitempty"`
+	// Disable kubernetes pod network within the Virtual Server
+	// Useful for isolating a Virtual Server in VPC networks
+	DisableK8sNetworking bool `json:"disableK8sNetworking,omitempty"`
+}
+
+// VirtualServerVPC defines a VPC network for the Virtual Server to join
+type VirtualServerVPC struct {
+	Name string `json:"name"`
+	// MACAddress of the VPC interface, used to match the interface in the network data. It must be a local unicast type.
+	// +optional
+	// +kubebuilder:validation:Pattern="^[0-9a-f][26ae][:]([0-9a-f]{2}[:]){4}([0-9a-f]{2})|[0-9A-F][26AE][-]([0-9A-F]{2}[-]){4}([0-9A-F]{2})$"
+	MACAddress string `json:"macAddress,omitempty"`
+}
+
+// VirtualServerServiceTemplate defines a service created by the VirtualServer
+type VirtualServerServiceTemplate struct {
+	// A list of ports.
+	// The list is constrained to a maximum of 10 ports
+	// +kubebuilder:validation:MaxItems=10
+	Ports []Port `json:"ports,omitempty"`
+}
+
+// +kubebuilder:validation:Minimum=1
+// +kubebuilder:validation:Maximum=65535
+type Port int32
+
+// VirtualServerFloatingIP represents a source that will be used for a VirtualServer floating IP
+type VirtualServerFloatingIP struct {
+	// The name of an existing LoadBalancer Service to use as the Floating IP source
+	ServiceName string `json:"serviceName"`
+}
+
+type VirtualServerNetworkStatus struct {
+	InternalIP  *string           `json:"internalIP,omitempty"`
+	ExternalIP  *string           `json:"externalIP,omitempty"`
+	ServiceIP   *string           `json:"serviceIP,omitempty"`
+	FloatingIPs map[string]string `json:"floatingIPs,omitempty"`
+}
+
+// VirtualServerRootDiskResizeState is the state of a root disk resize
+// +kubebuilder:validation:Enum=VolumeResizing;FileSystemResizePending;GuestResizePending;Complete
+type VirtualServerRootDiskResizeState string
+
+const (
+	// The root disk volume is being expanded to the requested size
+	VirtualServerRootDiskResizeStateVolumeResizing VirtualServerRootDiskResizeState = "VolumeResizing"
+	// The root disk volume has been expanded, but the filesystem has not been resized.
+	// The guest sees the new capacity once the filesystem is resized, which may require the VirtualServer to be restarted.
+	VirtualServerRootDiskResizeStateFileSystemResizePending VirtualServerRootDiskResizeState = "FileSystemResizePending"
+	// The root disk volume has the requested capacity, but the guest does not see it yet.
+	// The guest sees the new capacity once the volume is expanded online, or the VirtualServer is restarted.
+	VirtualServerRootDiskResizeStateGuestResizePending VirtualServerRootDiskResizeState = "GuestResizePending"
+	// The root disk has the requested size and the guest sees the new capacity
+	VirtualServerRootDiskResizeStateComplete VirtualServerRootDiskResizeState = "Complete"
+)
+
+// VirtualServerRootDiskStatus describes the observed state of the root disk
+type VirtualServerRootDiskStatus struct {
+	// RequestedSize is the size of the root disk requested in the spec
+	// +optional
+	RequestedSize *resource.Quantity `json:"requestedSize,omitempty"`
+	// Capacity is the current capacity of the root disk volume
+	// +optional
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+	// GuestSize is the size of the root disk seen by the running guest, as reported by the VirtualMachineInstance
+	// +optional
+	GuestSize *resource.Quantity `json:"guestSize,omitempty"`
+	// ResizeState is the state of the last resize of the root disk, empty if the root disk was never resized
+	// +optional
+	ResizeState VirtualServerRootDiskResizeState `json:"resizeState,omitempty"`
+}
+
+type VirtualServerOSType string
+
+const (
+	// VirtualServer Linux operating system
+	VirtualServerOSTypeLinux VirtualServerOSType = "linux"
+	// VirtualServer Windows operating system
+	VirtualServerOSTypeWindows VirtualServerOSType = "windows"
+)
+
+// VirtualServerProvisioner is the first boot provisioning agent of the VirtualServer operating system
+type VirtualServerProvisioner string
+
+const (
+	// VirtualServerProvisionerCloudInit provisions the VirtualServer with cloud-init, or cloudbase-init on Windows
+	VirtualServerProvisionerCloudInit VirtualServerProvisioner = "cloud-init"
+	// VirtualServerProvisionerIgnition provisions the VirtualServer with Ignition, as used by Fedora CoreOS and Flatcar
+	VirtualServerProvisionerIgnition VirtualServerProvisioner = "ignition"
+)
+
+// VirtualServerPhase is a high level summary of the lifecycle of a VirtualServer
+// +kubebuilder:validation:Enum=Pending;Provisioning;Running;Stopped;Failed;Terminating
+type VirtualServerPhase string
+
+const (
+	// VirtualServerPhasePending indicates that the VirtualServer has not yet started provisioning
+	VirtualServerPhasePending VirtualServerPhase = "Pending"
+	// VirtualServerPhaseProvisioning indicates that the resources of the VirtualServer are being created or updated
+	VirtualServerPhaseProvisioning VirtualServerPhase = "Provisioning"
+	// VirtualServerPhaseRunning indicates that the VirtualServer is ready for use
+	VirtualServerPhaseRunning VirtualServerPhase = "Running"
+	// VirtualServerPhaseStopped indicates that the VirtualServer has been stopped
+	VirtualServerPhaseStopped VirtualServerPhase = "Stopped"
+	// VirtualServerPhaseFailed indicates that the VirtualServer could not be created or started
+	VirtualServerPhaseFailed VirtualServerPhase = "Failed"
+	// VirtualServerPhaseTerminating indicates that the VirtualServer is being deleted
+	VirtualServerPhaseTerminating VirtualServerPhase = "Terminating"
+)
+
+type VirtualServerConditionType string
+
+const (
+	// VSConditionTypeReady describes the ready state of the Virtual Server
+	VSConditionTypeReady VirtualServerConditionType = "Ready"
+	// VSConditionTypeStarted describes whether the VirtualServer has been started
+	VSConditionTypeStarted VirtualServerConditionType = "VirtualServerStarted"
+	// VSConditionTypeServicesReady describes the ready state of the services dynamically created and/or those required by the VirtualServer
+	VSConditionTypeServicesReady VirtualServerConditionType = "ServicesReady"
+	// VSConditionTypeVMReady describes the ready state of the underlying VirtualMachine
+	VSConditionTypeVMReady VirtualServerConditionType = "VirtualMachineReady"
+	// VSConditionTypeSecretReady describes the ready state of the cloud-init secret required by the VirtualServer
+	VSConditionTypeSecretReady VirtualServerConditionType = "SecretReady"
+)
+
+type VirtualServerConditionReason string
+
+const (
+	// VSConditionReasonInitializing indicates that the VirtualServer is initializing for the first time
+	VSConditionReasonInitializing VirtualServerConditionReason = "Initializing"
+	// VSConditionReasonPending indicates that the VirtualServer is pending an update to its spec
+	VSConditionReasonPending VirtualServerConditionReason = "Pending"
+	// VSConditionReasonTerminating indicates that a VirtualServer resource is terminating
+	VSConditionReasonTerminating VirtualServerConditionReason = "Terminating"
+	// VSConditionReasonFailed indicates that the VirtualServer was not able to create or start
+	VSConditionReasonFailed VirtualServerConditionReason = "Failed"
+	// VSConditionReasonReady indicates that the VirtualServer has successfully been created and is ready for use
+	VSConditionReasonReady VirtualServerConditionReason = "VirtualServerReady"
+	// VSConditionReasonStarted indicates that the VirtualServer has started
+	VSConditionReasonStarted VirtualServerConditionReason = "VirtualServerStarted"
+	// VSConditionReasonStopped indicates that the VirtualServer been stopped
+	VSConditionReasonStopped VirtualServerConditionReason = "VirtualServerStopped"
+	// VSConditionReasonVMIShutdown indicates that the Virtual Machine Instance has been shut down
+	VSConditionReasonVMIShutdown VirtualServerConditionReason = "VirtualMachineInstanceShutdown"
+	// VSConditionReasonDefinitionDeprecated indicates that the definition used to configure the VirtualServer has been deprecated and a manual update is required
+	VSConditionReasonDefinitionDeprecated VirtualServerConditionReason = "DefinitionDeprecated"
+	// VSConditionReasonServicesCreated indicates that the VirtualServer services have been successfully created
+	VSConditionReasonServicesCreated VirtualServerConditionReason = "ServicesCreated"
+	// VSConditionReasonWaitingForServices indicates that the VirtualServer is waiting for the required services to be ready
+	VSConditionReasonWaitingForServices VirtualServerConditionReason = "WaitingForServices"
+	// VSConditionReasonServicesReady indicates that the required services are ready
+	VSConditionReasonServicesReady VirtualServerConditionReason = "ServicesReady"
+	// VSConditionReasonVMNameTaken indicates that the name of the underlying VirtualMachine already exists or is owned by another VirtualServer and therefore could not be created
+	VSConditionReasonVMNameTaken VirtualServerConditionReason = "VirtualMachineNameTaken"
+	// VSConditionReasonVMReady indicates that the underlying VirtualMachine is ready
+	VSConditionReasonVMReady VirtualServerConditionReason = "VirtualMachineReady"
+	// VSConditionReasonSecretCreated indicates that the VirtualServer secret have been successfully created
+	VSConditionReasonSecretCreated VirtualServerConditionReason = "SecretCreated"
+	// VSConditionReasonWaitingForSecret indicates that the VirtualServer is waiting for the required secret to be ready
+	VSConditionReasonWaitingForSecrets VirtualServerConditionReason = "WaitingForSecret"
+	// VSConditionReasonResizeInProgress indicates that the VirtualServer root disk is being resized
+	VSConditionReasonResizeInProgress VirtualServerConditionReason = "RootDiskResizeinProgress"
+)
@@ -0,0 +1,300 @@
+// Package cloudinit builds and validates the cloud-config user data of VirtualServers.
+//
+// A Config covers the commonly used cloud-config modules and marshals to user data with the #cloud-config header.
+// User data written by hand, including multipart/mixed user data combining cloud-config with scripts, boothooks and
+// jinja templates, may be checked with ValidateUserData, which rejects unknown cloud-config keys, or with
+// ValidateUserDataFormat, which accepts them.
+package cloudinit
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Header is the first line of cloud-config user data
+const Header = "#cloud-config"
+
+var (
+	permissionsRegExp = regexp.MustCompile(`^0?[0-7]{3,4}$`)
+
+	supportedEncodings = sets.NewString("b64", "base64", "gz", "gzip", "gz+b64", "gz+base64", "gzip+b64", "gzip+base64", "text/plain")
+
+	// knownKeys are the top-level keys of the cloud-config modules of cloud-init and cloudbase-init
+	knownKeys = sets.NewString(
+		"allow_public_ssh_keys", "ansible", "apk_repos", "apt", "apt_ftp_proxy", "apt_get_command",
+		"apt_get_upgrade_subcommand", "apt_get_wrapper", "apt_http_proxy", "apt_https_proxy", "apt_mirror",
+		"apt_mirror_search", "apt_mirror_search_dns", "apt_pipelining", "apt_preserve_sources_list", "apt_proxy",
+		"apt_reboot_if_required", "apt_sources", "apt_update", "apt_upgrade", "autoinstall", "bootcmd", "byobu_by_default",
+		"ca_certs", "ca-certs", "chef", "chpasswd", "cloud_config_modules", "cloud_final_modules", "cloud_init_modules",
+		"create_hostname_file", "debconf_selections", "device_aliases", "disable_ec2_metadata", "disable_root", "disable_root_opts", "disk_setup",
+		"drivers", "fan", "final_message", "fqdn", "fs_setup", "groups", "growpart", "hostname", "keyboard", "landscape",
+		"locale", "locale_configfile", "lxd", "manage_etc_hosts", "manage_resolv_conf", "mcollective", "merge_how",
+		"merge_type", "mount_default_fields", "mounts", "no_ssh_fingerprints", "ntp", "output", "package_reboot_if_required",
+		"package_update", "package_upgrade", "packages", "password", "phone_home", "power_state", "prefer_fqdn_over_hostname",
+		"preserve_hostname", "puppet", "random_seed", "reporting", "resize_rootfs", "resolv_conf", "rh_subscription",
+		"rsyslog", "runcmd", "salt_minion", "set_hostname", "set_timezone", "snap", "spacewalk", "ssh",
+		"ssh_authorized_keys", "ssh_deletekeys", "ssh_fp_console_blacklist", "ssh_genkeytypes", "ssh_import_id",
+		"ssh_key_console_blacklist", "ssh_keys", "ssh_publish_hostkeys", "ssh_pwauth", "ssh_quiet_keygen", "swap",
+		"system_info", "timezone", "ubuntu_advantage", "ubuntu_pro", "unverified_modules", "updates", "user", "users", "vendor_data",
+		"wireguard", "write_files", "yum_repo_dir", "yum_repos", "zypper",
+	)
+)
+
+// Config is the cloud-config of a VirtualServer
+type Config struct {
+	// PackageUpdate updates the package database on first boot
+	PackageUpdate bool `yaml:"package_update,omitempty"`
+	// PackageUpgrade upgrades the installed packages on first boot
+	PackageUpgrade bool `yaml:"package_upgrade,omitempty"`
+	// Packages are installed on first boot
+	Packages []string `yaml:"packages,omitempty"`
+	// Apt configures the apt package sources
+	Apt *Apt `yaml:"apt,omitempty"`
+	// NTP configures time synchronization
+	NTP *NTP `yaml:"ntp,omitempty"`
+	// Users are created on first boot, in addition to the VirtualServer users
+	Users []User `yaml:"users,omitempty"`
+	// Mounts are added to /etc/fstab, in addition to the VirtualServer filesystem mounts
+	Mounts []Mount `yaml:"mounts,omitempty"`
+	// WriteFiles are written on first boot
+	WriteFiles []WriteFile `yaml:"write_files,omitempty"`
+	// BootCmd are run early on every boot
+	BootCmd []Command `yaml:"bootcmd,omitempty"`
+	// RunCmd are run late on first boot
+	RunCmd []Command `yaml:"runcmd,omitempty"`
+}
+
+// Apt configures the apt package sources
+type Apt struct {
+	// Sources are the additional apt sources, by name.
+	// The name is used as the sources.list.d file name when Filename is not set.
+	Sources map[string]AptSource `yaml:"sources,omitempty"`
+}
+
+// AptSource is an additional apt source
+type AptSource struct {
+	// Source is the sources.list line, such as deb http://example.com/ubuntu $RELEASE main
+	Source string `yaml:"source,omitempty"`
+	// Filename is the sources.list.d file name
+	Filename string `yaml:"filename,omitempty"`
+	// KeyID is the ID of the signing key, imported from Keyserver
+	KeyID string `yaml:"keyid,omitempty"`
+	// Key is the ASCII armored signing key
+	Key string `yaml:"key,omitempty"`
+	// Keyserver is the server KeyID is imported from
+	Keyserver string `yaml:"keyserver,omitempty"`
+}
+
+// NTP configures time synchronization
+type NTP struct {
+	// Enabled enables the NTP client
+	Enabled *bool `yaml:"enabled,omitempty"`
+	// Servers are the NTP servers
+	Servers []string `yaml:"servers,omitempty"`
+	// Pools are the NTP server pools
+	Pools []string `yaml:"pools,omitempty"`
+}
+
+// User is a cloud-config user.
+// Plaintext passwords are not supported, use VirtualServer users for passwords.
+type User struct {
+	Name              string   `yaml:"name"`
+	Groups            string   `yaml:"groups,omitempty"`
+	Sudo              []string `yaml:"sudo,omitempty"`
+	Shell             string   `yaml:"shell,omitempty"`
+	LockPasswd        *bool    `yaml:"lock_passwd,omitempty"`
+	HashedPasswd      string   `yaml:"hashed_passwd,omitempty"`
+	SSHAuthorizedKeys []string `yaml:"ssh_authorized_keys,omitempty"`
+}
+
+// Mount is an /etc/fstab entry: the device, mount point, filesystem type, options, dump and pass fields.
+// Missing trailing fields are defaulted by cloud-init.
+type Mount []string
+
+// WriteFile is a file written on first boot
+type WriteFile struct {
+	// Path is the absolute path of the file
+	Path string `yaml:"path"`
+	// Content is the content of the file, encoded as Encoding
+	Content string `yaml:"content,omitempty"`
+	// Encoding is the encoding of Content, such as b64 or gz+b64. Defaults to plain text.
+	Encoding string `yaml:"encoding,omitempty"`
+	// Owner is the user:group owning the file. Defaults to root:root.
+	Owner string `yaml:"owner,omitempty"`
+	// Permissions are the octal permissions of the file, such as 0644
+	Permissions string `yaml:"permissions,omitempty"`
+	// Append appends Content to an existing file
+	Append bool `yaml:"append,omitempty"`
+	// Defer writes the file after users and packages are set up
+	Defer bool `yaml:"defer,omitempty"`
+}
+
+// Command is a command run without a shell, as a program followed by its arguments
+type Command []string
+
+// Shell returns a Command running the script with sh
+func Shell(script string) Command {
+	return Command{"sh", "-c", script}
+}
+
+// GzipFile returns a WriteFile with the content gzip compressed and base64 encoded, to fit larger files in the user data
+func GzipFile(path string, content []byte) (WriteFile, error) {
+	compressed, err := Gzip(content)
+	if err != nil {
+		return WriteFile{}, err
+	}
+	return WriteFile{
+		Path:     path,
+		Content:  base64.StdEncoding.EncodeToString(compressed),
+		Encoding: "gz+b64",
+	}, nil
+}
+
+// Marshal returns the cloud-config user data, starting with the #cloud-config header
+func (c *Config) Marshal() (string, error) {
+	out, err := yaml.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return Header + "\n" + string(out), nil
+}
+
+// Validate checks the Config
+func (c *Config) Validate(fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, pkg := range c.Packages {
+		if strings.TrimSpace(pkg) == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("packages").Index(i), ""))
+		}
+	}
+	if c.Apt != nil {
+		for _, name := range sets.StringKeySet(c.Apt.Sources).List() {
+			source := c.Apt.Sources[name]
+			p := fldPath.Child("apt", "sources").Key(name)
+			if source.Source == "" {
+				allErrs = append(allErrs, field.Required(p.Child("source"), ""))
+			}
+			if source.KeyID != "" && source.Key != "" {
+				allErrs = append(allErrs, field.Forbidden(p.Child("key"), "may not be set with keyid"))
+			}
+		}
+	}
+	for i, user := range c.Users {
+		p := fldPath.Child("users").Index(i)
+		if user.Name == "" {
+			allErrs = append(allErrs, field.Required(p.Child("name"), ""))
+		}
+		if user.Shell != "" && !strings.HasPrefix(user.Shell, "/") {
+			allErrs = append(allErrs, field.Invalid(p.Child("shell"), user.Shell, "must be an absolute path"))
+		}
+	}
+	for i, mount := range c.Mounts {
+		if len(mount) == 0 || len(mount) > 6 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("mounts").Index(i), mount, "must have between 1 and 6 fields"))
+		}
+	}
+	for i, file := range c.WriteFiles {
+		p := fldPath.Child("write_files").Index(i)
+		if !strings.HasPrefix(file.Path, "/") {
+			allErrs = append(allErrs, field.Invalid(p.Child("path"), file.Path, "must be an absolute path"))
+		}
+		if file.Encoding != "" && !supportedEncodings.Has(file.Encoding) {
+			allErrs = append(allErrs, field.NotSupported(p.Child("encoding"), file.Encoding, supportedEncodings.List()))
+		}
+		if file.Permissions != "" && !permissionsRegExp.MatchString(file.Permissions) {
+			allErrs = append(allErrs, field.Invalid(p.Child("permissions"), file.Permissions, "must be octal permissions, such as 0644"))
+		}
+	}
+	allErrs = append(allErrs, validateCommands(c.BootCmd, fldPath.Child("bootcmd"))...)
+	allErrs = append(allErrs, validateCommands(c.RunCmd, fldPath.Child("runcmd"))...)
+	return allErrs
+}
+
+func validateCommands(commands []Command, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, command := range commands {
+		if len(command) == 0 || command[0] == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i), "must have a program"))
+		}
+	}
+	return allErrs
+}
+
+// ValidateUserData checks each part of the user data.
+// Cloud-config parts must be maps with known top-level keys, scripts must start with #!, and jinja templates must
+// render to cloud-config or a script. Other user data formats such as #include are not supported.
+// Parts without a header line are cloud-config, as the VirtualServer renders them with the #cloud-config header.
+func ValidateUserData(userData string, fldPath *field.Path) field.ErrorList {
+	return validateUserData(userData, true, fldPath)
+}
+
+// ValidateUserDataFormat checks each part of the user data as ValidateUserData does, but accepts unknown cloud-config keys
+func ValidateUserDataFormat(userData string, fldPath *field.Path) field.ErrorList {
+	return validateUserData(userData, false, fldPath)
+}
+
+func validateUserData(userData string, knownKeysOnly bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	parts, err := ParseUserData(userData)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, "<cloud-init>", err.Error()))
+	}
+	for i, part := range parts {
+		p := fldPath
+		if IsMultiPart(userData) {
+			p = fldPath.Index(i)
+		}
+		allErrs = append(allErrs, validatePart(&part, knownKeysOnly, p)...)
+	}
+	return allErrs
+}
+
+func validatePart(part *Part, knownKeysOnly bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	lines := strings.SplitN(part.Content, "\n", 3)
+	switch part.ContentType {
+	case ContentTypeCloudConfig:
+		allErrs = append(allErrs, validateCloudConfig(part.Content, knownKeysOnly, fldPath)...)
+	case ContentTypeShellScript:
+		if !strings.HasPrefix(part.Content, "#!") {
+			allErrs = append(allErrs, field.Invalid(fldPath, "<cloud-init>", "script must start with #!"))
+		}
+	case ContentTypeCloudBoothook:
+		if strings.TrimSpace(strings.TrimPrefix(part.Content, boothookHeader)) == "" {
+			allErrs = append(allErrs, field.Required(fldPath, "boothook must not be empty"))
+		}
+	case ContentTypeJinja2:
+		// The rendered content depends on the instance data, so only the headers are checked
+		if !strings.HasPrefix(lines[0], jinjaHeader) {
+			allErrs = append(allErrs, field.Invalid(fldPath, "<cloud-init>", fmt.Sprintf("jinja template must start with %s", jinjaHeader)))
+		} else if len(lines) < 2 || strings.TrimSpace(lines[1]) != Header && !strings.HasPrefix(lines[1], "#!") {
+			allErrs = append(allErrs, field.Invalid(fldPath, "<cloud-init>", fmt.Sprintf("jinja template must render to %s or a #! script", Header)))
+		}
+	}
+	return allErrs
+}
+
+// validateCloudConfig checks that the cloud-config is a map, with known top-level keys if knownKeysOnly is set
+func validateCloudConfig(cloudConfig string, knownKeysOnly bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(cloudConfig), &config); err != nil {
+		return append(allErrs, field.Invalid(fldPath, "<cloud-init>", err.Error()))
+	}
+	if _, err := ParseMergeHow(config); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Key("merge_how"), "<merge_how>", err.Error()))
+	}
+	if !knownKeysOnly {
+		return allErrs
+	}
+	for _, key := range sets.StringKeySet(config).Difference(knownKeys).List() {
+		allErrs = append(allErrs, field.Invalid(fldPath.Key(key), key, "is not a known cloud-config module key"))
+	}
+	return allErrs
+}
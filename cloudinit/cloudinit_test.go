@@ -0,0 +1,176 @@
+package cloudinit_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/coreweave/virtual-server/cloudinit"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestMarshal(t *testing.T) {
+	enabled := true
+	cfg := &cloudinit.Config{
+		PackageUpdate: true,
+		Packages:      []string{"curl", "nfs-common"},
+		Apt: &cloudinit.Apt{Sources: map[string]cloudinit.AptSource{
+			"docker": {Source: "deb https://download.docker.com/linux/ubuntu $RELEASE stable", KeyID: "0EBFCD88"},
+		}},
+		NTP:        &cloudinit.NTP{Enabled: &enabled, Servers: []string{"time.example.com"}},
+		Users:      []cloudinit.User{{Name: "deploy", Groups: "docker", SSHAuthorizedKeys: []string{"ssh-ed25519 AAAA deploy"}}},
+		Mounts:     []cloudinit.Mount{{"/dev/vdb", "/mnt/data", "ext4", "defaults,nofail"}},
+		WriteFiles: []cloudinit.WriteFile{{Path: "/etc/motd", Content: "hello\n", Permissions: "0644"}},
+		BootCmd:    []cloudinit.Command{{"modprobe", "nvidia"}},
+		RunCmd:     []cloudinit.Command{cloudinit.Shell("echo done > /tmp/done")},
+	}
+	got, err := cfg.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `#cloud-config
+package_update: true
+packages:
+- curl
+- nfs-common
+apt:
+  sources:
+    docker:
+      source: deb https://download.docker.com/linux/ubuntu $RELEASE stable
+      keyid: 0EBFCD88
+ntp:
+  enabled: true
+  servers:
+  - time.example.com
+users:
+- name: deploy
+  groups: docker
+  ssh_authorized_keys:
+  - ssh-ed25519 AAAA deploy
+mounts:
+- - /dev/vdb
+  - /mnt/data
+  - ext4
+  - defaults,nofail
+write_files:
+- path: /etc/motd
+  content: |
+    hello
+  permissions: "0644"
+bootcmd:
+- - modprobe
+  - nvidia
+runcmd:
+- - sh
+  - -c
+  - echo done > /tmp/done
+`
+	if got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+	if errs := cloudinit.ValidateUserData(got, field.NewPath("cloudInit")); len(errs) > 0 {
+		t.Errorf("expected the marshaled config to be valid user data, got %v", errs)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg := &cloudinit.Config{
+		Packages:   []string{""},
+		Apt:        &cloudinit.Apt{Sources: map[string]cloudinit.AptSource{"docker": {KeyID: "0EBFCD88", Key: "key"}}},
+		Users:      []cloudinit.User{{Shell: "bash"}},
+		Mounts:     []cloudinit.Mount{{}},
+		WriteFiles: []cloudinit.WriteFile{{Path: "etc/motd", Encoding: "b32", Permissions: "rw-r--r--"}},
+		RunCmd:     []cloudinit.Command{{}},
+	}
+	want := []string{
+		"cloudConfig.packages[0]",
+		"cloudConfig.apt.sources[docker].source",
+		"cloudConfig.apt.sources[docker].key",
+		"cloudConfig.users[0].name",
+		"cloudConfig.users[0].shell",
+		"cloudConfig.mounts[0]",
+		"cloudConfig.write_files[0].path",
+		"cloudConfig.write_files[0].encoding",
+		"cloudConfig.write_files[0].permissions",
+		"cloudConfig.runcmd[0]",
+	}
+	if got := fields(cfg.Validate(field.NewPath("cloudConfig"))); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected errors on %q, got %q", want, got)
+	}
+}
+
+func TestValidateUserData(t *testing.T) {
+	tests := []struct {
+		name     string
+		userData string
+		want     []string
+	}{
+		{name: "without header", userData: "packages:\n- curl\n"},
+		{name: "with header", userData: "#cloud-config\npackages:\n- curl\n"},
+		{name: "comment", userData: "# install curl\npackages:\n- curl\n"},
+		{name: "misspelled header", userData: "#cloud-confg\npackages:\n- curl\n", want: []string{"cloudInit"}},
+		{name: "script", userData: "#!/bin/bash\necho hello\n"},
+		{name: "jinja template", userData: "## template: jinja\n#cloud-config\nhostname: {{ v1.local_hostname }}\n"},
+		{name: "jinja template without type", userData: "## template: jinja\nhostname: {{ v1.local_hostname }}\n", want: []string{"cloudInit"}},
+		{name: "include", userData: "#include\nhttps://example.com/user-data\n", want: []string{"cloudInit"}},
+		{name: "not a map", userData: "- not\n- a map", want: []string{"cloudInit"}},
+		{name: "unknown keys", userData: "packges:\n- curl\nruncmd: []\nwrite_file: []\n", want: []string{"cloudInit[packges]", "cloudInit[write_file]"}},
+		{name: "merge_how", userData: "merge_how: list(append)+dict(recurse_list)+str()\nruncmd: []\n"},
+		{name: "invalid merge_how", userData: "merge_how: list(append)+tuple()\n", want: []string{"cloudInit[merge_how]"}},
+		{name: "apt and ssh keys", userData: "apt_update: true\napt_upgrade: true\nallow_public_ssh_keys: false\nunverified_modules: []\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(cloudinit.ValidateUserData(tt.userData, field.NewPath("cloudInit"))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected errors on %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateUserDataFormat(t *testing.T) {
+	userData := "#cloud-config\nfuture_module: true\n"
+	if errs := cloudinit.ValidateUserDataFormat(userData, field.NewPath("cloudInit")); len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if errs := cloudinit.ValidateUserDataFormat("- not\n- a map", field.NewPath("cloudInit")); len(errs) != 1 {
+		t.Errorf("expected an error for cloud-config that is not a map, got %v", errs)
+	}
+}
+
+func fields(errs field.ErrorList) []string {
+	var got []string
+	for _, err := range errs {
+		got = append(got, err.Field)
+	}
+	return got
+}
+
+func TestGzipFile(t *testing.T) {
+	content := []byte(strings.Repeat("hello\n", 1000))
+	file, err := cloudinit.GzipFile("/etc/hello", content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if file.Encoding != "gz+b64" || len(file.Content) >= len(content) {
+		t.Errorf("expected compressed gz+b64 content, got %s encoding of %d bytes", file.Encoding, len(file.Content))
+	}
+	compressed, err := base64.StdEncoding.DecodeString(file.Content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := io.ReadAll(r); err != nil || !bytes.Equal(got, content) {
+		t.Errorf("expected the content to round trip, got %v", err)
+	}
+	if errs := (&cloudinit.Config{WriteFiles: []cloudinit.WriteFile{file}}).Validate(field.NewPath("cloudConfig")); len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
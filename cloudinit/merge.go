@@ -0,0 +1,169 @@
+package cloudinit
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// mergerRegExp matches a merger of a merge_how string, such as list(append) or dict(no_replace,recurse_list)
+var mergerRegExp = regexp.MustCompile(`^\s*(dict|list|str)\s*\(([^()]*)\)\s*$`)
+
+// MergeHow is how a cloud-config part is merged into the cloud-config of the previous parts,
+// as set by the merge_how or merge_type key of the part
+type MergeHow struct {
+	// Dict holds the settings of the dict merger, such as replace, no_replace or recurse_list
+	Dict []string
+	// List holds the settings of the list merger, such as append, prepend or no_replace
+	List []string
+	// Str holds the settings of the str merger, such as append
+	Str []string
+}
+
+// DefaultMergeHow is the merge_how of cloud-init for parts without one, dict(replace)+list()+str():
+// keys of the part replace the keys of the previous parts
+var DefaultMergeHow = MergeHow{Dict: []string{"replace"}}
+
+// ParseMergeHow returns the merge_how, or merge_type, of the cloud-config, or nil if it has neither.
+// Both the string form, such as list(append)+dict(no_replace,recurse_list)+str(), and the list of
+// name and settings maps are supported.
+func ParseMergeHow(config map[string]interface{}) (*MergeHow, error) {
+	value, ok := config["merge_how"]
+	if !ok {
+		if value, ok = config["merge_type"]; !ok {
+			return nil, nil
+		}
+	}
+	how := &MergeHow{}
+	switch v := value.(type) {
+	case string:
+		for _, merger := range strings.Split(v, "+") {
+			m := mergerRegExp.FindStringSubmatch(merger)
+			if m == nil {
+				return nil, fmt.Errorf("invalid merger %q, must be dict(...), list(...) or str(...)", strings.TrimSpace(merger))
+			}
+			var settings []string
+			for _, setting := range strings.Split(m[2], ",") {
+				if setting = strings.TrimSpace(setting); setting != "" {
+					settings = append(settings, setting)
+				}
+			}
+			how.set(m[1], settings)
+		}
+	case []interface{}:
+		for i, item := range v {
+			merger, ok := item.(map[interface{}]interface{})
+			if !ok {
+				return nil, fmt.Errorf("merger %d must be a map with a name and settings", i)
+			}
+			name, _ := merger["name"].(string)
+			if name != "dict" && name != "list" && name != "str" {
+				return nil, fmt.Errorf("merger %d has unsupported name %q, must be dict, list or str", i, name)
+			}
+			var settings []string
+			if list, ok := merger["settings"].([]interface{}); ok {
+				for _, setting := range list {
+					settings = append(settings, fmt.Sprint(setting))
+				}
+			}
+			how.set(name, settings)
+		}
+	default:
+		return nil, fmt.Errorf("must be a string or a list of mergers")
+	}
+	return how, nil
+}
+
+func (how *MergeHow) set(name string, settings []string) {
+	switch name {
+	case "dict":
+		how.Dict = settings
+	case "list":
+		how.List = settings
+	case "str":
+		how.Str = settings
+	}
+}
+
+// Merge merges the cloud-config src into dst as cloud-init merges cloud-config parts following how.
+// The merge_how and merge_type keys of src are not merged.
+//
+// Keys of src missing from dst are added. For keys present in both, the dict merger replaces the value with replace,
+// merges nested maps, lists with recurse_list or recurse_array and strings with recurse_str, and otherwise keeps
+// the value of dst. The list merger appends with append, prepends with prepend, keeps the list of dst with no_replace
+// and otherwise replaces it. The str merger appends with append, and otherwise replaces the string.
+func Merge(dst map[string]interface{}, src map[string]interface{}, how MergeHow) {
+	for key, value := range src {
+		if key == "merge_how" || key == "merge_type" {
+			continue
+		}
+		if old, ok := dst[key]; ok {
+			value = how.mergeKey(old, value)
+		}
+		dst[key] = value
+	}
+}
+
+// mergeKey returns the value of a key present in both maps
+func (how *MergeHow) mergeKey(old interface{}, value interface{}) interface{} {
+	if has(how.Dict, "replace") {
+		return value
+	}
+	switch v := value.(type) {
+	case []interface{}:
+		if has(how.Dict, "recurse_list") || has(how.Dict, "recurse_array") {
+			return how.mergeList(old, v)
+		}
+	case string:
+		if has(how.Dict, "recurse_str") {
+			return how.mergeStr(old, v)
+		}
+	case map[interface{}]interface{}:
+		if o, ok := old.(map[interface{}]interface{}); ok {
+			merged := make(map[interface{}]interface{}, len(o)+len(v))
+			for key, value := range o {
+				merged[key] = value
+			}
+			for key, value := range v {
+				if old, ok := merged[key]; ok {
+					value = how.mergeKey(old, value)
+				}
+				merged[key] = value
+			}
+			return merged
+		}
+	}
+	return old
+}
+
+func (how *MergeHow) mergeList(old interface{}, value []interface{}) interface{} {
+	o, ok := old.([]interface{})
+	if !ok {
+		return value
+	}
+	switch {
+	case has(how.List, "append"):
+		return append(append([]interface{}(nil), o...), value...)
+	case has(how.List, "prepend"):
+		return append(append([]interface{}(nil), value...), o...)
+	case has(how.List, "no_replace"):
+		return o
+	}
+	return value
+}
+
+func (how *MergeHow) mergeStr(old interface{}, value string) interface{} {
+	if o, ok := old.(string); ok && has(how.Str, "append") {
+		return o + value
+	}
+	return value
+}
+
+func has(settings []string, setting string) bool {
+	for _, s := range settings {
+		if s == setting {
+			return true
+		}
+	}
+	return false
+}
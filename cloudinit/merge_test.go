@@ -0,0 +1,113 @@
+package cloudinit_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/coreweave/virtual-server/cloudinit"
+	"gopkg.in/yaml.v2"
+)
+
+func TestParseMergeHow(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		want    *cloudinit.MergeHow
+		wantErr bool
+	}{
+		{
+			name:   "none",
+			config: "runcmd: []\n",
+		},
+		{
+			name:   "string",
+			config: "merge_how: list(append)+dict(no_replace, recurse_list)+str()\n",
+			want:   &cloudinit.MergeHow{Dict: []string{"no_replace", "recurse_list"}, List: []string{"append"}},
+		},
+		{
+			name:   "merge_type list",
+			config: "merge_type:\n- name: list\n  settings: [prepend]\n- name: dict\n  settings: [recurse_array]\n",
+			want:   &cloudinit.MergeHow{Dict: []string{"recurse_array"}, List: []string{"prepend"}},
+		},
+		{
+			name:    "unknown merger",
+			config:  "merge_how: tuple(append)\n",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported type",
+			config:  "merge_how: 1\n",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config := map[string]interface{}{}
+			if err := yaml.Unmarshal([]byte(tc.config), &config); err != nil {
+				t.Fatal(err)
+			}
+			how, err := cloudinit.ParseMergeHow(config)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if !reflect.DeepEqual(how, tc.want) {
+				t.Errorf("expected %+v, got %+v", tc.want, how)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := "runcmd: [first]\npackages: [curl]\nfinal_message: done\nntp:\n  enabled: true\n  servers: [a]\n"
+	tests := []struct {
+		name string
+		part string
+		how  cloudinit.MergeHow
+		want string
+	}{
+		{
+			name: "default replaces",
+			part: "runcmd: [second]\nntp:\n  servers: [b]\n",
+			how:  cloudinit.DefaultMergeHow,
+			want: "runcmd: [second]\npackages: [curl]\nfinal_message: done\nntp:\n  servers: [b]\n",
+		},
+		{
+			name: "append lists",
+			part: "merge_how: list(append)+dict(recurse_list)+str()\nruncmd: [second]\nntp:\n  servers: [b]\n",
+			how:  cloudinit.MergeHow{Dict: []string{"recurse_list"}, List: []string{"append"}},
+			want: "runcmd: [first, second]\npackages: [curl]\nfinal_message: done\nntp:\n  enabled: true\n  servers: [a, b]\n",
+		},
+		{
+			name: "prepend lists",
+			part: "runcmd: [second]\n",
+			how:  cloudinit.MergeHow{Dict: []string{"recurse_array"}, List: []string{"prepend"}},
+			want: "runcmd: [second, first]\npackages: [curl]\nfinal_message: done\nntp:\n  enabled: true\n  servers: [a]\n",
+		},
+		{
+			name: "keep existing values",
+			part: "runcmd: [second]\nfinal_message: other\nbootcmd: [early]\n",
+			how:  cloudinit.MergeHow{Dict: []string{"no_replace"}},
+			want: "runcmd: [first]\npackages: [curl]\nfinal_message: done\nntp:\n  enabled: true\n  servers: [a]\nbootcmd: [early]\n",
+		},
+		{
+			name: "append strings",
+			part: "final_message: \" again\"\n",
+			how:  cloudinit.MergeHow{Dict: []string{"recurse_str"}, Str: []string{"append"}},
+			want: "runcmd: [first]\npackages: [curl]\nfinal_message: done again\nntp:\n  enabled: true\n  servers: [a]\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dst, src, want := map[string]interface{}{}, map[string]interface{}{}, map[string]interface{}{}
+			for s, m := range map[string]map[string]interface{}{base: dst, tc.part: src, tc.want: want} {
+				if err := yaml.Unmarshal([]byte(s), &m); err != nil {
+					t.Fatal(err)
+				}
+			}
+			cloudinit.Merge(dst, src, tc.how)
+			if !reflect.DeepEqual(dst, want) {
+				t.Errorf("expected %v, got %v", want, dst)
+			}
+		})
+	}
+}
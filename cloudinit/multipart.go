@@ -0,0 +1,210 @@
+package cloudinit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"unicode"
+)
+
+// Content types of the supported user data parts
+const (
+	ContentTypeCloudConfig   = "text/cloud-config"
+	ContentTypeShellScript   = "text/x-shellscript"
+	ContentTypeCloudBoothook = "text/cloud-boothook"
+	ContentTypeJinja2        = "text/jinja2"
+)
+
+const (
+	boothookHeader = "#cloud-boothook"
+	jinjaHeader    = "## template: jinja"
+)
+
+// Part is a part of multipart user data
+type Part struct {
+	// ContentType is one of the ContentType constants
+	ContentType string
+	// Filename names the part, such as the file the script is stored in.
+	// Defaults to part-001, part-002 and so on.
+	Filename string
+	// Content is the content of the part, including its header line such as #cloud-config or #!/bin/bash
+	Content string
+}
+
+// MarshalMultiPart returns multipart/mixed user data with the parts, in order.
+// The boundary is derived from the content, so that the same parts always marshal to the same user data.
+func MarshalMultiPart(parts ...Part) (string, error) {
+	hash := sha256.New()
+	for _, part := range parts {
+		hash.Write([]byte(part.ContentType + "\n" + part.Filename + "\n" + part.Content))
+	}
+	boundary := fmt.Sprintf("==%x==", hash.Sum(nil)[:16])
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	if err := w.SetBoundary(boundary); err != nil {
+		return "", err
+	}
+	for i, part := range parts {
+		if !supportedContentTypes[part.ContentType] {
+			return "", fmt.Errorf("unsupported content type %q of part %d", part.ContentType, i)
+		}
+		filename := part.Filename
+		if filename == "" {
+			filename = fmt.Sprintf("part-%03d", i+1)
+		}
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", mime.FormatMediaType(part.ContentType, map[string]string{"charset": "utf-8"}))
+		header.Set("MIME-Version", "1.0")
+		header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		content := part.Content
+		if isASCII(content) {
+			header.Set("Content-Transfer-Encoding", "7bit")
+		} else {
+			header.Set("Content-Transfer-Encoding", "base64")
+			content = base64.StdEncoding.EncodeToString([]byte(content))
+		}
+		pw, err := w.CreatePart(header)
+		if err != nil {
+			return "", err
+		}
+		if _, err := io.WriteString(pw, content); err != nil {
+			return "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	header := fmt.Sprintf("Content-Type: %s\r\nMIME-Version: 1.0\r\n\r\n", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": boundary}))
+	return header + body.String(), nil
+}
+
+// IsMultiPart returns true if the user data is a MIME message
+func IsMultiPart(userData string) bool {
+	firstLine := strings.ToLower(strings.TrimSpace(strings.SplitN(strings.TrimLeft(userData, "\r\n"), "\n", 2)[0]))
+	return strings.HasPrefix(firstLine, "content-type:") || strings.HasPrefix(firstLine, "mime-version:")
+}
+
+// ParseUserData returns the parts of the user data.
+// The content type of single part user data is detected from its header line; user data without a header is cloud-config.
+// Empty user data has no parts.
+func ParseUserData(userData string) ([]Part, error) {
+	if strings.TrimSpace(userData) == "" {
+		return nil, nil
+	}
+	if !IsMultiPart(userData) {
+		contentType, err := detectContentType(userData)
+		if err != nil {
+			return nil, err
+		}
+		return []Part{{ContentType: contentType, Content: userData}}, nil
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(strings.TrimLeft(userData, "\r\n")))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MIME user data: %w", err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MIME user data: %w", err)
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		part, err := readPart(textproto.MIMEHeader(msg.Header), msg.Body, "")
+		if err != nil {
+			return nil, err
+		}
+		return []Part{part}, nil
+	}
+
+	var parts []Part
+	r := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		p, err := r.NextPart()
+		if err == io.EOF {
+			return parts, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid MIME user data: %w", err)
+		}
+		part, err := readPart(p.Header, p, p.FileName())
+		if err != nil {
+			return nil, fmt.Errorf("part %d: %w", len(parts), err)
+		}
+		parts = append(parts, part)
+	}
+}
+
+// readPart reads a MIME part, decoding base64 content.
+// The content type of text/plain parts is detected from their header line.
+func readPart(header textproto.MIMEHeader, body io.Reader, filename string) (Part, error) {
+	if strings.EqualFold(header.Get("Content-Transfer-Encoding"), "base64") {
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return Part{}, err
+	}
+	part := Part{Filename: filename, Content: string(content)}
+
+	contentType := header.Get("Content-Type")
+	if contentType != "" {
+		if contentType, _, err = mime.ParseMediaType(contentType); err != nil {
+			return Part{}, err
+		}
+	}
+	switch {
+	case contentType == "" || contentType == "text/plain":
+		part.ContentType, err = detectContentType(part.Content)
+	case strings.HasPrefix(contentType, "multipart/"):
+		err = errors.New("nested multipart user data is not supported")
+	case !supportedContentTypes[contentType]:
+		err = fmt.Errorf("unsupported content type %q", contentType)
+	default:
+		part.ContentType = contentType
+	}
+	return part, err
+}
+
+var supportedContentTypes = map[string]bool{
+	ContentTypeCloudConfig:   true,
+	ContentTypeShellScript:   true,
+	ContentTypeCloudBoothook: true,
+	ContentTypeJinja2:        true,
+}
+
+// detectContentType returns the content type implied by the header line of the content
+func detectContentType(content string) (string, error) {
+	firstLine := strings.TrimSpace(strings.SplitN(content, "\n", 2)[0])
+	switch {
+	case firstLine == Header:
+		return ContentTypeCloudConfig, nil
+	case strings.HasPrefix(firstLine, "#!"):
+		return ContentTypeShellScript, nil
+	case firstLine == boothookHeader:
+		return ContentTypeCloudBoothook, nil
+	case strings.HasPrefix(firstLine, jinjaHeader):
+		return ContentTypeJinja2, nil
+	case strings.HasPrefix(firstLine, "#cloud") || strings.HasPrefix(firstLine, "#include") ||
+		strings.HasPrefix(firstLine, "#part-handler") || strings.HasPrefix(firstLine, "#upstart-job"):
+		return "", fmt.Errorf("unsupported user data format %q, must be %s, a #! script, %s or a %s template", firstLine, Header, boothookHeader, jinjaHeader)
+	}
+	return ContentTypeCloudConfig, nil
+}
+
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
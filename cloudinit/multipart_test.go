@@ -0,0 +1,86 @@
+package cloudinit_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/coreweave/virtual-server/cloudinit"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestMultiPartRoundTrip(t *testing.T) {
+	parts := []cloudinit.Part{
+		{ContentType: cloudinit.ContentTypeCloudConfig, Filename: "cloud-config.txt", Content: "#cloud-config\npackages:\n- curl\n"},
+		{ContentType: cloudinit.ContentTypeShellScript, Filename: "setup.sh", Content: "#!/bin/bash\necho héllo > /tmp/hello\n"},
+		{ContentType: cloudinit.ContentTypeCloudBoothook, Content: "#cloud-boothook\necho boot\n"},
+		{ContentType: cloudinit.ContentTypeJinja2, Content: "## template: jinja\n#cloud-config\nhostname: {{ v1.local_hostname }}\n"},
+	}
+	userData, err := cloudinit.MarshalMultiPart(parts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cloudinit.IsMultiPart(userData) {
+		t.Errorf("expected multipart user data, got:\n%s", userData)
+	}
+	again, err := cloudinit.MarshalMultiPart(parts...)
+	if err != nil || again != userData {
+		t.Errorf("expected marshaling to be deterministic")
+	}
+
+	got, err := cloudinit.ParseUserData(userData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts[2].Filename, parts[3].Filename = "part-003", "part-004"
+	if !reflect.DeepEqual(got, parts) {
+		t.Errorf("expected parts %+v, got %+v", parts, got)
+	}
+	if errs := cloudinit.ValidateUserData(userData, field.NewPath("cloudInit")); len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+
+	if _, err := cloudinit.MarshalMultiPart(cloudinit.Part{ContentType: "text/x-include-url", Content: "https://example.com"}); err == nil {
+		t.Error("expected an error for an unsupported content type")
+	}
+}
+
+func TestValidateMultiPart(t *testing.T) {
+	userData := strings.Join([]string{
+		`Content-Type: multipart/mixed; boundary="BOUNDARY"`,
+		"MIME-Version: 1.0",
+		"",
+		"--BOUNDARY",
+		"Content-Type: text/cloud-config",
+		"",
+		"packges:",
+		"- curl",
+		"--BOUNDARY",
+		"Content-Type: text/x-shellscript",
+		"",
+		"echo missing shebang",
+		"--BOUNDARY",
+		"Content-Type: text/plain",
+		"",
+		"#!/bin/sh",
+		"echo detected",
+		"--BOUNDARY--",
+		"",
+	}, "\r\n")
+	parts, err := cloudinit.ParseUserData(userData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) != 3 || parts[2].ContentType != cloudinit.ContentTypeShellScript {
+		t.Errorf("expected the text/plain part to be detected as a script, got %+v", parts)
+	}
+	want := []string{"cloudInit[0][packges]", "cloudInit[1]"}
+	if got := fields(cloudinit.ValidateUserData(userData, field.NewPath("cloudInit"))); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected errors on %q, got %q", want, got)
+	}
+
+	unsupported := strings.Replace(userData, "text/x-shellscript", "text/x-include-url", 1)
+	if got := fields(cloudinit.ValidateUserData(unsupported, field.NewPath("cloudInit"))); !reflect.DeepEqual(got, []string{"cloudInit"}) {
+		t.Errorf("expected an error for an unsupported part, got %q", got)
+	}
+}
@@ -0,0 +1,234 @@
+package cloudinit
+
+import (
+	"fmt"
+	"net"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var (
+	networkV1Keys      = sets.NewString("version", "config")
+	networkV1Types     = sets.NewString("physical", "bond", "bridge", "vlan", "nameserver", "route")
+	networkV2Keys      = sets.NewString("version", "renderer", "ethernets", "bonds", "bridges", "vlans", "wifis")
+	networkV2Renderers = sets.NewString("networkd", "NetworkManager")
+)
+
+// NetworkConfig is a cloud-init network-config in version 2 (netplan) format
+type NetworkConfig struct {
+	// Ethernets are the ethernet interfaces, by netplan ID
+	Ethernets map[string]Ethernet `yaml:"ethernets,omitempty"`
+}
+
+// Ethernet configures an ethernet interface
+type Ethernet struct {
+	// Match selects the interface, the netplan ID is used as the interface name when not set
+	Match *Match `yaml:"match,omitempty"`
+	// SetName renames the matched interface
+	SetName string `yaml:"set-name,omitempty"`
+	// DHCP4 enables DHCP for IPv4
+	DHCP4 *bool `yaml:"dhcp4,omitempty"`
+	// DHCP6 enables DHCP for IPv6
+	DHCP6 *bool `yaml:"dhcp6,omitempty"`
+	// Addresses are the static addresses in CIDR notation, such as 10.0.0.10/24
+	Addresses []string `yaml:"addresses,omitempty"`
+	// Routes are the static routes
+	Routes []Route `yaml:"routes,omitempty"`
+	// Nameservers are the DNS servers and search domains
+	Nameservers *Nameservers `yaml:"nameservers,omitempty"`
+	// MTU is the maximum transmission unit of the interface
+	MTU int `yaml:"mtu,omitempty"`
+}
+
+// Match selects an interface
+type Match struct {
+	// MACAddress is the MAC address of the interface, such as the MAC address of a VPC interface
+	MACAddress string `yaml:"macaddress,omitempty"`
+	// Name is the name of the interface, and may contain wildcards
+	Name string `yaml:"name,omitempty"`
+}
+
+// Route is a static route
+type Route struct {
+	// To is the destination in CIDR notation, or default
+	To string `yaml:"to"`
+	// Via is the gateway
+	Via string `yaml:"via"`
+	// Metric is the metric of the route
+	Metric *int `yaml:"metric,omitempty"`
+}
+
+// Nameservers configures DNS
+type Nameservers struct {
+	Addresses []string `yaml:"addresses,omitempty"`
+	Search    []string `yaml:"search,omitempty"`
+}
+
+// StaticEthernet returns an Ethernet matching the MAC address with static addresses, a default route through the gateway
+// and the nameservers. The gateway may be empty for networks without a default route.
+func StaticEthernet(macAddress string, addresses []string, gateway string, nameservers ...string) Ethernet {
+	dhcp := false
+	e := Ethernet{
+		Match:     &Match{MACAddress: macAddress},
+		DHCP4:     &dhcp,
+		Addresses: addresses,
+	}
+	if gateway != "" {
+		e.Routes = []Route{{To: "default", Via: gateway}}
+	}
+	if len(nameservers) > 0 {
+		e.Nameservers = &Nameservers{Addresses: nameservers}
+	}
+	return e
+}
+
+// Marshal returns the network-config
+func (c *NetworkConfig) Marshal() (string, error) {
+	out, err := yaml.Marshal(struct {
+		Version       int `yaml:"version"`
+		NetworkConfig `yaml:",inline"`
+	}{2, *c})
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// ValidateNetworkConfig checks that the network-config is in version 1 or version 2 format.
+// The configuration may be nested in a top-level network key.
+func ValidateNetworkConfig(networkConfig string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(networkConfig), &config); err != nil {
+		return append(allErrs, field.Invalid(fldPath, "<network-config>", err.Error()))
+	}
+	if network, ok := config["network"]; ok && len(config) == 1 {
+		if config, ok = stringMap(network); !ok {
+			return append(allErrs, field.Invalid(fldPath.Key("network"), "<network-config>", "must be a map"))
+		}
+		fldPath = fldPath.Key("network")
+	}
+
+	switch version := config["version"]; version {
+	case 1:
+		allErrs = append(allErrs, unknownKeys(config, networkV1Keys, fldPath)...)
+		items, ok := config["config"].([]interface{})
+		if !ok {
+			return append(allErrs, field.Required(fldPath.Key("config"), "must be a list"))
+		}
+		for i, item := range items {
+			p := fldPath.Key("config").Index(i)
+			entry, ok := stringMap(item)
+			if !ok {
+				allErrs = append(allErrs, field.Invalid(p, "<network-config>", "must be a map"))
+				continue
+			}
+			entryType, _ := entry["type"].(string)
+			if !networkV1Types.Has(entryType) {
+				allErrs = append(allErrs, field.NotSupported(p.Key("type"), entry["type"], networkV1Types.List()))
+			}
+			if _, ok := entry["name"]; !ok && entryType != "nameserver" && entryType != "route" {
+				allErrs = append(allErrs, field.Required(p.Key("name"), ""))
+			}
+		}
+	case 2:
+		allErrs = append(allErrs, unknownKeys(config, networkV2Keys, fldPath)...)
+		if renderer, ok := config["renderer"]; ok {
+			if s, _ := renderer.(string); !networkV2Renderers.Has(s) {
+				allErrs = append(allErrs, field.NotSupported(fldPath.Key("renderer"), renderer, networkV2Renderers.List()))
+			}
+		}
+		for _, key := range []string{"ethernets", "bonds", "bridges", "vlans", "wifis"} {
+			devices, ok := config[key]
+			if !ok {
+				continue
+			}
+			byID, ok := stringMap(devices)
+			if !ok {
+				allErrs = append(allErrs, field.Invalid(fldPath.Key(key), "<network-config>", "must be a map"))
+				continue
+			}
+			for _, id := range sets.StringKeySet(byID).List() {
+				allErrs = append(allErrs, validateNetworkDevice(byID[id], fldPath.Key(key).Key(id))...)
+			}
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Key("version"), version, []string{"1", "2"}))
+	}
+	return allErrs
+}
+
+// validateNetworkDevice checks the addresses, gateways and nameservers of a version 2 device
+func validateNetworkDevice(device interface{}, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	d, ok := stringMap(device)
+	if !ok {
+		return append(allErrs, field.Invalid(fldPath, "<network-config>", "must be a map"))
+	}
+	addresses, _ := d["addresses"].([]interface{})
+	for i, address := range addresses {
+		if s, _ := address.(string); !isCIDR(s) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key("addresses").Index(i), address, "must be an address in CIDR notation"))
+		}
+	}
+	for _, key := range []string{"gateway4", "gateway6"} {
+		if gateway, ok := d[key]; ok {
+			if s, _ := gateway.(string); net.ParseIP(s) == nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Key(key), gateway, "must be an IP address"))
+			}
+		}
+	}
+	routes, _ := d["routes"].([]interface{})
+	for i, route := range routes {
+		r, _ := stringMap(route)
+		if to, _ := r["to"].(string); to != "default" && !isCIDR(to) && net.ParseIP(to) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key("routes").Index(i).Key("to"), r["to"], "must be default or a destination in CIDR notation"))
+		}
+		if via, _ := r["via"].(string); net.ParseIP(via) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key("routes").Index(i).Key("via"), r["via"], "must be an IP address"))
+		}
+	}
+	if nameservers, ok := stringMap(d["nameservers"]); ok {
+		addresses, _ := nameservers["addresses"].([]interface{})
+		for i, address := range addresses {
+			if s, _ := address.(string); net.ParseIP(s) == nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Key("nameservers").Key("addresses").Index(i), address, "must be an IP address"))
+			}
+		}
+	}
+	return allErrs
+}
+
+func unknownKeys(config map[string]interface{}, known sets.String, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for _, key := range sets.StringKeySet(config).Difference(known).List() {
+		allErrs = append(allErrs, field.Invalid(fldPath.Key(key), key, fmt.Sprintf("is not a known key, must be one of %v", known.List())))
+	}
+	return allErrs
+}
+
+// stringMap converts a YAML map to a map with string keys
+func stringMap(value interface{}) (map[string]interface{}, bool) {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			s, ok := k.(string)
+			if !ok {
+				return nil, false
+			}
+			out[s] = v
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+func isCIDR(s string) bool {
+	_, _, err := net.ParseCIDR(s)
+	return err == nil
+}
@@ -0,0 +1,86 @@
+package cloudinit_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/coreweave/virtual-server/cloudinit"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestNetworkConfigMarshal(t *testing.T) {
+	cfg := &cloudinit.NetworkConfig{Ethernets: map[string]cloudinit.Ethernet{
+		"vpc0": cloudinit.StaticEthernet("02:00:00:00:00:02", []string{"10.0.0.10/24"}, "10.0.0.1", "1.1.1.1"),
+	}}
+	got, err := cfg.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `version: 2
+ethernets:
+  vpc0:
+    match:
+      macaddress: "02:00:00:00:00:02"
+    dhcp4: false
+    addresses:
+    - 10.0.0.10/24
+    routes:
+    - to: default
+      via: 10.0.0.1
+    nameservers:
+      addresses:
+      - 1.1.1.1
+`
+	if got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+	if errs := cloudinit.ValidateNetworkConfig(got, field.NewPath("networkData")); len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
+
+func TestValidateNetworkConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		networkConfig string
+		want          []string
+	}{
+		{
+			name:          "version 1",
+			networkConfig: "version: 1\nconfig:\n- type: physical\n  name: eth1\n  subnets:\n  - type: static\n    address: 10.0.0.10/24\n- type: nameserver\n  address: [1.1.1.1]\n",
+		},
+		{
+			name:          "version 2 nested in network",
+			networkConfig: "network:\n  version: 2\n  ethernets:\n    eth1:\n      dhcp4: true\n",
+		},
+		{
+			name:          "missing version",
+			networkConfig: "ethernets: {}\n",
+			want:          []string{"networkData[version]"},
+		},
+		{
+			name:          "invalid version 1",
+			networkConfig: "version: 1\nconfig:\n- type: ethernet\n- type: physical\n",
+			want:          []string{"networkData[config][0][type]", "networkData[config][0][name]", "networkData[config][1][name]"},
+		},
+		{
+			name:          "invalid version 2",
+			networkConfig: "version: 2\nrenderer: systemd\nethernet: {}\nethernets:\n  eth1:\n    addresses: [10.0.0.10]\n    gateway4: gateway\n    routes:\n    - to: default\n      via: 10.0.0.256\n    nameservers:\n      addresses: [dns]\n",
+			want: []string{
+				"networkData[ethernet]",
+				"networkData[renderer]",
+				"networkData[ethernets][eth1][addresses][0]",
+				"networkData[ethernets][eth1][gateway4]",
+				"networkData[ethernets][eth1][routes][0][via]",
+				"networkData[ethernets][eth1][nameservers][addresses][0]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fields(cloudinit.ValidateNetworkConfig(tt.networkConfig, field.NewPath("networkData"))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected errors on %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
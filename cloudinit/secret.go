@@ -0,0 +1,120 @@
+package cloudinit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// UserDataKey is the key of the cloud-init Secret holding the user data
+	UserDataKey = "userdata"
+	// NetworkDataKey is the key of the cloud-init Secret holding the network data
+	NetworkDataKey = "networkdata"
+)
+
+// Budget is the size of a cloud-init Secret, as counted against corev1.MaxSecretSize
+type Budget struct {
+	// UserData is the size of the user data, after compression when Compressed is true
+	UserData int
+	// NetworkData is the size of the network data
+	NetworkData int
+	// Compressed is true when the user data is gzip compressed to fit in the Secret
+	Compressed bool
+	// Size is the size of the Secret data, the sum of the value lengths validated against corev1.MaxSecretSize
+	Size int
+	// Remaining is the number of bytes left in the Secret, negative when the Secret exceeds corev1.MaxSecretSize
+	Remaining int
+}
+
+// Err returns an error if the Secret exceeds corev1.MaxSecretSize, nil otherwise
+func (b *Budget) Err() error {
+	if b.Remaining >= 0 {
+		return nil
+	}
+	if b.Compressed {
+		return fmt.Errorf("cloud-init Secret is %d bytes after compression, exceeding the limit of %d bytes by %d bytes", b.Size, corev1.MaxSecretSize, -b.Remaining)
+	}
+	return fmt.Errorf("cloud-init Secret is %d bytes, exceeding the limit of %d bytes by %d bytes", b.Size, corev1.MaxSecretSize, -b.Remaining)
+}
+
+// Secret is the data of a cloud-init Secret
+type Secret struct {
+	// StringData holds the user data and network data, unless the user data is compressed
+	StringData map[string]string
+	// Data holds the compressed user data
+	Data map[string][]byte
+	// Budget is the size of the Secret
+	Budget Budget
+}
+
+// NewSecret returns the data of the cloud-init Secret holding the user data and network data.
+// The NoCloud disk requires user data, so an empty cloud-config is used with network data only.
+// When the Secret would exceed corev1.MaxSecretSize and compressible is set, the user data is gzip compressed,
+// as cloud-init and cloudbase-init detect and decompress it. Ignition configs are not compressible.
+// The Secret is empty when there is neither user data nor network data.
+func NewSecret(userData string, networkData string, compressible bool) (*Secret, error) {
+	secret := &Secret{}
+	if userData == "" && networkData == "" {
+		secret.Budget.Remaining = corev1.MaxSecretSize
+		return secret, nil
+	}
+	if userData == "" {
+		userData = Header + "\n"
+	}
+	secret.StringData = map[string]string{UserDataKey: userData}
+	if networkData != "" {
+		secret.StringData[NetworkDataKey] = networkData
+	}
+
+	budget := &secret.Budget
+	budget.UserData, budget.NetworkData = len(userData), len(networkData)
+	budget.Size = secret.size()
+	if budget.Size > corev1.MaxSecretSize && compressible {
+		compressed, err := Gzip([]byte(userData))
+		if err != nil {
+			return nil, err
+		}
+		delete(secret.StringData, UserDataKey)
+		secret.Data = map[string][]byte{UserDataKey: compressed}
+		budget.UserData, budget.Compressed = len(compressed), true
+		budget.Size = secret.size()
+	}
+	budget.Remaining = corev1.MaxSecretSize - budget.Size
+	return secret, nil
+}
+
+// IsEmpty returns true if the Secret holds neither user data nor network data
+func (s *Secret) IsEmpty() bool {
+	return len(s.StringData) == 0 && len(s.Data) == 0
+}
+
+// size returns the size of the Secret data as validated by the API server, the sum of the value lengths
+func (s *Secret) size() int {
+	size := 0
+	for _, value := range s.Data {
+		size += len(value)
+	}
+	for _, value := range s.StringData {
+		size += len(value)
+	}
+	return size
+}
+
+// Gzip returns the data compressed with gzip at the best compression level
+func Gzip(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
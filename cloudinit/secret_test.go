@@ -0,0 +1,56 @@
+package cloudinit_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/coreweave/virtual-server/cloudinit"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNewSecret(t *testing.T) {
+	secret, err := cloudinit.NewSecret("", "", true)
+	if err != nil || !secret.IsEmpty() || secret.Budget.Remaining != corev1.MaxSecretSize {
+		t.Errorf("expected an empty Secret, got %+v, %v", secret, err)
+	}
+
+	networkData := "version: 2\n"
+	if secret, err = cloudinit.NewSecret("", networkData, true); err != nil {
+		t.Fatal(err)
+	}
+	if secret.StringData[cloudinit.UserDataKey] != cloudinit.Header+"\n" || secret.StringData[cloudinit.NetworkDataKey] != networkData {
+		t.Errorf("expected an empty cloud-config with the network data, got %q", secret.StringData)
+	}
+	want := len(cloudinit.Header+"\n") + len(networkData)
+	if secret.Budget.Size != want || secret.Budget.Remaining != corev1.MaxSecretSize-want {
+		t.Errorf("expected a Secret of %d bytes, got %+v", want, secret.Budget)
+	}
+
+	userData := cloudinit.Header + "\nruncmd:\n- echo " + strings.Repeat("a", corev1.MaxSecretSize) + "\n"
+	if secret, err = cloudinit.NewSecret(userData, "", false); err != nil {
+		t.Fatal(err)
+	}
+	if secret.Budget.Compressed || secret.Budget.Err() == nil {
+		t.Errorf("expected uncompressible user data to exceed the Secret, got %+v", secret.Budget)
+	}
+
+	if secret, err = cloudinit.NewSecret(userData, "", true); err != nil {
+		t.Fatal(err)
+	}
+	if !secret.Budget.Compressed || secret.Budget.Err() != nil || secret.Budget.UserData != len(secret.Data[cloudinit.UserDataKey]) {
+		t.Fatalf("expected compressed user data within the Secret, got %+v", secret.Budget)
+	}
+	if _, ok := secret.StringData[cloudinit.UserDataKey]; ok {
+		t.Error("expected the compressed user data to replace the plain user data")
+	}
+	r, err := gzip.NewReader(bytes.NewReader(secret.Data[cloudinit.UserDataKey]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err := io.ReadAll(r); err != nil || string(data) != userData {
+		t.Errorf("expected the user data to decompress to the original, got %d bytes, %v", len(data), err)
+	}
+}
@@ -0,0 +1,31 @@
+// Command virtual-server-webhook runs the VirtualServer admission webhooks without a controller manager.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/coreweave/virtual-server/webhook"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func main() {
+	var opts webhook.Options
+	flag.StringVar(&opts.Host, "host", "", "The address the webhook server listens on")
+	flag.IntVar(&opts.Port, "port", 9443, "The port the webhook server listens on")
+	flag.StringVar(&opts.CertDir, "cert-dir", "", "The directory containing tls.crt and tls.key")
+	flag.Parse()
+
+	scheme, err := webhook.NewScheme()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create scheme: %s\n", err)
+		os.Exit(1)
+	}
+
+	server := webhook.NewServer(opts)
+	if err := server.StartStandalone(ctrl.SetupSignalHandler(), scheme); err != nil {
+		fmt.Fprintf(os.Stderr, "Webhook server failed: %s\n", err)
+		os.Exit(1)
+	}
+}
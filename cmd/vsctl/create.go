@@ -0,0 +1,334 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	vsv1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	kvv1 "kubevirt.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// stringList is a flag that may be repeated or given a comma separated list
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
+
+// repeatedString is a flag that may be repeated, where each value may contain commas
+type repeatedString []string
+
+func (l *repeatedString) String() string {
+	return strings.Join(*l, " ")
+}
+
+func (l *repeatedString) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+type createOptions struct {
+	namespace      string
+	region         string
+	os             string
+	uefi           bool
+	gpuType        string
+	gpuCount       uint
+	cpuType        string
+	cpuCount       uint
+	memory         string
+	rootSize       string
+	rootPVC        string
+	rootURL        string
+	storageClass   string
+	volumeMode     string
+	accessMode     string
+	ephemeral      bool
+	disks          stringList
+	emptyDisks     stringList
+	filesystems    stringList
+	swap           string
+	tcpPorts       stringList
+	udpPorts       stringList
+	public         bool
+	directAttach   bool
+	headless       bool
+	floatingIPs    stringList
+	vpcs           stringList
+	macAddress     string
+	users          repeatedString
+	sshPublicKeys  repeatedString
+	cloudInitFile  string
+	running        bool
+	runStrategy    string
+	firmwareSerial string
+}
+
+func runCreate(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	var opts createOptions
+	fs := newFlagSet("create", "create NAME [flags]", stderr)
+	fs.StringVar(&opts.namespace, "namespace", "default", "The namespace of the VirtualServer")
+	fs.StringVar(&opts.namespace, "n", "default", "The namespace of the VirtualServer (shorthand)")
+	fs.StringVar(&opts.region, "region", "", "The region the VirtualServer is deployed to")
+	fs.StringVar(&opts.os, "os", string(vsv1alpha1.VirtualServerOSTypeLinux), "The operating system, linux or windows")
+	fs.BoolVar(&opts.uefi, "uefi", false, "Boot the VirtualServer with a UEFI bootloader")
+	fs.StringVar(&opts.gpuType, "gpu-type", "", "The GPU type to request")
+	fs.UintVar(&opts.gpuCount, "gpu-count", 0, "The number of GPUs to request. Defaults to 1 if a GPU type is set")
+	fs.StringVar(&opts.cpuType, "cpu-type", "", "The CPU type to request, for CPU only VirtualServers")
+	fs.UintVar(&opts.cpuCount, "cpu-count", 0, "The number of CPU cores to request")
+	fs.StringVar(&opts.memory, "memory", "", "The memory to request, e.g. 16Gi")
+	fs.StringVar(&opts.rootSize, "root-size", "", "The size of the root filesystem, e.g. 40Gi")
+	fs.StringVar(&opts.rootPVC, "root-pvc", "", "The NAMESPACE/NAME of the PVC the root filesystem is cloned from")
+	fs.StringVar(&opts.rootURL, "root-url", "", "The URL of the image the root filesystem is imported from")
+	fs.StringVar(&opts.storageClass, "storage-class", "", "The storage class of the root filesystem")
+	fs.StringVar(&opts.volumeMode, "volume-mode", "", "The volume mode of the root filesystem, Block or Filesystem")
+	fs.StringVar(&opts.accessMode, "access-mode", "", "The access mode of the root filesystem, e.g. ReadWriteOnce")
+	fs.BoolVar(&opts.ephemeral, "ephemeral", false, "Discard changes to the root filesystem when the VirtualServer stops")
+	fs.Var(&opts.disks, "disk", "Add a PVC as a disk, as NAME=PVC. May be repeated")
+	fs.Var(&opts.emptyDisks, "empty-disk", "Add an empty disk, as NAME=SIZE. May be repeated")
+	fs.Var(&opts.filesystems, "filesystem", "Add a PVC as a filesystem, as NAME=PVC. May be repeated")
+	fs.StringVar(&opts.swap, "swap", "", "The size of the swap disk, e.g. 4Gi")
+	fs.Var(&opts.tcpPorts, "tcp-ports", "TCP ports to expose, e.g. 22,443")
+	fs.Var(&opts.udpPorts, "udp-ports", "UDP ports to expose, e.g. 4172")
+	fs.BoolVar(&opts.public, "public", true, "Assign public IPs to the created Services")
+	fs.BoolVar(&opts.directAttach, "direct-attach", false, "Attach a load balancer IP directly to the VirtualServer")
+	fs.BoolVar(&opts.headless, "headless", false, "Create a headless Service for the VirtualServer")
+	fs.Var(&opts.floatingIPs, "floating-ip", "Add the IP of an existing LoadBalancer Service as a floating IP. May be repeated")
+	fs.Var(&opts.vpcs, "vpc", "Join a VPC network. May be repeated")
+	fs.StringVar(&opts.macAddress, "mac-address", "", "The MAC address of the VirtualServer")
+	fs.Var(&opts.users, "user", "Add a user, as USERNAME or USERNAME:PASSWORD. May be repeated")
+	fs.Var(&opts.sshPublicKeys, "ssh-public-key", "Authorize a public key for a user, as USERNAME=KEY. May be repeated")
+	fs.StringVar(&opts.cloudInitFile, "cloud-init-file", "", "A file containing additional cloud-init configuration")
+	fs.BoolVar(&opts.running, "running", false, "Start the VirtualServer once it is created")
+	fs.StringVar(&opts.runStrategy, "run-strategy", "", "The run strategy, Always, RerunOnFailure, Manual or Halted")
+	fs.StringVar(&opts.firmwareSerial, "firmware-serial", "", "The SMBIOS system serial number")
+
+	name, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	vs, err := opts.virtualServer(name)
+	if err != nil {
+		return err
+	}
+
+	// Print the defaulted VirtualServer, as zero values such as the CPU count would otherwise be rejected by the CRD schema
+	vs.Default()
+	if errs := vs.Validate(); len(errs) > 0 {
+		printFieldErrors(stderr, fmt.Sprintf("VirtualServer %s", name), errs)
+		return errSilent
+	}
+
+	out, err := yaml.Marshal(vs)
+	if err != nil {
+		return err
+	}
+	_, err = stdout.Write(out)
+	return err
+}
+
+// virtualServer builds the VirtualServer with the v1alpha1 setters
+func (opts *createOptions) virtualServer(name string) (*vsv1alpha1.VirtualServer, error) {
+	vs := vsv1alpha1.NewVirtualServer(name, opts.namespace)
+	vs.APIVersion = vsv1alpha1.GroupVersion.String()
+	vs.Kind = "VirtualServer"
+
+	vs.SetRegion(opts.region)
+	vs.SetOS(vsv1alpha1.VirtualServerOSType(opts.os))
+	vs.EnableUEFIBoot(opts.uefi)
+	if opts.gpuType != "" {
+		if err := vs.SetGPUType(opts.gpuType); err != nil {
+			return nil, err
+		}
+	}
+	if opts.gpuCount > 0 {
+		if err := vs.SetGPUCount(uint32(opts.gpuCount)); err != nil {
+			return nil, err
+		}
+	}
+	if opts.cpuType != "" {
+		if err := vs.SetCPUType(opts.cpuType); err != nil {
+			return nil, err
+		}
+	}
+	if opts.cpuCount > 0 {
+		vs.SetCPUCount(uint32(opts.cpuCount))
+	}
+	if opts.memory != "" {
+		if err := vs.SetMemory(opts.memory); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := opts.configureStorage(vs); err != nil {
+		return nil, err
+	}
+	if err := opts.configureNetwork(vs); err != nil {
+		return nil, err
+	}
+	if err := opts.configureUsers(vs); err != nil {
+		return nil, err
+	}
+
+	if opts.firmwareSerial != "" {
+		if err := vs.SetFirmwareSerial(opts.firmwareSerial); err != nil {
+			return nil, err
+		}
+	}
+	vs.InitializeRunning(opts.running)
+	if opts.runStrategy != "" {
+		vs.RunStrategy(kvv1.VirtualMachineRunStrategy(opts.runStrategy))
+	}
+	return vs, nil
+}
+
+func (opts *createOptions) configureStorage(vs *vsv1alpha1.VirtualServer) error {
+	volumeMode := corev1.PersistentVolumeMode(opts.volumeMode)
+	accessMode := corev1.PersistentVolumeAccessMode(opts.accessMode)
+	switch {
+	case opts.rootPVC != "" && opts.rootURL != "":
+		return fmt.Errorf("only one of --root-pvc and --root-url may be set")
+	case opts.rootPVC != "":
+		namespace, pvcName, ok := strings.Cut(opts.rootPVC, "/")
+		if !ok {
+			namespace, pvcName = opts.namespace, opts.rootPVC
+		}
+		if err := vs.ConfigureStorageRootWithPVCSource(vsv1alpha1.VirtualServerStorageRootPVCSource{
+			Size:             opts.rootSize,
+			PVCName:          pvcName,
+			PVCNamespace:     namespace,
+			StorageClassName: opts.storageClass,
+			VolumeMode:       volumeMode,
+			AccessMode:       accessMode,
+		}); err != nil {
+			return fmt.Errorf("invalid --root-size: %w", err)
+		}
+	case opts.rootURL != "":
+		if err := vs.ConfigureStorageRootWithHTTPSource(vsv1alpha1.VirtualServerStorageRootHTTPSource{
+			Size:             opts.rootSize,
+			ImageUrl:         opts.rootURL,
+			StorageClassName: opts.storageClass,
+			VolumeMode:       volumeMode,
+			AccessMode:       accessMode,
+		}); err != nil {
+			return fmt.Errorf("invalid --root-size: %w", err)
+		}
+	}
+	vs.Spec.Storage.Root.Ephemeral = opts.ephemeral
+
+	for _, disk := range opts.disks {
+		name, pvcName, err := keyValue("--disk", disk)
+		if err != nil {
+			return err
+		}
+		vs.AddPVCDisk(name, pvcName, false)
+	}
+	for _, disk := range opts.emptyDisks {
+		name, size, err := keyValue("--empty-disk", disk)
+		if err != nil {
+			return err
+		}
+		if err := vs.AddEmptyDisk(name, size); err != nil {
+			return fmt.Errorf("invalid --empty-disk %q: %w", disk, err)
+		}
+	}
+	for _, filesystem := range opts.filesystems {
+		name, pvcName, err := keyValue("--filesystem", filesystem)
+		if err != nil {
+			return err
+		}
+		vs.AddPVCFileSystem(name, pvcName, false)
+	}
+	if opts.swap != "" {
+		if err := vs.AddSwap(opts.swap); err != nil {
+			return fmt.Errorf("invalid --swap: %w", err)
+		}
+	}
+	return nil
+}
+
+func (opts *createOptions) configureNetwork(vs *vsv1alpha1.VirtualServer) error {
+	vs.EnablePublicIP(opts.public)
+	vs.SetHeadless(opts.headless)
+	if err := exposePorts("--tcp-ports", opts.tcpPorts, vs.ExposeTCPPort); err != nil {
+		return err
+	}
+	if err := exposePorts("--udp-ports", opts.udpPorts, vs.ExposeUDPPort); err != nil {
+		return err
+	}
+	// Set after exposing the ports, as the setters refuse to expose ports once it is enabled
+	vs.DirectAttachLoadBalancerIP(opts.directAttach)
+	for _, serviceName := range opts.floatingIPs {
+		vs.AddFloatingIP(serviceName)
+	}
+	for _, vpc := range opts.vpcs {
+		vs.AddVPC(vpc)
+	}
+	if opts.macAddress != "" {
+		if err := vs.SetMacAddress(opts.macAddress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (opts *createOptions) configureUsers(vs *vsv1alpha1.VirtualServer) error {
+	for _, user := range opts.users {
+		username, password, _ := strings.Cut(user, ":")
+		vs.AddUser(vsv1alpha1.VirtualServerUser{Username: username, Password: password})
+	}
+	for _, sshPublicKey := range opts.sshPublicKeys {
+		username, key, err := keyValue("--ssh-public-key", sshPublicKey)
+		if err != nil {
+			return err
+		}
+		vs.AddUser(vsv1alpha1.VirtualServerUser{Username: username, SSHPublicKeys: []string{key}})
+	}
+	if opts.cloudInitFile != "" {
+		cloudInit, err := os.ReadFile(opts.cloudInitFile)
+		if err != nil {
+			return err
+		}
+		if err := vs.AddCloudInit(string(cloudInit)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exposePorts parses the ports of the flag and exposes them with expose
+func exposePorts(flag string, ports []string, expose func(int32) error) error {
+	for _, p := range ports {
+		port, err := strconv.ParseInt(p, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", flag, p, err)
+		}
+		if err := expose(int32(port)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keyValue splits a KEY=VALUE flag value
+func keyValue(flag, value string) (string, string, error) {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || key == "" || val == "" {
+		return "", "", fmt.Errorf("invalid %s %q, expected KEY=VALUE", flag, value)
+	}
+	return key, val, nil
+}
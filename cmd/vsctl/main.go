@@ -0,0 +1,95 @@
+// Command vsctl builds, validates and inspects VirtualServers using the v1alpha1 helpers.
+//
+// Usage:
+//
+//	vsctl create NAME [flags]      print a VirtualServer built from flags as YAML
+//	vsctl validate -f FILE         validate the VirtualServers in a YAML file
+//	vsctl render -f FILE [flags]   print the objects derived from the VirtualServers in a YAML file
+//	vsctl status NAME [flags]      summarize the conditions and IPs of a VirtualServer in the cluster
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `vsctl builds, validates and inspects VirtualServers.
+
+Usage:
+  vsctl create NAME [flags]    print a VirtualServer built from flags as YAML
+  vsctl validate -f FILE       validate the VirtualServers in a YAML file
+  vsctl render -f FILE [flags] print the objects derived from the VirtualServers in a YAML file
+  vsctl status NAME [flags]    summarize the conditions and IPs of a VirtualServer in the cluster
+
+Run "vsctl COMMAND -h" for the flags of a command.
+`
+
+// errSilent is returned by commands that have already reported their failure
+var errSilent = errors.New("")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if err != errSilent && err != flag.ErrHelp {
+			fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// run executes the vsctl command in args
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return errSilent
+	}
+	commands := map[string]func([]string, io.Reader, io.Writer, io.Writer) error{
+		"create":   runCreate,
+		"validate": runValidate,
+		"render":   runRender,
+		"status":   runStatus,
+	}
+	command, ok := commands[args[0]]
+	if !ok {
+		if args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+			fmt.Fprint(stdout, usage)
+			return nil
+		}
+		fmt.Fprint(stderr, usage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	return command(args[1:], stdin, stdout, stderr)
+}
+
+// newFlagSet returns a flag set for the command that reports errors to stderr
+func newFlagSet(name, usage string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: vsctl %s\n\nFlags:\n", usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseArgs parses the flags, which may be interleaved with a single positional NAME argument
+func parseArgs(fs *flag.FlagSet, args []string) (string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return "", err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		return "", fmt.Errorf("expected a single NAME argument, got %d", len(positional))
+	}
+	return positional[0], nil
+}
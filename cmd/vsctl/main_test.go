@@ -0,0 +1,239 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	vsv1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	"github.com/coreweave/virtual-server/pkg/client/clientset/versioned"
+	"github.com/coreweave/virtual-server/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func runCommand(t *testing.T, stdin string, args ...string) (string, string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	err := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return stdout.String(), stderr.String(), err
+}
+
+func TestCreateValidateRender(t *testing.T) {
+	created, stderr, err := runCommand(t, "", "create", "my-virtual-server",
+		"-n", "my-namespace",
+		"--region", "ORD1",
+		"--gpu-type", "Quadro_RTX_4000",
+		"--memory", "16Gi",
+		"--root-pvc", "vd-images/ubuntu2004-docker-master-20210601-ord1",
+		"--root-size", "40Gi",
+		"--storage-class", "block-nvme-ord1",
+		"--tcp-ports", "22,443",
+		"--udp-ports", "4172",
+		"--user", "myuser:mypassword",
+		"--ssh-public-key", "myuser=ssh-ed25519 AAAA one",
+		"--ssh-public-key", "myuser=ssh-ed25519 AAAA two",
+	)
+	if err != nil {
+		t.Fatalf("create failed: %v\n%s", err, stderr)
+	}
+	for _, want := range []string{"namespace: my-namespace", "type: Quadro_RTX_4000", "count: 1", "- 443", "sshPublicKeys:"} {
+		if !strings.Contains(created, want) {
+			t.Errorf("expected created VirtualServer to contain %q:\n%s", want, created)
+		}
+	}
+
+	out, stderr, err := runCommand(t, created, "validate", "-f", "-")
+	if err != nil {
+		t.Fatalf("validate failed: %v\n%s", err, stderr)
+	}
+	if out != "VirtualServer my-namespace/my-virtual-server is valid\n" {
+		t.Errorf("unexpected validate output %q", out)
+	}
+
+	out, stderr, err = runCommand(t, created, "render", "-f", "-")
+	if err != nil {
+		t.Fatalf("render failed: %v\n%s", err, stderr)
+	}
+	for _, want := range []string{"kind: Secret", "kind: DataVolume", "name: my-virtual-server-tcp", "name: my-virtual-server-udp", "kind: VirtualMachine"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected rendered objects to contain %q", want)
+		}
+	}
+}
+
+func TestCreateInvalid(t *testing.T) {
+	_, stderr, err := runCommand(t, "", "create", "my-virtual-server", "--tcp-ports", "22", "--direct-attach")
+	if err == nil {
+		t.Fatal("expected create to fail")
+	}
+	for _, want := range []string{"spec.storage.root.source: Required value", "spec.network.tcp.ports: Forbidden"} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("expected errors to contain %q, got:\n%s", want, stderr)
+		}
+	}
+}
+
+func TestValidateUnknownField(t *testing.T) {
+	doc := `apiVersion: virtualservers.coreweave.com/v1alpha1
+kind: VirtualServer
+metadata:
+  name: my-virtual-server
+spec:
+  os:
+    type: linux
+  resources:
+    gpus:
+      type: Quadro_RTX_4000
+`
+	_, _, err := runCommand(t, doc, "validate", "-f", "-")
+	if err == nil || !strings.Contains(err.Error(), `unknown field "gpus"`) {
+		t.Errorf("expected an unknown field error, got %v", err)
+	}
+}
+
+func TestRenderPublicDefault(t *testing.T) {
+	const doc = `apiVersion: virtualservers.coreweave.com/v1alpha1
+kind: VirtualServer
+metadata:
+  name: my-virtual-server
+  namespace: my-namespace
+spec:
+  os:
+    type: linux
+  storage:
+    root:
+      size: 40Gi
+      storageClassName: block-nvme-ord1
+      source:
+        pvc:
+          namespace: vd-images
+          name: ubuntu2004-docker-master-20210601-ord1
+  network:
+    tcp:
+      ports: [22]
+`
+	for _, tt := range []struct {
+		name     string
+		public   string
+		wantType string
+	}{
+		{name: "unset", wantType: "type: LoadBalancer"},
+		{name: "false", public: "    public: false\n", wantType: "type: ClusterIP"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			out, stderr, err := runCommand(t, doc+tt.public, "render", "-f", "-")
+			if err != nil {
+				t.Fatalf("render failed: %v\n%s", err, stderr)
+			}
+			if !strings.Contains(out, tt.wantType) {
+				t.Errorf("expected the Service to have %q:\n%s", tt.wantType, out)
+			}
+		})
+	}
+}
+
+func TestRenderSecrets(t *testing.T) {
+	const doc = `apiVersion: virtualservers.coreweave.com/v1alpha1
+kind: VirtualServer
+metadata:
+  name: my-virtual-server
+  namespace: my-namespace
+spec:
+  os:
+    type: linux
+  storage:
+    root:
+      size: 40Gi
+      storageClassName: block-nvme-ord1
+      source:
+        pvc:
+          namespace: vd-images
+          name: ubuntu2004-docker-master-20210601-ord1
+  users:
+  - username: myuser
+    passwordSecretRef:
+      name: my-credentials
+      key: password
+    sshPublicKeySecretRef:
+      name: my-keys
+      key: authorized_keys
+`
+	const secrets = `apiVersion: v1
+kind: Secret
+metadata:
+  name: my-credentials
+  namespace: my-namespace
+stringData:
+  password: my-secret-password
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: my-keys
+data:
+  authorized_keys: c3NoLWVkMjU1MTkgQUFBQSBvbmU=
+`
+	dir := t.TempDir()
+	secretsFile := filepath.Join(dir, "secrets.yaml")
+	if err := os.WriteFile(secretsFile, []byte(secrets), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := runCommand(t, doc, "render", "-f", "-"); err == nil || !strings.Contains(err.Error(), "was not provided") {
+		t.Errorf("expected an error for the Secrets not provided, got %v", err)
+	}
+	out, stderr, err := runCommand(t, doc, "render", "-f", "-", "--secrets", secretsFile)
+	if err != nil {
+		t.Fatalf("render failed: %v\n%s", err, stderr)
+	}
+	for _, want := range []string{"my-secret-password", "ssh-ed25519 AAAA one"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected the rendered cloud-init to contain %q:\n%s", want, out)
+		}
+	}
+
+	if err := os.WriteFile(secretsFile, []byte("apiVersion: v1\nkind: ConfigMap\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := runCommand(t, doc, "render", "-f", "-", "--secrets", secretsFile); err == nil {
+		t.Error("expected an error for a document that is not a Secret")
+	}
+}
+
+func TestStatus(t *testing.T) {
+	vs := vsv1alpha1.NewVirtualServer("my-virtual-server", "my-namespace")
+	vs.InitializeStatus()
+	vs.SetCondition(vsv1alpha1.VSConditionTypeReady, metav1.ConditionTrue, vsv1alpha1.VSConditionReasonReady, nil, false)
+	internalIP := "10.0.0.1"
+	vs.Status.Network.InternalIP = &internalIP
+	vs.Status.Network.FloatingIPs["my-lb"] = "203.0.113.1"
+
+	newClient = func(string) (versioned.Interface, string, error) {
+		return fake.NewSimpleClientset(vs), "default", nil
+	}
+	out, stderr, err := runCommand(t, "", "status", "my-virtual-server", "-n", "my-namespace")
+	if err != nil {
+		t.Fatalf("status failed: %v\n%s", err, stderr)
+	}
+	// Compare with collapsed whitespace so that the assertions do not depend on the column widths
+	fields := strings.Join(strings.Fields(out), " ")
+	for _, want := range []string{
+		"Phase: Running",
+		"Reconciled: true",
+		"Ready True VirtualServerReady",
+		"VirtualMachineReady Unknown Initializing",
+		"Internal IP: 10.0.0.1",
+		"External IP: <none>",
+		"Floating IPs: my-lb=203.0.113.1",
+	} {
+		if !strings.Contains(fields, want) {
+			t.Errorf("expected status to contain %q:\n%s", want, out)
+		}
+	}
+
+	if _, _, err := runCommand(t, "", "status", "missing"); err == nil {
+		t.Error("expected status of a missing VirtualServer to fail")
+	}
+}
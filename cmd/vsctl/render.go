@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/coreweave/virtual-server/render"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func runRender(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := newFlagSet("render", "render -f FILE [--secrets FILE]", stderr)
+	filename := fs.String("f", "", "The YAML file containing VirtualServers, or - for stdin")
+	secretsFilename := fs.String("secrets", "", "The YAML file containing the Secrets referenced by the VirtualServers, such as user passwords and SSH public keys")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	vss, err := readVirtualServers(*filename, stdin)
+	if err != nil {
+		return err
+	}
+	var opts []render.Option
+	if *secretsFilename != "" {
+		if *secretsFilename == "-" && *filename == "-" {
+			return fmt.Errorf("the VirtualServers and the Secrets cannot both be read from stdin")
+		}
+		secrets, err := readSecrets(*secretsFilename, stdin)
+		if err != nil {
+			return fmt.Errorf("%s: %w", *secretsFilename, err)
+		}
+		opts = append(opts, render.WithSecrets(secrets...))
+	}
+
+	for _, vs := range vss {
+		w, err := render.Render(vs, opts...)
+		if err != nil {
+			return fmt.Errorf("%s: %w", describe(vs), err)
+		}
+		for _, obj := range w.Objects() {
+			out, err := yaml.Marshal(obj)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(stdout, "---\n%s", out)
+		}
+	}
+	return nil
+}
+
+// readSecrets decodes the Secrets in the YAML documents of the file.
+// Secrets without a namespace are used for the VirtualServers of any namespace.
+func readSecrets(filename string, stdin io.Reader) ([]*corev1.Secret, error) {
+	var secrets []*corev1.Secret
+	err := readDocuments(filename, stdin, func(i int, doc []byte) error {
+		secret := &corev1.Secret{}
+		if err := yaml.UnmarshalStrict(doc, secret); err != nil {
+			return fmt.Errorf("document %d: %w", i, err)
+		}
+		if secret.APIVersion != corev1.SchemeGroupVersion.String() || secret.Kind != "Secret" {
+			return fmt.Errorf("document %d: expected apiVersion %s and kind Secret, got %s %s", i, corev1.SchemeGroupVersion, secret.APIVersion, secret.Kind)
+		}
+		secrets = append(secrets, secret)
+		return nil
+	})
+	return secrets, err
+}
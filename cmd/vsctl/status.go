@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	vsv1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	"github.com/coreweave/virtual-server/pkg/client/clientset/versioned"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// newClient returns a client and the default namespace of the kubeconfig.
+// An empty kubeconfig uses the KUBECONFIG environment variable or the default location.
+var newClient = func(kubeconfig string) (versioned.Interface, string, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{})
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, "", err
+	}
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, "", err
+	}
+	client, err := versioned.NewForConfig(config)
+	return client, namespace, err
+}
+
+// statusConditions are the conditions summarized by the status command, in the order they are printed
+var statusConditions = []vsv1alpha1.VirtualServerConditionType{
+	vsv1alpha1.VSConditionTypeReady,
+	vsv1alpha1.VSConditionTypeVMReady,
+	vsv1alpha1.VSConditionTypeServicesReady,
+	vsv1alpha1.VSConditionTypeSecretReady,
+	vsv1alpha1.VSConditionTypeStarted,
+}
+
+func runStatus(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	fs := newFlagSet("status", "status NAME [flags]", stderr)
+	kubeconfig := fs.String("kubeconfig", "", "The kubeconfig file. Defaults to $KUBECONFIG or ~/.kube/config")
+	namespace := fs.String("namespace", "", "The namespace of the VirtualServer. Defaults to the kubeconfig namespace")
+	fs.StringVar(namespace, "n", "", "The namespace of the VirtualServer (shorthand)")
+	name, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+
+	client, defaultNamespace, err := newClient(*kubeconfig)
+	if err != nil {
+		return err
+	}
+	if *namespace == "" {
+		*namespace = defaultNamespace
+	}
+	vs, err := client.VirtualserversV1alpha1().VirtualServers(*namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	return printStatus(stdout, vs)
+}
+
+// printStatus summarizes the conditions and IPs of the VirtualServer
+func printStatus(w io.Writer, vs *vsv1alpha1.VirtualServer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Name:\t%s\n", vs.Name)
+	fmt.Fprintf(tw, "Namespace:\t%s\n", vs.Namespace)
+	fmt.Fprintf(tw, "Phase:\t%s\n", orNone(string(vs.Status.Phase)))
+	fmt.Fprintf(tw, "Reconciled:\t%t\n", vs.IsReconciled())
+	fmt.Fprintf(tw, "Conditions:\n")
+	fmt.Fprintf(tw, "  TYPE\tSTATUS\tREASON\tMESSAGE\n")
+	for _, conditionType := range statusConditions {
+		condition := apimeta.FindStatusCondition(vs.Status.Conditions, string(conditionType))
+		if condition == nil {
+			fmt.Fprintf(tw, "  %s\t%s\t\t\n", conditionType, metav1.ConditionUnknown)
+			continue
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", conditionType, condition.Status, condition.Reason, condition.Message)
+	}
+	fmt.Fprintf(tw, "Internal IP:\t%s\n", orNone(vs.Status.InternalIP()))
+	fmt.Fprintf(tw, "External IP:\t%s\n", orNone(vs.Status.ExternalIP()))
+
+	var floatingIPs []string
+	for service, ip := range vs.Status.FloatingIPs() {
+		floatingIPs = append(floatingIPs, fmt.Sprintf("%s=%s", service, ip))
+	}
+	sort.Strings(floatingIPs)
+	fmt.Fprintf(tw, "Floating IPs:\t%s\n", orNone(strings.Join(floatingIPs, ", ")))
+	return tw.Flush()
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	vsv1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+func runValidate(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := newFlagSet("validate", "validate -f FILE", stderr)
+	filename := fs.String("f", "", "The YAML file containing VirtualServers, or - for stdin")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	vss, err := readVirtualServers(*filename, stdin)
+	if err != nil {
+		return err
+	}
+
+	valid := true
+	for _, vs := range vss {
+		vs.Default()
+		if errs := vs.Validate(); len(errs) > 0 {
+			printFieldErrors(stderr, describe(vs), errs)
+			valid = false
+			continue
+		}
+		fmt.Fprintf(stdout, "%s is valid\n", describe(vs))
+	}
+	if !valid {
+		return errSilent
+	}
+	return nil
+}
+
+// readVirtualServers decodes the v1alpha1 VirtualServers in the YAML documents of the file.
+// Unknown fields are rejected, so that misspelled fields are not silently dropped.
+// Network.Public defaults to true when it is not set in a document, as the apiserver would default it.
+func readVirtualServers(filename string, stdin io.Reader) ([]*vsv1alpha1.VirtualServer, error) {
+	if filename == "" {
+		return nil, fmt.Errorf("a file must be set with -f")
+	}
+	var vss []*vsv1alpha1.VirtualServer
+	err := readDocuments(filename, stdin, func(i int, doc []byte) error {
+		// Public defaults to true as in the CRD, and is only replaced when set in the document
+		vs := &vsv1alpha1.VirtualServer{Spec: vsv1alpha1.VirtualServerSpec{Network: vsv1alpha1.VirtualServerNetwork{Public: true}}}
+		if err := yaml.UnmarshalStrict(doc, vs); err != nil {
+			return fmt.Errorf("document %d: %w", i, err)
+		}
+		if vs.APIVersion != vsv1alpha1.GroupVersion.String() || vs.Kind != "VirtualServer" {
+			return fmt.Errorf("document %d: expected apiVersion %s and kind VirtualServer, got %s %s", i, vsv1alpha1.GroupVersion, vs.APIVersion, vs.Kind)
+		}
+		vss = append(vss, vs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(vss) == 0 {
+		return nil, fmt.Errorf("no VirtualServers found in %s", filename)
+	}
+	return vss, nil
+}
+
+// readDocuments calls decode with each non-empty YAML document of the file, or of stdin if the filename is -
+func readDocuments(filename string, stdin io.Reader, decode func(i int, doc []byte) error) error {
+	r := stdin
+	if filename != "-" {
+		f, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	for i := 0; ; i++ {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		if err := decode(i, doc); err != nil {
+			return err
+		}
+	}
+}
+
+func describe(vs *vsv1alpha1.VirtualServer) string {
+	return fmt.Sprintf("VirtualServer %s/%s", vs.Namespace, vs.Name)
+}
+
+// printFieldErrors prints one line per validation error
+func printFieldErrors(w io.Writer, subject string, errs field.ErrorList) {
+	fmt.Fprintf(w, "%s is invalid:\n", subject)
+	for _, err := range errs {
+		fmt.Fprintf(w, "  %s\n", err)
+	}
+}
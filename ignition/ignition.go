@@ -0,0 +1,273 @@
+// Package ignition builds and validates Ignition v3 configs for Fedora CoreOS and Flatcar Virtual Servers.
+//
+// Only the subset of the specification used to provision Virtual Servers is typed: users, files and systemd units.
+// Other sections, such as storage.disks or kernelArguments, are accepted by ValidateConfig and may be provided as raw JSON.
+package ignition
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Version is the Ignition specification version of the marshaled configs
+const Version = "3.3.0"
+
+var (
+	knownKeys       = sets.NewString("ignition", "kernelArguments", "passwd", "storage", "systemd")
+	unitTypes       = sets.NewString(".service", ".socket", ".device", ".mount", ".automount", ".swap", ".target", ".path", ".timer", ".slice", ".scope")
+	resourceSchemes = sets.NewString("data", "http", "https", "s3", "tftp", "gs", "arn")
+	compressions    = sets.NewString("gzip")
+)
+
+// Config is an Ignition v3 config
+type Config struct {
+	Ignition Ignition `json:"ignition"`
+	Passwd   *Passwd  `json:"passwd,omitempty"`
+	Storage  *Storage `json:"storage,omitempty"`
+	Systemd  *Systemd `json:"systemd,omitempty"`
+}
+
+// Ignition is the metadata of the config
+type Ignition struct {
+	// Version is the specification version, defaults to Version when marshaled
+	Version string `json:"version"`
+	// Config references other configs
+	Config *ConfigReferences `json:"config,omitempty"`
+}
+
+// ConfigReferences references configs to merge into or to replace the config
+type ConfigReferences struct {
+	// Merge are the configs merged into this config, in order
+	Merge []Resource `json:"merge,omitempty"`
+}
+
+// Passwd configures users
+type Passwd struct {
+	Users []User `json:"users,omitempty"`
+}
+
+// User is a user account, created when it does not exist
+type User struct {
+	Name string `json:"name"`
+	// PasswordHash is the password in crypt(3) format. Users without a password hash can only log in with SSH keys.
+	PasswordHash      string   `json:"passwordHash,omitempty"`
+	SSHAuthorizedKeys []string `json:"sshAuthorizedKeys,omitempty"`
+	Groups            []string `json:"groups,omitempty"`
+	Shell             string   `json:"shell,omitempty"`
+}
+
+// Storage configures files
+type Storage struct {
+	Files []File `json:"files,omitempty"`
+}
+
+// File is a file written to the root filesystem
+type File struct {
+	// Path is the absolute path of the file
+	Path string `json:"path"`
+	// Overwrite replaces an existing file
+	Overwrite *bool `json:"overwrite,omitempty"`
+	// Mode is the permission bits of the file, such as 0644
+	Mode *int `json:"mode,omitempty"`
+	// Contents is the content of the file
+	Contents Resource `json:"contents"`
+}
+
+// Resource is content fetched from a URL
+type Resource struct {
+	// Source is the URL of the content, such as a data URL returned by DataURL
+	Source string `json:"source"`
+	// Compression is the compression of the content, empty or gzip
+	Compression string `json:"compression,omitempty"`
+}
+
+// Systemd configures systemd units
+type Systemd struct {
+	Units []Unit `json:"units,omitempty"`
+}
+
+// Unit is a systemd unit
+type Unit struct {
+	// Name is the name of the unit, including its type suffix such as .service
+	Name string `json:"name"`
+	// Enabled enables or disables the unit
+	Enabled *bool `json:"enabled,omitempty"`
+	// Mask masks the unit
+	Mask *bool `json:"mask,omitempty"`
+	// Contents is the unit file, empty to configure an existing unit
+	Contents string `json:"contents,omitempty"`
+	// Dropins are drop-in files of the unit
+	Dropins []Dropin `json:"dropins,omitempty"`
+}
+
+// Dropin is a systemd unit drop-in
+type Dropin struct {
+	// Name is the name of the drop-in file, ending with .conf
+	Name     string `json:"name"`
+	Contents string `json:"contents,omitempty"`
+}
+
+// DataURL returns a data URL with the base64 encoded content
+func DataURL(content []byte) string {
+	return "data:;base64," + base64.StdEncoding.EncodeToString(content)
+}
+
+// NewFile returns a file with the content, overwriting any existing file
+func NewFile(path string, content string, mode int) File {
+	overwrite := true
+	return File{
+		Path:      path,
+		Overwrite: &overwrite,
+		Mode:      &mode,
+		Contents:  Resource{Source: DataURL([]byte(content))},
+	}
+}
+
+// NewUnit returns an enabled unit with the contents
+func NewUnit(name string, contents string) Unit {
+	enabled := true
+	return Unit{Name: name, Enabled: &enabled, Contents: contents}
+}
+
+// AddUser adds the user to the config
+func (c *Config) AddUser(user User) {
+	if c.Passwd == nil {
+		c.Passwd = &Passwd{}
+	}
+	c.Passwd.Users = append(c.Passwd.Users, user)
+}
+
+// AddFile adds the file to the config
+func (c *Config) AddFile(file File) {
+	if c.Storage == nil {
+		c.Storage = &Storage{}
+	}
+	c.Storage.Files = append(c.Storage.Files, file)
+}
+
+// AddUnit adds the systemd unit to the config
+func (c *Config) AddUnit(unit Unit) {
+	if c.Systemd == nil {
+		c.Systemd = &Systemd{}
+	}
+	c.Systemd.Units = append(c.Systemd.Units, unit)
+}
+
+// Merge references the config, such as a config provided by the user, to be merged into this config by Ignition
+func (c *Config) Merge(config string) {
+	if c.Ignition.Config == nil {
+		c.Ignition.Config = &ConfigReferences{}
+	}
+	c.Ignition.Config.Merge = append(c.Ignition.Config.Merge, Resource{Source: DataURL([]byte(config))})
+}
+
+// Marshal returns the config as JSON, defaulting the version to Version
+func (c *Config) Marshal() (string, error) {
+	cfg := *c
+	if cfg.Ignition.Version == "" {
+		cfg.Ignition.Version = Version
+	}
+	out, err := json.Marshal(cfg)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// Validate checks the users, files and units of the config
+func (c *Config) Validate(fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if c.Ignition.Version != "" && !strings.HasPrefix(c.Ignition.Version, "3.") {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ignition", "version"), c.Ignition.Version, "must be a 3.x version, Ignition v2 configs are not supported"))
+	}
+	if c.Ignition.Config != nil {
+		for i, r := range c.Ignition.Config.Merge {
+			allErrs = append(allErrs, validateResource(&r, fldPath.Child("ignition", "config", "merge").Index(i))...)
+		}
+	}
+	if c.Passwd != nil {
+		names := sets.NewString()
+		for i, user := range c.Passwd.Users {
+			p := fldPath.Child("passwd", "users").Index(i)
+			if user.Name == "" {
+				allErrs = append(allErrs, field.Required(p.Child("name"), ""))
+			} else if names.Has(user.Name) {
+				allErrs = append(allErrs, field.Duplicate(p.Child("name"), user.Name))
+			}
+			names.Insert(user.Name)
+			if user.Shell != "" && !strings.HasPrefix(user.Shell, "/") {
+				allErrs = append(allErrs, field.Invalid(p.Child("shell"), user.Shell, "must be an absolute path"))
+			}
+		}
+	}
+	if c.Storage != nil {
+		for i, file := range c.Storage.Files {
+			p := fldPath.Child("storage", "files").Index(i)
+			if !path.IsAbs(file.Path) {
+				allErrs = append(allErrs, field.Invalid(p.Child("path"), file.Path, "must be an absolute path"))
+			}
+			if file.Mode != nil && (*file.Mode < 0 || *file.Mode > 07777) {
+				allErrs = append(allErrs, field.Invalid(p.Child("mode"), *file.Mode, "must be between 0 and 07777"))
+			}
+			allErrs = append(allErrs, validateResource(&file.Contents, p.Child("contents"))...)
+		}
+	}
+	if c.Systemd != nil {
+		for i, unit := range c.Systemd.Units {
+			p := fldPath.Child("systemd", "units").Index(i)
+			if !unitTypes.Has(path.Ext(unit.Name)) || strings.Contains(unit.Name, "/") {
+				allErrs = append(allErrs, field.Invalid(p.Child("name"), unit.Name, fmt.Sprintf("must be a unit name with one of the types %v", unitTypes.List())))
+			}
+			for j, dropin := range unit.Dropins {
+				if !strings.HasSuffix(dropin.Name, ".conf") || strings.Contains(dropin.Name, "/") {
+					allErrs = append(allErrs, field.Invalid(p.Child("dropins").Index(j).Child("name"), dropin.Name, "must be a file name ending with .conf"))
+				}
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateResource(r *Resource, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if r.Source != "" {
+		u, err := url.Parse(r.Source)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("source"), "<source>", err.Error()))
+		} else if !resourceSchemes.Has(u.Scheme) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("source"), u.Scheme, resourceSchemes.List()))
+		}
+	}
+	if r.Compression != "" && !compressions.Has(r.Compression) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("compression"), r.Compression, compressions.List()))
+	}
+	return allErrs
+}
+
+// ValidateConfig checks that the config is an Ignition v3 config in JSON format, without unknown top-level keys,
+// and validates its users, files and units
+func ValidateConfig(config string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(config), &raw); err != nil {
+		return append(allErrs, field.Invalid(fldPath, "<ignition>", fmt.Sprintf("must be a JSON object: %v", err)))
+	}
+	for _, key := range sets.StringKeySet(raw).Difference(knownKeys).List() {
+		allErrs = append(allErrs, field.Invalid(fldPath.Key(key), key, fmt.Sprintf("is not a known key, must be one of %v", knownKeys.List())))
+	}
+	cfg := &Config{}
+	if err := json.Unmarshal([]byte(config), cfg); err != nil {
+		return append(allErrs, field.Invalid(fldPath, "<ignition>", err.Error()))
+	}
+	if cfg.Ignition.Version == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("ignition", "version"), "must be a 3.x version"))
+	}
+	return append(allErrs, cfg.Validate(fldPath)...)
+}
@@ -0,0 +1,74 @@
+package ignition_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/coreweave/virtual-server/ignition"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestMarshal(t *testing.T) {
+	cfg := &ignition.Config{}
+	cfg.AddUser(ignition.User{Name: "core", SSHAuthorizedKeys: []string{"ssh-ed25519 AAAA core"}, Groups: []string{"wheel"}})
+	cfg.AddFile(ignition.NewFile("/etc/motd", "hello\n", 0644))
+	cfg.AddUnit(ignition.NewUnit("hello.service", "[Service]\nExecStart=/usr/bin/echo hello\n"))
+	cfg.Merge(`{"ignition":{"version":"3.3.0"}}`)
+	got, err := cfg.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"ignition":{"version":"3.3.0","config":{"merge":[{"source":"data:;base64,eyJpZ25pdGlvbiI6eyJ2ZXJzaW9uIjoiMy4zLjAifX0="}]}},` +
+		`"passwd":{"users":[{"name":"core","sshAuthorizedKeys":["ssh-ed25519 AAAA core"],"groups":["wheel"]}]},` +
+		`"storage":{"files":[{"path":"/etc/motd","overwrite":true,"mode":420,"contents":{"source":"data:;base64,aGVsbG8K"}}]},` +
+		`"systemd":{"units":[{"name":"hello.service","enabled":true,"contents":"[Service]\nExecStart=/usr/bin/echo hello\n"}]}}`
+	if got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+	if errs := ignition.ValidateConfig(got, field.NewPath("ignition")); len(errs) > 0 {
+		t.Errorf("expected the marshaled config to be valid, got %v", errs)
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   []string
+	}{
+		{name: "minimal", config: `{"ignition":{"version":"3.0.0"}}`},
+		{name: "kernel arguments", config: `{"ignition":{"version":"3.3.0"},"kernelArguments":{"shouldExist":["quiet"]}}`},
+		{name: "not json", config: "variant: fcos\nversion: 1.4.0\n", want: []string{"ignition"}},
+		{name: "missing version", config: `{"passwd":{}}`, want: []string{"ignition.ignition.version"}},
+		{name: "version 2", config: `{"ignition":{"version":"2.3.0"}}`, want: []string{"ignition.ignition.version"}},
+		{name: "unknown key", config: `{"ignition":{"version":"3.3.0"},"networkd":{}}`, want: []string{"ignition[networkd]"}},
+		{
+			name: "invalid users, files and units",
+			config: `{"ignition":{"version":"3.3.0"},` +
+				`"passwd":{"users":[{"name":"core"},{"name":"core","shell":"bash"}]},` +
+				`"storage":{"files":[{"path":"etc/motd","mode":65535,"contents":{"source":"ftp://example.com/motd","compression":"xz"}}]},` +
+				`"systemd":{"units":[{"name":"hello","dropins":[{"name":"override"}]}]}}`,
+			want: []string{
+				"ignition.passwd.users[1].name",
+				"ignition.passwd.users[1].shell",
+				"ignition.storage.files[0].path",
+				"ignition.storage.files[0].mode",
+				"ignition.storage.files[0].contents.source",
+				"ignition.storage.files[0].contents.compression",
+				"ignition.systemd.units[0].name",
+				"ignition.systemd.units[0].dropins[0].name",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, err := range ignition.ValidateConfig(tt.config, field.NewPath("ignition")) {
+				got = append(got, err.Field)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected errors on %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+// Package lifecycle starts, stops and restarts VirtualServers.
+//
+// Each operation patches the run strategy of the VirtualServer as needed, and records the request in the
+// ActionAnnotation and RequestedAtAnnotation annotations for the controller to act upon.
+// Completion is reported by the controller through the VirtualServerStarted condition, and may be awaited with (*Request).Wait.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	vsv1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	"github.com/coreweave/virtual-server/wait"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	kvv1 "kubevirt.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ActionAnnotation records the last lifecycle action requested on the VirtualServer
+	ActionAnnotation = "vs.coreweave.com/lifecycle-action"
+	// RequestedAtAnnotation records the RFC 3339 time, with fractional seconds, the last lifecycle action was requested at
+	RequestedAtAnnotation = "vs.coreweave.com/lifecycle-requested-at"
+	// GracePeriodAnnotation overrides TerminationGracePeriodSeconds when the VirtualServer is force stopped
+	GracePeriodAnnotation = "vs.coreweave.com/force-stop-grace-period-seconds"
+)
+
+// Action is a lifecycle operation requested on a VirtualServer
+type Action string
+
+const (
+	// ActionStart starts the VirtualServer
+	ActionStart Action = "start"
+	// ActionStop gracefully stops the VirtualServer
+	ActionStop Action = "stop"
+	// ActionRestart restarts the running VirtualServer
+	ActionRestart Action = "restart"
+	// ActionForceStop stops the VirtualServer with a grace period overriding TerminationGracePeriodSeconds
+	ActionForceStop Action = "force-stop"
+)
+
+// ErrNotRunning is returned when restarting a VirtualServer that is stopped
+var ErrNotRunning = errors.New("VirtualServer is not running")
+
+// Request is a lifecycle action recorded on a VirtualServer
+type Request struct {
+	// Key identifies the VirtualServer
+	Key client.ObjectKey
+	// Action is the requested action
+	Action Action
+	// RequestedAt is the time the action was requested at
+	RequestedAt metav1.Time
+	// GracePeriodSeconds overrides TerminationGracePeriodSeconds for ActionForceStop
+	GracePeriodSeconds *int64
+
+	client client.Client
+}
+
+// Start requests that the VirtualServer is started.
+// The Halted and Manual run strategies are replaced by Always; other run strategies already keep the VirtualServer running.
+// The replacement is permanent: a VirtualServer started from Manual is kept running by the Always run strategy,
+// until its run strategy is set back to Manual with RunStrategy.
+func Start(ctx context.Context, c client.Client, key client.ObjectKey) (*Request, error) {
+	return request(ctx, c, key, ActionStart, nil, func(vs *vsv1alpha1.VirtualServer) error {
+		if vs.Spec.RunStrategy == nil || *vs.Spec.RunStrategy == kvv1.RunStrategyHalted || *vs.Spec.RunStrategy == kvv1.RunStrategyManual {
+			vs.RunStrategy(kvv1.RunStrategyAlways)
+		}
+		return nil
+	})
+}
+
+// Stop requests that the VirtualServer is stopped gracefully, by setting the Halted run strategy
+func Stop(ctx context.Context, c client.Client, key client.ObjectKey) (*Request, error) {
+	return request(ctx, c, key, ActionStop, nil, halt)
+}
+
+// ForceStop requests that the VirtualServer is stopped within gracePeriodSeconds, overriding TerminationGracePeriodSeconds.
+// The spec TerminationGracePeriodSeconds is left unchanged for later stops.
+func ForceStop(ctx context.Context, c client.Client, key client.ObjectKey, gracePeriodSeconds int64) (*Request, error) {
+	if gracePeriodSeconds < 0 {
+		return nil, fmt.Errorf("grace period must be greater than or equal to 0, got %d", gracePeriodSeconds)
+	}
+	return request(ctx, c, key, ActionForceStop, &gracePeriodSeconds, halt)
+}
+
+// Restart requests that the running VirtualServer is restarted.
+// ErrNotRunning is returned if the VirtualServer has the Halted run strategy.
+func Restart(ctx context.Context, c client.Client, key client.ObjectKey) (*Request, error) {
+	return request(ctx, c, key, ActionRestart, nil, func(vs *vsv1alpha1.VirtualServer) error {
+		if vs.Spec.RunStrategy != nil && *vs.Spec.RunStrategy == kvv1.RunStrategyHalted ||
+			vs.Spec.RunStrategy == nil && !vs.Spec.InitializeRunning {
+			return ErrNotRunning
+		}
+		return nil
+	})
+}
+
+func halt(vs *vsv1alpha1.VirtualServer) error {
+	vs.RunStrategy(kvv1.RunStrategyHalted)
+	return nil
+}
+
+// request records the action on the VirtualServer and applies mutate to it, retrying on conflicts
+func request(ctx context.Context, c client.Client, key client.ObjectKey, action Action, gracePeriodSeconds *int64, mutate func(vs *vsv1alpha1.VirtualServer) error) (*Request, error) {
+	r := &Request{
+		Key:                key,
+		Action:             action,
+		RequestedAt:        metav1.NewTime(time.Now()),
+		GracePeriodSeconds: gracePeriodSeconds,
+		client:             c,
+	}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		vs := &vsv1alpha1.VirtualServer{}
+		if err := c.Get(ctx, key, vs); err != nil {
+			return err
+		}
+		patch := client.MergeFromWithOptions(vs.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		if err := mutate(vs); err != nil {
+			return err
+		}
+		r.annotate(vs)
+		return c.Patch(ctx, vs, patch)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not %s VirtualServer %s: %w", action, key, err)
+	}
+	return r, nil
+}
+
+// annotate records the request in the VirtualServer annotations
+func (r *Request) annotate(vs *vsv1alpha1.VirtualServer) {
+	annotations := vs.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[ActionAnnotation] = string(r.Action)
+	annotations[RequestedAtAnnotation] = r.RequestedAt.UTC().Format(time.RFC3339Nano)
+	delete(annotations, GracePeriodAnnotation)
+	if r.GracePeriodSeconds != nil {
+		annotations[GracePeriodAnnotation] = strconv.FormatInt(*r.GracePeriodSeconds, 10)
+	}
+	vs.SetAnnotations(annotations)
+}
+
+// LastRequest returns the last lifecycle request recorded on the VirtualServer, or nil if there is none
+func LastRequest(vs *vsv1alpha1.VirtualServer) (*Request, error) {
+	annotations := vs.GetAnnotations()
+	action, ok := annotations[ActionAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	r := &Request{
+		Key:    client.ObjectKeyFromObject(vs),
+		Action: Action(action),
+	}
+	switch r.Action {
+	case ActionStart, ActionStop, ActionRestart, ActionForceStop:
+	default:
+		return nil, fmt.Errorf("unknown lifecycle action %q", action)
+	}
+
+	requestedAt, err := time.Parse(time.RFC3339, annotations[RequestedAtAnnotation])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", RequestedAtAnnotation, err)
+	}
+	r.RequestedAt = metav1.NewTime(requestedAt)
+
+	if gracePeriod, ok := annotations[GracePeriodAnnotation]; ok && r.Action == ActionForceStop {
+		seconds, err := strconv.ParseInt(gracePeriod, 10, 64)
+		if err != nil || seconds < 0 {
+			return nil, fmt.Errorf("invalid %s annotation %q", GracePeriodAnnotation, gracePeriod)
+		}
+		r.GracePeriodSeconds = &seconds
+	}
+	return r, nil
+}
+
+// Wait waits until the controller reports the request as completed through the VirtualServerStarted condition.
+// A restart is completed once the VirtualServer has started again after the request, see wait.WaitForRestarted.
+// Waiting stops with wait.ErrFailed if the VirtualServer has a failure reason while starting or restarting.
+func (r *Request) Wait(ctx context.Context, opts ...wait.Option) (*vsv1alpha1.VirtualServer, error) {
+	switch r.Action {
+	case ActionStart:
+		return wait.WaitForStarted(ctx, r.client, r.Key, opts...)
+	case ActionStop, ActionForceStop:
+		return wait.WaitForStopped(ctx, r.client, r.Key, opts...)
+	}
+	return wait.WaitForRestarted(ctx, r.client, r.Key, r.RequestedAt.Time, opts...)
+}
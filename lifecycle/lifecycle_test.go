@@ -0,0 +1,206 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	vsv1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	"github.com/coreweave/virtual-server/lifecycle"
+	"github.com/coreweave/virtual-server/wait"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kvv1 "kubevirt.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var key = client.ObjectKey{Namespace: "default", Name: "my-virtual-server"}
+
+func newClient(t *testing.T, mutate func(vs *vsv1alpha1.VirtualServer)) client.WithWatch {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := vsv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	vs := vsv1alpha1.NewVirtualServer(key.Name, key.Namespace)
+	vs.TerminationGracePeriodSeconds(60)
+	vs.InitializeStatus()
+	mutate(vs)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(vs).Build()
+}
+
+func get(t *testing.T, c client.Client) *vsv1alpha1.VirtualServer {
+	t.Helper()
+	vs := &vsv1alpha1.VirtualServer{}
+	if err := c.Get(context.Background(), key, vs); err != nil {
+		t.Fatal(err)
+	}
+	return vs
+}
+
+func TestStart(t *testing.T) {
+	testCases := []struct {
+		name        string
+		runStrategy *kvv1.VirtualMachineRunStrategy
+		want        kvv1.VirtualMachineRunStrategy
+	}{
+		{name: "unset", want: kvv1.RunStrategyAlways},
+		{name: "halted", runStrategy: runStrategy(kvv1.RunStrategyHalted), want: kvv1.RunStrategyAlways},
+		{name: "manual", runStrategy: runStrategy(kvv1.RunStrategyManual), want: kvv1.RunStrategyAlways},
+		{name: "rerun on failure", runStrategy: runStrategy(kvv1.RunStrategyRerunOnFailure), want: kvv1.RunStrategyRerunOnFailure},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newClient(t, func(vs *vsv1alpha1.VirtualServer) {
+				vs.Spec.RunStrategy = tc.runStrategy
+			})
+			r, err := lifecycle.Start(context.Background(), c, key)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			vs := get(t, c)
+			if vs.Spec.RunStrategy == nil || *vs.Spec.RunStrategy != tc.want {
+				t.Errorf("expected run strategy %s, got %v", tc.want, vs.Spec.RunStrategy)
+			}
+			recorded, err := lifecycle.LastRequest(vs)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if recorded.Action != lifecycle.ActionStart || !recorded.RequestedAt.Equal(&r.RequestedAt) {
+				t.Errorf("expected the recorded request to match %s at %s, got %s at %s", r.Action, r.RequestedAt, recorded.Action, recorded.RequestedAt)
+			}
+		})
+	}
+}
+
+func TestForceStop(t *testing.T) {
+	c := newClient(t, func(vs *vsv1alpha1.VirtualServer) {
+		vs.RunStrategy(kvv1.RunStrategyAlways)
+	})
+	if _, err := lifecycle.ForceStop(context.Background(), c, key, 5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	vs := get(t, c)
+	if *vs.Spec.RunStrategy != kvv1.RunStrategyHalted {
+		t.Errorf("expected run strategy %s, got %s", kvv1.RunStrategyHalted, *vs.Spec.RunStrategy)
+	}
+	if *vs.Spec.TerminationGracePeriodSeconds != 60 {
+		t.Errorf("expected terminationGracePeriodSeconds to be unchanged, got %d", *vs.Spec.TerminationGracePeriodSeconds)
+	}
+	r, err := lifecycle.LastRequest(vs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Action != lifecycle.ActionForceStop || r.GracePeriodSeconds == nil || *r.GracePeriodSeconds != 5 {
+		t.Errorf("expected a force stop with a 5 second grace period, got %+v", r)
+	}
+
+	// A later graceful stop does not keep the grace period override
+	if _, err := lifecycle.Stop(context.Background(), c, key); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := get(t, c).Annotations[lifecycle.GracePeriodAnnotation]; ok {
+		t.Errorf("expected the %s annotation to be removed", lifecycle.GracePeriodAnnotation)
+	}
+
+	if _, err := lifecycle.ForceStop(context.Background(), c, key, -1); err == nil {
+		t.Error("expected an error for a negative grace period")
+	}
+}
+
+func TestRestartNotRunning(t *testing.T) {
+	c := newClient(t, func(vs *vsv1alpha1.VirtualServer) {
+		vs.RunStrategy(kvv1.RunStrategyHalted)
+	})
+	if _, err := lifecycle.Restart(context.Background(), c, key); !errors.Is(err, lifecycle.ErrNotRunning) {
+		t.Fatalf("expected %v, got %v", lifecycle.ErrNotRunning, err)
+	}
+	if _, ok := get(t, c).Annotations[lifecycle.ActionAnnotation]; ok {
+		t.Error("expected the request not to be recorded")
+	}
+}
+
+// started returns a client for a running VirtualServer that started at startedAt
+func started(t *testing.T, startedAt time.Time) client.WithWatch {
+	return newClient(t, func(vs *vsv1alpha1.VirtualServer) {
+		vs.InitializeRunning(true)
+		vs.SetCondition(vsv1alpha1.VSConditionTypeStarted, metav1.ConditionTrue, vsv1alpha1.VSConditionReasonStarted, nil, false)
+		apimeta.FindStatusCondition(vs.Status.Conditions, string(vsv1alpha1.VSConditionTypeStarted)).LastTransitionTime = metav1.NewTime(startedAt)
+	})
+}
+
+// update applies mutate to the VirtualServer after a delay, as the controller would
+func update(t *testing.T, c client.Client, mutates ...func(vs *vsv1alpha1.VirtualServer)) {
+	go func() {
+		for _, mutate := range mutates {
+			time.Sleep(50 * time.Millisecond)
+			vs := &vsv1alpha1.VirtualServer{}
+			if err := c.Get(context.Background(), key, vs); err != nil {
+				t.Errorf("could not get VirtualServer: %v", err)
+				return
+			}
+			mutate(vs)
+			if err := c.Update(context.Background(), vs); err != nil {
+				t.Errorf("could not update VirtualServer: %v", err)
+				return
+			}
+		}
+	}()
+}
+
+func TestRestartWait(t *testing.T) {
+	// The VirtualServer started before the restart is requested
+	c := started(t, time.Now().Add(-time.Hour))
+	r, err := lifecycle.Restart(context.Background(), c, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	update(t, c, func(vs *vsv1alpha1.VirtualServer) {
+		vs.SetCondition(vsv1alpha1.VSConditionTypeStarted, metav1.ConditionFalse, vsv1alpha1.VSConditionReasonStopped, nil, false)
+	}, func(vs *vsv1alpha1.VirtualServer) {
+		vs.SetCondition(vsv1alpha1.VSConditionTypeStarted, metav1.ConditionTrue, vsv1alpha1.VSConditionReasonStarted, nil, false)
+	})
+
+	if _, err := r.Wait(context.Background(), wait.WithTimeout(5*time.Second)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRestartWaitStartedInSameSecond(t *testing.T) {
+	// The VirtualServer started within the second the restart is requested in, before the request
+	c := started(t, time.Now())
+	r, err := lifecycle.Restart(context.Background(), c, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := r.Wait(context.Background(), wait.WithTimeout(200*time.Millisecond)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestRestartWaitFailed(t *testing.T) {
+	c := started(t, time.Now().Add(-time.Hour))
+	r, err := lifecycle.Restart(context.Background(), c, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	message := "VirtualMachine my-virtual-server is owned by another VirtualServer"
+	update(t, c, func(vs *vsv1alpha1.VirtualServer) {
+		vs.SetCondition(vsv1alpha1.VSConditionTypeVMReady, metav1.ConditionFalse, vsv1alpha1.VSConditionReasonVMNameTaken, &message, false)
+	})
+
+	if _, err := r.Wait(context.Background(), wait.WithTimeout(5*time.Second)); !errors.Is(err, wait.ErrFailed) {
+		t.Fatalf("expected %v, got %v", wait.ErrFailed, err)
+	}
+}
+
+func runStrategy(s kvv1.VirtualMachineRunStrategy) *kvv1.VirtualMachineRunStrategy {
+	return &s
+}
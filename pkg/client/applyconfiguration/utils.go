@@ -0,0 +1,135 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package applyconfiguration
+
+import (
+	v1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	v1beta1 "github.com/coreweave/virtual-server/api/v1beta1"
+	virtualserversv1alpha1 "github.com/coreweave/virtual-server/pkg/client/applyconfiguration/virtualservers/v1alpha1"
+	virtualserversv1beta1 "github.com/coreweave/virtual-server/pkg/client/applyconfiguration/virtualservers/v1beta1"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
+// apply configuration type exists for the given GroupVersionKind.
+func ForKind(kind schema.GroupVersionKind) interface{} {
+	switch kind {
+	// Group=virtualservers.coreweave.com, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("DiskAttributes"):
+		return &virtualserversv1alpha1.DiskAttributesApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Firmware"):
+		return &virtualserversv1alpha1.FirmwareApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServer"):
+		return &virtualserversv1alpha1.VirtualServerApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerDataVolumeTemplate"):
+		return &virtualserversv1alpha1.VirtualServerDataVolumeTemplateApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerDisks"):
+		return &virtualserversv1alpha1.VirtualServerDisksApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerFilesystem"):
+		return &virtualserversv1alpha1.VirtualServerFilesystemApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerFloatingIP"):
+		return &virtualserversv1alpha1.VirtualServerFloatingIPApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerMetaData"):
+		return &virtualserversv1alpha1.VirtualServerMetaDataApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerNetwork"):
+		return &virtualserversv1alpha1.VirtualServerNetworkApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerNetworkStatus"):
+		return &virtualserversv1alpha1.VirtualServerNetworkStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerOS"):
+		return &virtualserversv1alpha1.VirtualServerOSApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerResourceCPU"):
+		return &virtualserversv1alpha1.VirtualServerResourceCPUApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerResourceGPU"):
+		return &virtualserversv1alpha1.VirtualServerResourceGPUApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerResources"):
+		return &virtualserversv1alpha1.VirtualServerResourcesApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerRootDiskStatus"):
+		return &virtualserversv1alpha1.VirtualServerRootDiskStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerServiceTemplate"):
+		return &virtualserversv1alpha1.VirtualServerServiceTemplateApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerSpec"):
+		return &virtualserversv1alpha1.VirtualServerSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerStatus"):
+		return &virtualserversv1alpha1.VirtualServerStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerStorage"):
+		return &virtualserversv1alpha1.VirtualServerStorageApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerStorageRoot"):
+		return &virtualserversv1alpha1.VirtualServerStorageRootApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerStorageVolume"):
+		return &virtualserversv1alpha1.VirtualServerStorageVolumeApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerUser"):
+		return &virtualserversv1alpha1.VirtualServerUserApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerVPC"):
+		return &virtualserversv1alpha1.VirtualServerVPCApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("VirtualServerWindows"):
+		return &virtualserversv1alpha1.VirtualServerWindowsApplyConfiguration{}
+
+		// Group=virtualservers.coreweave.com, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithKind("DiskAttributes"):
+		return &virtualserversv1beta1.DiskAttributesApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("Firmware"):
+		return &virtualserversv1beta1.FirmwareApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServer"):
+		return &virtualserversv1beta1.VirtualServerApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerDataVolumeTemplate"):
+		return &virtualserversv1beta1.VirtualServerDataVolumeTemplateApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerDisks"):
+		return &virtualserversv1beta1.VirtualServerDisksApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerFilesystem"):
+		return &virtualserversv1beta1.VirtualServerFilesystemApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerFloatingIP"):
+		return &virtualserversv1beta1.VirtualServerFloatingIPApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerMetaData"):
+		return &virtualserversv1beta1.VirtualServerMetaDataApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerNetwork"):
+		return &virtualserversv1beta1.VirtualServerNetworkApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerNetworkStatus"):
+		return &virtualserversv1beta1.VirtualServerNetworkStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerOS"):
+		return &virtualserversv1beta1.VirtualServerOSApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerResourceCPU"):
+		return &virtualserversv1beta1.VirtualServerResourceCPUApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerResourceGPU"):
+		return &virtualserversv1beta1.VirtualServerResourceGPUApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerResources"):
+		return &virtualserversv1beta1.VirtualServerResourcesApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerRootDiskStatus"):
+		return &virtualserversv1beta1.VirtualServerRootDiskStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerServiceTemplate"):
+		return &virtualserversv1beta1.VirtualServerServiceTemplateApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerSpec"):
+		return &virtualserversv1beta1.VirtualServerSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerStatus"):
+		return &virtualserversv1beta1.VirtualServerStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerStorage"):
+		return &virtualserversv1beta1.VirtualServerStorageApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerStorageRoot"):
+		return &virtualserversv1beta1.VirtualServerStorageRootApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerStorageVolume"):
+		return &virtualserversv1beta1.VirtualServerStorageVolumeApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerUser"):
+		return &virtualserversv1beta1.VirtualServerUserApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerVPC"):
+		return &virtualserversv1beta1.VirtualServerVPCApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VirtualServerWindows"):
+		return &virtualserversv1beta1.VirtualServerWindowsApplyConfiguration{}
+
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	types "k8s.io/apimachinery/pkg/types"
+)
+
+// FirmwareApplyConfiguration represents an declarative configuration of the Firmware type for use
+// with apply.
+type FirmwareApplyConfiguration struct {
+	UUID   *types.UID `json:"uuid,omitempty"`
+	Serial *string    `json:"serial,omitempty"`
+}
+
+// FirmwareApplyConfiguration constructs an declarative configuration of the Firmware type for use with
+// apply.
+func Firmware() *FirmwareApplyConfiguration {
+	return &FirmwareApplyConfiguration{}
+}
+
+// WithUUID sets the UUID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UUID field is set to the value of the last call.
+func (b *FirmwareApplyConfiguration) WithUUID(value types.UID) *FirmwareApplyConfiguration {
+	b.UUID = &value
+	return b
+}
+
+// WithSerial sets the Serial field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Serial field is set to the value of the last call.
+func (b *FirmwareApplyConfiguration) WithSerial(value string) *FirmwareApplyConfiguration {
+	b.Serial = &value
+	return b
+}
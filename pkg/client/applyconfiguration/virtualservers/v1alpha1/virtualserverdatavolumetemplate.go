@@ -0,0 +1,81 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+	v1beta1 "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+// VirtualServerDataVolumeTemplateApplyConfiguration represents an declarative configuration of the VirtualServerDataVolumeTemplate type for use
+// with apply.
+type VirtualServerDataVolumeTemplateApplyConfiguration struct {
+	Size             *resource.Quantity             `json:"size,omitempty"`
+	Source           *v1beta1.DataVolumeSource      `json:"source,omitempty"`
+	StorageClassName *string                        `json:"storageClassName,omitempty"`
+	VolumeMode       *v1.PersistentVolumeMode       `json:"volumeMode,omitempty"`
+	AccessMode       *v1.PersistentVolumeAccessMode `json:"accessMode,omitempty"`
+}
+
+// VirtualServerDataVolumeTemplateApplyConfiguration constructs an declarative configuration of the VirtualServerDataVolumeTemplate type for use with
+// apply.
+func VirtualServerDataVolumeTemplate() *VirtualServerDataVolumeTemplateApplyConfiguration {
+	return &VirtualServerDataVolumeTemplateApplyConfiguration{}
+}
+
+// WithSize sets the Size field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Size field is set to the value of the last call.
+func (b *VirtualServerDataVolumeTemplateApplyConfiguration) WithSize(value resource.Quantity) *VirtualServerDataVolumeTemplateApplyConfiguration {
+	b.Size = &value
+	return b
+}
+
+// WithSource sets the Source field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Source field is set to the value of the last call.
+func (b *VirtualServerDataVolumeTemplateApplyConfiguration) WithSource(value v1beta1.DataVolumeSource) *VirtualServerDataVolumeTemplateApplyConfiguration {
+	b.Source = &value
+	return b
+}
+
+// WithStorageClassName sets the StorageClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StorageClassName field is set to the value of the last call.
+func (b *VirtualServerDataVolumeTemplateApplyConfiguration) WithStorageClassName(value string) *VirtualServerDataVolumeTemplateApplyConfiguration {
+	b.StorageClassName = &value
+	return b
+}
+
+// WithVolumeMode sets the VolumeMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the VolumeMode field is set to the value of the last call.
+func (b *VirtualServerDataVolumeTemplateApplyConfiguration) WithVolumeMode(value v1.PersistentVolumeMode) *VirtualServerDataVolumeTemplateApplyConfiguration {
+	b.VolumeMode = &value
+	return b
+}
+
+// WithAccessMode sets the AccessMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AccessMode field is set to the value of the last call.
+func (b *VirtualServerDataVolumeTemplateApplyConfiguration) WithAccessMode(value v1.PersistentVolumeAccessMode) *VirtualServerDataVolumeTemplateApplyConfiguration {
+	b.AccessMode = &value
+	return b
+}
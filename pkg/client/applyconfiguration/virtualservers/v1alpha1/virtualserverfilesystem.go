@@ -0,0 +1,60 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "kubevirt.io/api/core/v1"
+)
+
+// VirtualServerFilesystemApplyConfiguration represents an declarative configuration of the VirtualServerFilesystem type for use
+// with apply.
+type VirtualServerFilesystemApplyConfiguration struct {
+	VirtualServerStorageVolumeApplyConfiguration `json:",inline"`
+	Mountpoint                                   *string `json:"mountPoint,omitempty"`
+}
+
+// VirtualServerFilesystemApplyConfiguration constructs an declarative configuration of the VirtualServerFilesystem type for use with
+// apply.
+func VirtualServerFilesystem() *VirtualServerFilesystemApplyConfiguration {
+	return &VirtualServerFilesystemApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *VirtualServerFilesystemApplyConfiguration) WithName(value string) *VirtualServerFilesystemApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithSpec sets the Spec field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Spec field is set to the value of the last call.
+func (b *VirtualServerFilesystemApplyConfiguration) WithSpec(value v1.VolumeSource) *VirtualServerFilesystemApplyConfiguration {
+	b.Spec = &value
+	return b
+}
+
+// WithMountpoint sets the Mountpoint field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Mountpoint field is set to the value of the last call.
+func (b *VirtualServerFilesystemApplyConfiguration) WithMountpoint(value string) *VirtualServerFilesystemApplyConfiguration {
+	b.Mountpoint = &value
+	return b
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+)
+
+// VirtualServerNetworkApplyConfiguration represents an declarative configuration of the VirtualServerNetwork type for use
+// with apply.
+type VirtualServerNetworkApplyConfiguration struct {
+	DirectAttachLoadBalancerIP *bool                                           `json:"directAttachLoadBalancerIP,omitempty"`
+	FloatingIPs                []VirtualServerFloatingIPApplyConfiguration     `json:"floatingIPs,omitempty"`
+	TCP                        *VirtualServerServiceTemplateApplyConfiguration `json:"tcp,omitempty"`
+	UDP                        *VirtualServerServiceTemplateApplyConfiguration `json:"udp,omitempty"`
+	Public                     *bool                                           `json:"public,omitempty"`
+	DNSConfig                  *v1.PodDNSConfigApplyConfiguration              `json:"dnsConfig,omitempty"`
+	DNSPolicy                  *corev1.DNSPolicy                               `json:"dnsPolicy,omitempty"`
+	MACAddress                 *string                                         `json:"macAddress,omitempty"`
+	Headless                   *bool                                           `json:"headless,omitempty"`
+	VPCs                       []VirtualServerVPCApplyConfiguration            `json:"vpcs,omitempty"`
+	DisableK8sNetworking       *bool                                           `json:"disableK8sNetworking,omitempty"`
+}
+
+// VirtualServerNetworkApplyConfiguration constructs an declarative configuration of the VirtualServerNetwork type for use with
+// apply.
+func VirtualServerNetwork() *VirtualServerNetworkApplyConfiguration {
+	return &VirtualServerNetworkApplyConfiguration{}
+}
+
+// WithDirectAttachLoadBalancerIP sets the DirectAttachLoadBalancerIP field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DirectAttachLoadBalancerIP field is set to the value of the last call.
+func (b *VirtualServerNetworkApplyConfiguration) WithDirectAttachLoadBalancerIP(value bool) *VirtualServerNetworkApplyConfiguration {
+	b.DirectAttachLoadBalancerIP = &value
+	return b
+}
+
+// WithFloatingIPs adds the given value to the FloatingIPs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FloatingIPs field.
+func (b *VirtualServerNetworkApplyConfiguration) WithFloatingIPs(values ...*VirtualServerFloatingIPApplyConfiguration) *VirtualServerNetworkApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFloatingIPs")
+		}
+		b.FloatingIPs = append(b.FloatingIPs, *values[i])
+	}
+	return b
+}
+
+// WithTCP sets the TCP field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TCP field is set to the value of the last call.
+func (b *VirtualServerNetworkApplyConfiguration) WithTCP(value *VirtualServerServiceTemplateApplyConfiguration) *VirtualServerNetworkApplyConfiguration {
+	b.TCP = value
+	return b
+}
+
+// WithUDP sets the UDP field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UDP field is set to the value of the last call.
+func (b *VirtualServerNetworkApplyConfiguration) WithUDP(value *VirtualServerServiceTemplateApplyConfiguration) *VirtualServerNetworkApplyConfiguration {
+	b.UDP = value
+	return b
+}
+
+// WithPublic sets the Public field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Public field is set to the value of the last call.
+func (b *VirtualServerNetworkApplyConfiguration) WithPublic(value bool) *VirtualServerNetworkApplyConfiguration {
+	b.Public = &value
+	return b
+}
+
+// WithDNSConfig sets the DNSConfig field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DNSConfig field is set to the value of the last call.
+func (b *VirtualServerNetworkApplyConfiguration) WithDNSConfig(value *v1.PodDNSConfigApplyConfiguration) *VirtualServerNetworkApplyConfiguration {
+	b.DNSConfig = value
+	return b
+}
+
+// WithDNSPolicy sets the DNSPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DNSPolicy field is set to the value of the last call.
+func (b *VirtualServerNetworkApplyConfiguration) WithDNSPolicy(value corev1.DNSPolicy) *VirtualServerNetworkApplyConfiguration {
+	b.DNSPolicy = &value
+	return b
+}
+
+// WithMACAddress sets the MACAddress field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MACAddress field is set to the value of the last call.
+func (b *VirtualServerNetworkApplyConfiguration) WithMACAddress(value string) *VirtualServerNetworkApplyConfiguration {
+	b.MACAddress = &value
+	return b
+}
+
+// WithHeadless sets the Headless field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Headless field is set to the value of the last call.
+func (b *VirtualServerNetworkApplyConfiguration) WithHeadless(value bool) *VirtualServerNetworkApplyConfiguration {
+	b.Headless = &value
+	return b
+}
+
+// WithVPCs adds the given value to the VPCs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the VPCs field.
+func (b *VirtualServerNetworkApplyConfiguration) WithVPCs(values ...*VirtualServerVPCApplyConfiguration) *VirtualServerNetworkApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithVPCs")
+		}
+		b.VPCs = append(b.VPCs, *values[i])
+	}
+	return b
+}
+
+// WithDisableK8sNetworking sets the DisableK8sNetworking field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DisableK8sNetworking field is set to the value of the last call.
+func (b *VirtualServerNetworkApplyConfiguration) WithDisableK8sNetworking(value bool) *VirtualServerNetworkApplyConfiguration {
+	b.DisableK8sNetworking = &value
+	return b
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+)
+
+// VirtualServerOSApplyConfiguration represents an declarative configuration of the VirtualServerOS type for use
+// with apply.
+type VirtualServerOSApplyConfiguration struct {
+	Type           *v1alpha1.VirtualServerOSType           `json:"type,omitempty"`
+	Definition     *string                                 `json:"definition,omitempty"`
+	EnableUEFIBoot *bool                                   `json:"enableUEFIBoot,omitempty"`
+	Provisioner    *v1alpha1.VirtualServerProvisioner      `json:"provisioner,omitempty"`
+	Windows        *VirtualServerWindowsApplyConfiguration `json:"windows,omitempty"`
+}
+
+// VirtualServerOSApplyConfiguration constructs an declarative configuration of the VirtualServerOS type for use with
+// apply.
+func VirtualServerOS() *VirtualServerOSApplyConfiguration {
+	return &VirtualServerOSApplyConfiguration{}
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *VirtualServerOSApplyConfiguration) WithType(value v1alpha1.VirtualServerOSType) *VirtualServerOSApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithDefinition sets the Definition field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Definition field is set to the value of the last call.
+func (b *VirtualServerOSApplyConfiguration) WithDefinition(value string) *VirtualServerOSApplyConfiguration {
+	b.Definition = &value
+	return b
+}
+
+// WithEnableUEFIBoot sets the EnableUEFIBoot field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EnableUEFIBoot field is set to the value of the last call.
+func (b *VirtualServerOSApplyConfiguration) WithEnableUEFIBoot(value bool) *VirtualServerOSApplyConfiguration {
+	b.EnableUEFIBoot = &value
+	return b
+}
+
+// WithProvisioner sets the Provisioner field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Provisioner field is set to the value of the last call.
+func (b *VirtualServerOSApplyConfiguration) WithProvisioner(value v1alpha1.VirtualServerProvisioner) *VirtualServerOSApplyConfiguration {
+	b.Provisioner = &value
+	return b
+}
+
+// WithWindows sets the Windows field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Windows field is set to the value of the last call.
+func (b *VirtualServerOSApplyConfiguration) WithWindows(value *VirtualServerWindowsApplyConfiguration) *VirtualServerOSApplyConfiguration {
+	b.Windows = value
+	return b
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// VirtualServerResourceCPUApplyConfiguration represents an declarative configuration of the VirtualServerResourceCPU type for use
+// with apply.
+type VirtualServerResourceCPUApplyConfiguration struct {
+	Type  *string `json:"type,omitempty"`
+	Count *uint32 `json:"count,omitempty"`
+}
+
+// VirtualServerResourceCPUApplyConfiguration constructs an declarative configuration of the VirtualServerResourceCPU type for use with
+// apply.
+func VirtualServerResourceCPU() *VirtualServerResourceCPUApplyConfiguration {
+	return &VirtualServerResourceCPUApplyConfiguration{}
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *VirtualServerResourceCPUApplyConfiguration) WithType(value string) *VirtualServerResourceCPUApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithCount sets the Count field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Count field is set to the value of the last call.
+func (b *VirtualServerResourceCPUApplyConfiguration) WithCount(value uint32) *VirtualServerResourceCPUApplyConfiguration {
+	b.Count = &value
+	return b
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// VirtualServerRootDiskStatusApplyConfiguration represents an declarative configuration of the VirtualServerRootDiskStatus type for use
+// with apply.
+type VirtualServerRootDiskStatusApplyConfiguration struct {
+	RequestedSize *resource.Quantity                         `json:"requestedSize,omitempty"`
+	Capacity      *resource.Quantity                         `json:"capacity,omitempty"`
+	GuestSize     *resource.Quantity                         `json:"guestSize,omitempty"`
+	ResizeState   *v1alpha1.VirtualServerRootDiskResizeState `json:"resizeState,omitempty"`
+}
+
+// VirtualServerRootDiskStatusApplyConfiguration constructs an declarative configuration of the VirtualServerRootDiskStatus type for use with
+// apply.
+func VirtualServerRootDiskStatus() *VirtualServerRootDiskStatusApplyConfiguration {
+	return &VirtualServerRootDiskStatusApplyConfiguration{}
+}
+
+// WithRequestedSize sets the RequestedSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequestedSize field is set to the value of the last call.
+func (b *VirtualServerRootDiskStatusApplyConfiguration) WithRequestedSize(value resource.Quantity) *VirtualServerRootDiskStatusApplyConfiguration {
+	b.RequestedSize = &value
+	return b
+}
+
+// WithCapacity sets the Capacity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Capacity field is set to the value of the last call.
+func (b *VirtualServerRootDiskStatusApplyConfiguration) WithCapacity(value resource.Quantity) *VirtualServerRootDiskStatusApplyConfiguration {
+	b.Capacity = &value
+	return b
+}
+
+// WithGuestSize sets the GuestSize field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GuestSize field is set to the value of the last call.
+func (b *VirtualServerRootDiskStatusApplyConfiguration) WithGuestSize(value resource.Quantity) *VirtualServerRootDiskStatusApplyConfiguration {
+	b.GuestSize = &value
+	return b
+}
+
+// WithResizeState sets the ResizeState field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResizeState field is set to the value of the last call.
+func (b *VirtualServerRootDiskStatusApplyConfiguration) WithResizeState(value v1alpha1.VirtualServerRootDiskResizeState) *VirtualServerRootDiskStatusApplyConfiguration {
+	b.ResizeState = &value
+	return b
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+)
+
+// VirtualServerServiceTemplateApplyConfiguration represents an declarative configuration of the VirtualServerServiceTemplate type for use
+// with apply.
+type VirtualServerServiceTemplateApplyConfiguration struct {
+	Ports []v1alpha1.Port `json:"ports,omitempty"`
+}
+
+// VirtualServerServiceTemplateApplyConfiguration constructs an declarative configuration of the VirtualServerServiceTemplate type for use with
+// apply.
+func VirtualServerServiceTemplate() *VirtualServerServiceTemplateApplyConfiguration {
+	return &VirtualServerServiceTemplateApplyConfiguration{}
+}
+
+// WithPorts adds the given value to the Ports field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Ports field.
+func (b *VirtualServerServiceTemplateApplyConfiguration) WithPorts(values ...v1alpha1.Port) *VirtualServerServiceTemplateApplyConfiguration {
+	for i := range values {
+		b.Ports = append(b.Ports, values[i])
+	}
+	return b
+}
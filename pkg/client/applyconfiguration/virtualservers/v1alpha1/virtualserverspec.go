@@ -0,0 +1,202 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+	corev1 "kubevirt.io/api/core/v1"
+)
+
+// VirtualServerSpecApplyConfiguration represents an declarative configuration of the VirtualServerSpec type for use
+// with apply.
+type VirtualServerSpecApplyConfiguration struct {
+	Region                        *string                                   `json:"region,omitempty"`
+	Affinity                      *v1.AffinityApplyConfiguration            `json:"affinity,omitempty"`
+	OS                            *VirtualServerOSApplyConfiguration        `json:"os,omitempty"`
+	Resources                     *VirtualServerResourcesApplyConfiguration `json:"resources,omitempty"`
+	Storage                       *VirtualServerStorageApplyConfiguration   `json:"storage,omitempty"`
+	LivenessProbe                 *corev1.Probe                             `json:"livenessProbe,omitempty"`
+	ReadinessProbe                *corev1.Probe                             `json:"readinessProbe,omitempty"`
+	Users                         []VirtualServerUserApplyConfiguration     `json:"users,omitempty"`
+	Network                       *VirtualServerNetworkApplyConfiguration   `json:"network,omitempty"`
+	InitializeRunning             *bool                                     `json:"initializeRunning,omitempty"`
+	CloudInit                     *string                                   `json:"cloudInit,omitempty"`
+	Ignition                      *string                                   `json:"ignition,omitempty"`
+	NetworkData                   *string                                   `json:"networkData,omitempty"`
+	MetaData                      *VirtualServerMetaDataApplyConfiguration  `json:"metaData,omitempty"`
+	RunStrategy                   *corev1.VirtualMachineRunStrategy         `json:"runStrategy,omitempty"`
+	Firmware                      *FirmwareApplyConfiguration               `json:"firmware,omitempty"`
+	UseVirtioTransitional         *bool                                     `json:"useVirtioTransitional,omitempty"`
+	TerminationGracePeriodSeconds *int64                                    `json:"terminationGracePeriodSeconds,omitempty"`
+}
+
+// VirtualServerSpecApplyConfiguration constructs an declarative configuration of the VirtualServerSpec type for use with
+// apply.
+func VirtualServerSpec() *VirtualServerSpecApplyConfiguration {
+	return &VirtualServerSpecApplyConfiguration{}
+}
+
+// WithRegion sets the Region field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Region field is set to the value of the last call.
+func (b *VirtualServerSpecApplyConfiguration) WithRegion(value string) *VirtualServerSpecApplyConfiguration {
+	b.Region = &value
+	return b
+}
+
+// WithAffinity sets the Affinity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Affinity field is set to the value of the last call.
+func (b *VirtualServerSpecApplyConfiguration) WithAffinity(value *v1.AffinityApplyConfiguration) *VirtualServerSpecApplyConfiguration {
+	b.Affinity = value
+	return b
+}
+
+// WithOS sets the OS field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OS field is set to the value of the last call.
+func (b *VirtualServerSpecApplyConfiguration) WithOS(value *VirtualServerOSApplyConfiguration) *VirtualServerSpecApplyConfiguration {
+	b.OS = value
+	return b
+}
+
+// WithResources sets the Resources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resources field is set to the value of the last call.
+func (b *VirtualServerSpecApplyConfiguration) WithResources(value *VirtualServerResourcesApplyConfiguration) *VirtualServerSpecApplyConfiguration {
+	b.Resources = value
+	return b
+}
+
+// WithStorage sets the Storage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Storage field is set to the value of the last call.
+func (b *VirtualServerSpecApplyConfiguration) WithStorage(value *VirtualServerStorageApplyConfiguration) *VirtualServerSpecApplyConfiguration {
+	b.Storage = value
+	return b
+}
+
+// WithLivenessProbe sets the LivenessProbe field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LivenessProbe field is set to the value of the last call.
+func (b *VirtualServerSpecApplyConfiguration) WithLivenessProbe(value corev1.Probe) *VirtualServerSpecApplyConfiguration {
+	b.LivenessProbe = &value
+	return b
+}
+
+// WithReadinessProbe sets the ReadinessProbe field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReadinessProbe field is set to the value of the last call.
+func (b *VirtualServerSpecApplyConfiguration) WithReadinessProbe(value corev1.Probe) *VirtualServerSpecApplyConfiguration {
+	b.ReadinessProbe = &value
+	return b
+}
+
+// WithUsers adds the given value to the Users field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Users field.
+func (b *VirtualServerSpecApplyConfiguration) WithUsers(values ...*VirtualServerUserApplyConfiguration) *VirtualServerSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithUsers")
+		}
+		b.Users = append(b.Users, *values[i])
+	}
+	return b
+}
+
+// WithNetwork sets the Network field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Network field is set to the value of the last call.
+func (b *VirtualServerSpecApplyConfiguration) WithNetwork(value *VirtualServerNetworkApplyConfiguration) *VirtualServerSpecApplyConfiguration {
+	b.Network = value
+	return b
+}
+
+// WithInitializeRunning sets the InitializeRunning field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InitializeRunning field is set to the value of the last call.
+func (b *VirtualServerSpecApplyConfiguration) WithInitializeRunning(value bool) *VirtualServerSpecApplyConfiguration {
+	b.InitializeRunning = &value
+	return b
+}
+
+// WithCloudInit sets the CloudInit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CloudInit field is set to the value of the last call.
+func (b *VirtualServerSpecApplyConfiguration) WithCloudInit(value string) *VirtualServerSpecApplyConfiguration {
+	b.CloudInit = &value
+	return b
+}
+
+// WithIgnition sets the Ignition field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Ignition field is set to the value of the last call.
+func (b *VirtualServerSpecApplyConfiguration) WithIgnition(value string) *VirtualServerSpecApplyConfiguration {
+	b.Ignition = &value
+	return b
+}
+
+// WithNetworkData sets the NetworkData field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NetworkData field is set to the value of the last call.
+func (b *VirtualServerSpecApplyConfiguration) WithNetworkData(value string) *VirtualServerSpecApplyConfiguration {
+	b.NetworkData = &value
+	return b
+}
+
+// WithMetaData sets the MetaData field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MetaData field is set to the value of the last call.
+func (b *VirtualServerSpecApplyConfiguration) WithMetaData(value *VirtualServerMetaDataApplyConfiguration) *VirtualServerSpecApplyConfiguration {
+	b.MetaData = value
+	return b
+}
+
+// WithRunStrategy sets the RunStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RunStrategy field is set to the value of the last call.
+func (b *VirtualServerSpecApplyConfiguration) WithRunStrategy(value corev1.VirtualMachineRunStrategy) *VirtualServerSpecApplyConfiguration {
+	b.RunStrategy = &value
+	return b
+}
+
+// WithFirmware sets the Firmware field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Firmware field is set to the value of the last call.
+func (b *VirtualServerSpecApplyConfiguration) WithFirmware(value *FirmwareApplyConfiguration) *VirtualServerSpecApplyConfiguration {
+	b.Firmware = value
+	return b
+}
+
+// WithUseVirtioTransitional sets the UseVirtioTransitional field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UseVirtioTransitional field is set to the value of the last call.
+func (b *VirtualServerSpecApplyConfiguration) WithUseVirtioTransitional(value bool) *VirtualServerSpecApplyConfiguration {
+	b.UseVirtioTransitional = &value
+	return b
+}
+
+// WithTerminationGracePeriodSeconds sets the TerminationGracePeriodSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TerminationGracePeriodSeconds field is set to the value of the last call.
+func (b *VirtualServerSpecApplyConfiguration) WithTerminationGracePeriodSeconds(value int64) *VirtualServerSpecApplyConfiguration {
+	b.TerminationGracePeriodSeconds = &value
+	return b
+}
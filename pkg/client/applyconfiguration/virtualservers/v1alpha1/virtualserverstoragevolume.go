@@ -0,0 +1,52 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "kubevirt.io/api/core/v1"
+)
+
+// VirtualServerStorageVolumeApplyConfiguration represents an declarative configuration of the VirtualServerStorageVolume type for use
+// with apply.
+type VirtualServerStorageVolumeApplyConfiguration struct {
+	Name *string          `json:"name,omitempty"`
+	Spec *v1.VolumeSource `json:"spec,omitempty"`
+}
+
+// VirtualServerStorageVolumeApplyConfiguration constructs an declarative configuration of the VirtualServerStorageVolume type for use with
+// apply.
+func VirtualServerStorageVolume() *VirtualServerStorageVolumeApplyConfiguration {
+	return &VirtualServerStorageVolumeApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *VirtualServerStorageVolumeApplyConfiguration) WithName(value string) *VirtualServerStorageVolumeApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithSpec sets the Spec field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Spec field is set to the value of the last call.
+func (b *VirtualServerStorageVolumeApplyConfiguration) WithSpec(value v1.VolumeSource) *VirtualServerStorageVolumeApplyConfiguration {
+	b.Spec = &value
+	return b
+}
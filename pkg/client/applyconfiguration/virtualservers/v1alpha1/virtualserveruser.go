@@ -0,0 +1,148 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// VirtualServerUserApplyConfiguration represents an declarative configuration of the VirtualServerUser type for use
+// with apply.
+type VirtualServerUserApplyConfiguration struct {
+	Username              *string               `json:"username,omitempty"`
+	Password              *string               `json:"password,omitempty"`
+	HashedPassword        *string               `json:"hashedPassword,omitempty"`
+	PasswordSecretRef     *v1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+	SSHPublicKey          *string               `json:"sshpublickey,omitempty"`
+	SSHPublicKeys         []string              `json:"sshPublicKeys,omitempty"`
+	SSHPublicKeySecretRef *v1.SecretKeySelector `json:"sshPublicKeySecretRef,omitempty"`
+	Groups                []string              `json:"groups,omitempty"`
+	Sudo                  []string              `json:"sudo,omitempty"`
+	Shell                 *string               `json:"shell,omitempty"`
+	LockPassword          *bool                 `json:"lockPassword,omitempty"`
+	Administrator         *bool                 `json:"administrator,omitempty"`
+}
+
+// VirtualServerUserApplyConfiguration constructs an declarative configuration of the VirtualServerUser type for use with
+// apply.
+func VirtualServerUser() *VirtualServerUserApplyConfiguration {
+	return &VirtualServerUserApplyConfiguration{}
+}
+
+// WithUsername sets the Username field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Username field is set to the value of the last call.
+func (b *VirtualServerUserApplyConfiguration) WithUsername(value string) *VirtualServerUserApplyConfiguration {
+	b.Username = &value
+	return b
+}
+
+// WithPassword sets the Password field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Password field is set to the value of the last call.
+func (b *VirtualServerUserApplyConfiguration) WithPassword(value string) *VirtualServerUserApplyConfiguration {
+	b.Password = &value
+	return b
+}
+
+// WithHashedPassword sets the HashedPassword field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HashedPassword field is set to the value of the last call.
+func (b *VirtualServerUserApplyConfiguration) WithHashedPassword(value string) *VirtualServerUserApplyConfiguration {
+	b.HashedPassword = &value
+	return b
+}
+
+// WithPasswordSecretRef sets the PasswordSecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PasswordSecretRef field is set to the value of the last call.
+func (b *VirtualServerUserApplyConfiguration) WithPasswordSecretRef(value v1.SecretKeySelector) *VirtualServerUserApplyConfiguration {
+	b.PasswordSecretRef = &value
+	return b
+}
+
+// WithSSHPublicKey sets the SSHPublicKey field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SSHPublicKey field is set to the value of the last call.
+func (b *VirtualServerUserApplyConfiguration) WithSSHPublicKey(value string) *VirtualServerUserApplyConfiguration {
+	b.SSHPublicKey = &value
+	return b
+}
+
+// WithSSHPublicKeys adds the given value to the SSHPublicKeys field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the SSHPublicKeys field.
+func (b *VirtualServerUserApplyConfiguration) WithSSHPublicKeys(values ...string) *VirtualServerUserApplyConfiguration {
+	for i := range values {
+		b.SSHPublicKeys = append(b.SSHPublicKeys, values[i])
+	}
+	return b
+}
+
+// WithSSHPublicKeySecretRef sets the SSHPublicKeySecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SSHPublicKeySecretRef field is set to the value of the last call.
+func (b *VirtualServerUserApplyConfiguration) WithSSHPublicKeySecretRef(value v1.SecretKeySelector) *VirtualServerUserApplyConfiguration {
+	b.SSHPublicKeySecretRef = &value
+	return b
+}
+
+// WithGroups adds the given value to the Groups field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Groups field.
+func (b *VirtualServerUserApplyConfiguration) WithGroups(values ...string) *VirtualServerUserApplyConfiguration {
+	for i := range values {
+		b.Groups = append(b.Groups, values[i])
+	}
+	return b
+}
+
+// WithSudo adds the given value to the Sudo field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Sudo field.
+func (b *VirtualServerUserApplyConfiguration) WithSudo(values ...string) *VirtualServerUserApplyConfiguration {
+	for i := range values {
+		b.Sudo = append(b.Sudo, values[i])
+	}
+	return b
+}
+
+// WithShell sets the Shell field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Shell field is set to the value of the last call.
+func (b *VirtualServerUserApplyConfiguration) WithShell(value string) *VirtualServerUserApplyConfiguration {
+	b.Shell = &value
+	return b
+}
+
+// WithLockPassword sets the LockPassword field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LockPassword field is set to the value of the last call.
+func (b *VirtualServerUserApplyConfiguration) WithLockPassword(value bool) *VirtualServerUserApplyConfiguration {
+	b.LockPassword = &value
+	return b
+}
+
+// WithAdministrator sets the Administrator field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Administrator field is set to the value of the last call.
+func (b *VirtualServerUserApplyConfiguration) WithAdministrator(value bool) *VirtualServerUserApplyConfiguration {
+	b.Administrator = &value
+	return b
+}
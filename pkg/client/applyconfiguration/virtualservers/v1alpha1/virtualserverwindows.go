@@ -0,0 +1,99 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// VirtualServerWindowsApplyConfiguration represents an declarative configuration of the VirtualServerWindows type for use
+// with apply.
+type VirtualServerWindowsApplyConfiguration struct {
+	ComputerName                   *string               `json:"computerName,omitempty"`
+	TimeZone                       *string               `json:"timeZone,omitempty"`
+	Locale                         *string               `json:"locale,omitempty"`
+	ProductKey                     *string               `json:"productKey,omitempty"`
+	AdministratorPasswordSecretRef *v1.SecretKeySelector `json:"administratorPasswordSecretRef,omitempty"`
+	FirstLogonCommands             []string              `json:"firstLogonCommands,omitempty"`
+	EnableRDP                      *bool                 `json:"enableRDP,omitempty"`
+}
+
+// VirtualServerWindowsApplyConfiguration constructs an declarative configuration of the VirtualServerWindows type for use with
+// apply.
+func VirtualServerWindows() *VirtualServerWindowsApplyConfiguration {
+	return &VirtualServerWindowsApplyConfiguration{}
+}
+
+// WithComputerName sets the ComputerName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ComputerName field is set to the value of the last call.
+func (b *VirtualServerWindowsApplyConfiguration) WithComputerName(value string) *VirtualServerWindowsApplyConfiguration {
+	b.ComputerName = &value
+	return b
+}
+
+// WithTimeZone sets the TimeZone field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TimeZone field is set to the value of the last call.
+func (b *VirtualServerWindowsApplyConfiguration) WithTimeZone(value string) *VirtualServerWindowsApplyConfiguration {
+	b.TimeZone = &value
+	return b
+}
+
+// WithLocale sets the Locale field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Locale field is set to the value of the last call.
+func (b *VirtualServerWindowsApplyConfiguration) WithLocale(value string) *VirtualServerWindowsApplyConfiguration {
+	b.Locale = &value
+	return b
+}
+
+// WithProductKey sets the ProductKey field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProductKey field is set to the value of the last call.
+func (b *VirtualServerWindowsApplyConfiguration) WithProductKey(value string) *VirtualServerWindowsApplyConfiguration {
+	b.ProductKey = &value
+	return b
+}
+
+// WithAdministratorPasswordSecretRef sets the AdministratorPasswordSecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AdministratorPasswordSecretRef field is set to the value of the last call.
+func (b *VirtualServerWindowsApplyConfiguration) WithAdministratorPasswordSecretRef(value v1.SecretKeySelector) *VirtualServerWindowsApplyConfiguration {
+	b.AdministratorPasswordSecretRef = &value
+	return b
+}
+
+// WithFirstLogonCommands adds the given value to the FirstLogonCommands field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FirstLogonCommands field.
+func (b *VirtualServerWindowsApplyConfiguration) WithFirstLogonCommands(values ...string) *VirtualServerWindowsApplyConfiguration {
+	for i := range values {
+		b.FirstLogonCommands = append(b.FirstLogonCommands, values[i])
+	}
+	return b
+}
+
+// WithEnableRDP sets the EnableRDP field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EnableRDP field is set to the value of the last call.
+func (b *VirtualServerWindowsApplyConfiguration) WithEnableRDP(value bool) *VirtualServerWindowsApplyConfiguration {
+	b.EnableRDP = &value
+	return b
+}
@@ -0,0 +1,31 @@
+package v1beta1_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	vsv1beta1 "github.com/coreweave/virtual-server/pkg/client/applyconfiguration/virtualservers/v1beta1"
+	kvv1 "kubevirt.io/api/core/v1"
+)
+
+func TestVirtualServerApplyConfiguration(t *testing.T) {
+	vs := vsv1beta1.VirtualServer("my-virtual-server", "default").
+		WithSpec(vsv1beta1.VirtualServerSpec().
+			WithStorage(vsv1beta1.VirtualServerStorage().
+				WithAdditionalDisks(vsv1beta1.VirtualServerDisks().
+					WithName("data").
+					WithSpec(kvv1.VolumeSource{PersistentVolumeClaim: &kvv1.PersistentVolumeClaimVolumeSource{}}))).
+			WithNetwork(vsv1beta1.VirtualServerNetwork().
+				WithVPCs(vsv1beta1.VirtualServerVPC().WithName("my-vpc"))))
+
+	got, err := json.Marshal(vs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the fields set by the builders are included, so unrelated fields are not claimed by the field manager
+	want := `{"kind":"VirtualServer","apiVersion":"virtualservers.coreweave.com/v1beta1","metadata":{"name":"my-virtual-server","namespace":"default"},` +
+		`"spec":{"storage":{"additionalDisks":[{"name":"data","spec":{"persistentVolumeClaim":{"claimName":""}}}]},"network":{"vpcs":[{"name":"my-vpc"}]}}}`
+	if string(got) != want {
+		t.Errorf("expected apply configuration\n%s\ngot\n%s", want, got)
+	}
+}
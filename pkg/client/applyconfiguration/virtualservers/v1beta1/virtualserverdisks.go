@@ -0,0 +1,77 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "kubevirt.io/api/core/v1"
+)
+
+// VirtualServerDisksApplyConfiguration represents an declarative configuration of the VirtualServerDisks type for use
+// with apply.
+type VirtualServerDisksApplyConfiguration struct {
+	VirtualServerStorageVolumeApplyConfiguration `json:",inline"`
+	DiskAttributesApplyConfiguration             `json:",inline"`
+	DataVolumeTemplate                           *VirtualServerDataVolumeTemplateApplyConfiguration `json:"dataVolumeTemplate,omitempty"`
+}
+
+// VirtualServerDisksApplyConfiguration constructs an declarative configuration of the VirtualServerDisks type for use with
+// apply.
+func VirtualServerDisks() *VirtualServerDisksApplyConfiguration {
+	return &VirtualServerDisksApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *VirtualServerDisksApplyConfiguration) WithName(value string) *VirtualServerDisksApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithSpec sets the Spec field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Spec field is set to the value of the last call.
+func (b *VirtualServerDisksApplyConfiguration) WithSpec(value v1.VolumeSource) *VirtualServerDisksApplyConfiguration {
+	b.Spec = &value
+	return b
+}
+
+// WithReadOnly sets the ReadOnly field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReadOnly field is set to the value of the last call.
+func (b *VirtualServerDisksApplyConfiguration) WithReadOnly(value bool) *VirtualServerDisksApplyConfiguration {
+	b.ReadOnly = &value
+	return b
+}
+
+// WithSerial sets the Serial field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Serial field is set to the value of the last call.
+func (b *VirtualServerDisksApplyConfiguration) WithSerial(value string) *VirtualServerDisksApplyConfiguration {
+	b.Serial = &value
+	return b
+}
+
+// WithDataVolumeTemplate sets the DataVolumeTemplate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DataVolumeTemplate field is set to the value of the last call.
+func (b *VirtualServerDisksApplyConfiguration) WithDataVolumeTemplate(value *VirtualServerDataVolumeTemplateApplyConfiguration) *VirtualServerDisksApplyConfiguration {
+	b.DataVolumeTemplate = value
+	return b
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// VirtualServerNetworkStatusApplyConfiguration represents an declarative configuration of the VirtualServerNetworkStatus type for use
+// with apply.
+type VirtualServerNetworkStatusApplyConfiguration struct {
+	InternalIP  *string           `json:"internalIP,omitempty"`
+	ExternalIP  *string           `json:"externalIP,omitempty"`
+	ServiceIP   *string           `json:"serviceIP,omitempty"`
+	FloatingIPs map[string]string `json:"floatingIPs,omitempty"`
+}
+
+// VirtualServerNetworkStatusApplyConfiguration constructs an declarative configuration of the VirtualServerNetworkStatus type for use with
+// apply.
+func VirtualServerNetworkStatus() *VirtualServerNetworkStatusApplyConfiguration {
+	return &VirtualServerNetworkStatusApplyConfiguration{}
+}
+
+// WithInternalIP sets the InternalIP field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InternalIP field is set to the value of the last call.
+func (b *VirtualServerNetworkStatusApplyConfiguration) WithInternalIP(value string) *VirtualServerNetworkStatusApplyConfiguration {
+	b.InternalIP = &value
+	return b
+}
+
+// WithExternalIP sets the ExternalIP field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExternalIP field is set to the value of the last call.
+func (b *VirtualServerNetworkStatusApplyConfiguration) WithExternalIP(value string) *VirtualServerNetworkStatusApplyConfiguration {
+	b.ExternalIP = &value
+	return b
+}
+
+// WithServiceIP sets the ServiceIP field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceIP field is set to the value of the last call.
+func (b *VirtualServerNetworkStatusApplyConfiguration) WithServiceIP(value string) *VirtualServerNetworkStatusApplyConfiguration {
+	b.ServiceIP = &value
+	return b
+}
+
+// WithFloatingIPs puts the entries into the FloatingIPs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the FloatingIPs field,
+// overwriting an existing map entries in FloatingIPs field with the same key.
+func (b *VirtualServerNetworkStatusApplyConfiguration) WithFloatingIPs(entries map[string]string) *VirtualServerNetworkStatusApplyConfiguration {
+	if b.FloatingIPs == nil && len(entries) > 0 {
+		b.FloatingIPs = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.FloatingIPs[k] = v
+	}
+	return b
+}
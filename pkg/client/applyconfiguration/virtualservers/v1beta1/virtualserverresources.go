@@ -0,0 +1,70 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// VirtualServerResourcesApplyConfiguration represents an declarative configuration of the VirtualServerResources type for use
+// with apply.
+type VirtualServerResourcesApplyConfiguration struct {
+	Definition *string                                     `json:"definition,omitempty"`
+	GPU        *VirtualServerResourceGPUApplyConfiguration `json:"gpu,omitempty"`
+	CPU        *VirtualServerResourceCPUApplyConfiguration `json:"cpu,omitempty"`
+	Memory     *resource.Quantity                          `json:"memory,omitempty"`
+}
+
+// VirtualServerResourcesApplyConfiguration constructs an declarative configuration of the VirtualServerResources type for use with
+// apply.
+func VirtualServerResources() *VirtualServerResourcesApplyConfiguration {
+	return &VirtualServerResourcesApplyConfiguration{}
+}
+
+// WithDefinition sets the Definition field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Definition field is set to the value of the last call.
+func (b *VirtualServerResourcesApplyConfiguration) WithDefinition(value string) *VirtualServerResourcesApplyConfiguration {
+	b.Definition = &value
+	return b
+}
+
+// WithGPU sets the GPU field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GPU field is set to the value of the last call.
+func (b *VirtualServerResourcesApplyConfiguration) WithGPU(value *VirtualServerResourceGPUApplyConfiguration) *VirtualServerResourcesApplyConfiguration {
+	b.GPU = value
+	return b
+}
+
+// WithCPU sets the CPU field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CPU field is set to the value of the last call.
+func (b *VirtualServerResourcesApplyConfiguration) WithCPU(value *VirtualServerResourceCPUApplyConfiguration) *VirtualServerResourcesApplyConfiguration {
+	b.CPU = value
+	return b
+}
+
+// WithMemory sets the Memory field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Memory field is set to the value of the last call.
+func (b *VirtualServerResourcesApplyConfiguration) WithMemory(value resource.Quantity) *VirtualServerResourcesApplyConfiguration {
+	b.Memory = &value
+	return b
+}
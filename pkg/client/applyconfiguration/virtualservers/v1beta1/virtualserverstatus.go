@@ -0,0 +1,113 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/coreweave/virtual-server/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// VirtualServerStatusApplyConfiguration represents an declarative configuration of the VirtualServerStatus type for use
+// with apply.
+type VirtualServerStatusApplyConfiguration struct {
+	Conditions         []v1.ConditionApplyConfiguration               `json:"conditions,omitempty"`
+	Phase              *v1beta1.VirtualServerPhase                    `json:"phase,omitempty"`
+	Started            *metav1.ConditionStatus                        `json:"started,omitempty"`
+	ReadyReason        *string                                        `json:"readyReason,omitempty"`
+	ReadyMessage       *string                                        `json:"readyMessage,omitempty"`
+	ObservedGeneration *int64                                         `json:"observedGeneration,omitempty"`
+	Network            *VirtualServerNetworkStatusApplyConfiguration  `json:"network,omitempty"`
+	RootDisk           *VirtualServerRootDiskStatusApplyConfiguration `json:"rootDisk,omitempty"`
+}
+
+// VirtualServerStatusApplyConfiguration constructs an declarative configuration of the VirtualServerStatus type for use with
+// apply.
+func VirtualServerStatus() *VirtualServerStatusApplyConfiguration {
+	return &VirtualServerStatusApplyConfiguration{}
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *VirtualServerStatusApplyConfiguration) WithConditions(values ...*v1.ConditionApplyConfiguration) *VirtualServerStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *VirtualServerStatusApplyConfiguration) WithPhase(value v1beta1.VirtualServerPhase) *VirtualServerStatusApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithStarted sets the Started field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Started field is set to the value of the last call.
+func (b *VirtualServerStatusApplyConfiguration) WithStarted(value metav1.ConditionStatus) *VirtualServerStatusApplyConfiguration {
+	b.Started = &value
+	return b
+}
+
+// WithReadyReason sets the ReadyReason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReadyReason field is set to the value of the last call.
+func (b *VirtualServerStatusApplyConfiguration) WithReadyReason(value string) *VirtualServerStatusApplyConfiguration {
+	b.ReadyReason = &value
+	return b
+}
+
+// WithReadyMessage sets the ReadyMessage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReadyMessage field is set to the value of the last call.
+func (b *VirtualServerStatusApplyConfiguration) WithReadyMessage(value string) *VirtualServerStatusApplyConfiguration {
+	b.ReadyMessage = &value
+	return b
+}
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *VirtualServerStatusApplyConfiguration) WithObservedGeneration(value int64) *VirtualServerStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithNetwork sets the Network field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Network field is set to the value of the last call.
+func (b *VirtualServerStatusApplyConfiguration) WithNetwork(value *VirtualServerNetworkStatusApplyConfiguration) *VirtualServerStatusApplyConfiguration {
+	b.Network = value
+	return b
+}
+
+// WithRootDisk sets the RootDisk field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RootDisk field is set to the value of the last call.
+func (b *VirtualServerStatusApplyConfiguration) WithRootDisk(value *VirtualServerRootDiskStatusApplyConfiguration) *VirtualServerStatusApplyConfiguration {
+	b.RootDisk = value
+	return b
+}
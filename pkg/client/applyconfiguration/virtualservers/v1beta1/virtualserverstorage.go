@@ -0,0 +1,80 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// VirtualServerStorageApplyConfiguration represents an declarative configuration of the VirtualServerStorage type for use
+// with apply.
+type VirtualServerStorageApplyConfiguration struct {
+	Root            *VirtualServerStorageRootApplyConfiguration `json:"root,omitempty"`
+	AdditionalDisks []VirtualServerDisksApplyConfiguration      `json:"additionalDisks,omitempty"`
+	FileSystems     []VirtualServerFilesystemApplyConfiguration `json:"filesystems,omitempty"`
+	Swap            *resource.Quantity                          `json:"swap,omitempty"`
+}
+
+// VirtualServerStorageApplyConfiguration constructs an declarative configuration of the VirtualServerStorage type for use with
+// apply.
+func VirtualServerStorage() *VirtualServerStorageApplyConfiguration {
+	return &VirtualServerStorageApplyConfiguration{}
+}
+
+// WithRoot sets the Root field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Root field is set to the value of the last call.
+func (b *VirtualServerStorageApplyConfiguration) WithRoot(value *VirtualServerStorageRootApplyConfiguration) *VirtualServerStorageApplyConfiguration {
+	b.Root = value
+	return b
+}
+
+// WithAdditionalDisks adds the given value to the AdditionalDisks field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AdditionalDisks field.
+func (b *VirtualServerStorageApplyConfiguration) WithAdditionalDisks(values ...*VirtualServerDisksApplyConfiguration) *VirtualServerStorageApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAdditionalDisks")
+		}
+		b.AdditionalDisks = append(b.AdditionalDisks, *values[i])
+	}
+	return b
+}
+
+// WithFileSystems adds the given value to the FileSystems field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FileSystems field.
+func (b *VirtualServerStorageApplyConfiguration) WithFileSystems(values ...*VirtualServerFilesystemApplyConfiguration) *VirtualServerStorageApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFileSystems")
+		}
+		b.FileSystems = append(b.FileSystems, *values[i])
+	}
+	return b
+}
+
+// WithSwap sets the Swap field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Swap field is set to the value of the last call.
+func (b *VirtualServerStorageApplyConfiguration) WithSwap(value resource.Quantity) *VirtualServerStorageApplyConfiguration {
+	b.Swap = &value
+	return b
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// VirtualServerVPCApplyConfiguration represents an declarative configuration of the VirtualServerVPC type for use
+// with apply.
+type VirtualServerVPCApplyConfiguration struct {
+	Name       *string `json:"name,omitempty"`
+	MACAddress *string `json:"macAddress,omitempty"`
+}
+
+// VirtualServerVPCApplyConfiguration constructs an declarative configuration of the VirtualServerVPC type for use with
+// apply.
+func VirtualServerVPC() *VirtualServerVPCApplyConfiguration {
+	return &VirtualServerVPCApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *VirtualServerVPCApplyConfiguration) WithName(value string) *VirtualServerVPCApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithMACAddress sets the MACAddress field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MACAddress field is set to the value of the last call.
+func (b *VirtualServerVPCApplyConfiguration) WithMACAddress(value string) *VirtualServerVPCApplyConfiguration {
+	b.MACAddress = &value
+	return b
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2020.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+	"time"
+
+	v1beta1 "github.com/coreweave/virtual-server/api/v1beta1"
+	virtualserversv1beta1 "github.com/coreweave/virtual-server/pkg/client/applyconfiguration/virtualservers/v1beta1"
+	scheme "github.com/coreweave/virtual-server/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// VirtualServersGetter has a method to return a VirtualServerInterface.
+// A group's client should implement this interface.
+type VirtualServersGetter interface {
+	VirtualServers(namespace string) VirtualServerInterface
+}
+
+// VirtualServerInterface has methods to work with VirtualServer resources.
+type VirtualServerInterface interface {
+	Create(ctx context.Context, virtualServer *v1beta1.VirtualServer, opts v1.CreateOptions) (*v1beta1.VirtualServer, error)
+	Update(ctx context.Context, virtualServer *v1beta1.VirtualServer, opts v1.UpdateOptions) (*v1beta1.VirtualServer, error)
+	UpdateStatus(ctx context.Context, virtualServer *v1beta1.VirtualServer, opts v1.UpdateOptions) (*v1beta1.VirtualServer, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.VirtualServer, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1beta1.VirtualServerList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.VirtualServer, err error)
+	Apply(ctx context.Context, virtualServer *virtualserversv1beta1.VirtualServerApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.VirtualServer, err error)
+	ApplyStatus(ctx context.Context, virtualServer *virtualserversv1beta1.VirtualServerApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.VirtualServer, err error)
+	VirtualServerExpansion
+}
+
+// virtualServers implements VirtualServerInterface
+type virtualServers struct {
+	client rest.Interface
+	ns     string
+}
+
+// newVirtualServers returns a VirtualServers
+func newVirtualServers(c *VirtualserversV1beta1Client, namespace string) *virtualServers {
+	return &virtualServers{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the virtualServer, and returns the corresponding virtualServer object, and an error if there is any.
+func (c *virtualServers) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.VirtualServer, err error) {
+	result = &v1beta1.VirtualServer{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("virtualservers").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of VirtualServers that match those selectors.
+func (c *virtualServers) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.VirtualServerList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1beta1.VirtualServerList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("virtualservers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested virtualServers.
+func (c *virtualServers) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("virtualservers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a virtualServer and creates it.  Returns the server's representation of the virtualServer, and an error, if there is any.
+func (c *virtualServers) Create(ctx context.Context, virtualServer *v1beta1.VirtualServer, opts v1.CreateOptions) (result *v1beta1.VirtualServer, err error) {
+	result = &v1beta1.VirtualServer{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("virtualservers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(virtualServer).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a virtualServer and updates it. Returns the server's representation of the virtualServer, and an error, if there is any.
+func (c *virtualServers) Update(ctx context.Context, virtualServer *v1beta1.VirtualServer, opts v1.UpdateOptions) (result *v1beta1.VirtualServer, err error) {
+	result = &v1beta1.VirtualServer{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("virtualservers").
+		Name(virtualServer.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(virtualServer).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *virtualServers) UpdateStatus(ctx context.Context, virtualServer *v1beta1.VirtualServer, opts v1.UpdateOptions) (result *v1beta1.VirtualServer, err error) {
+	result = &v1beta1.VirtualServer{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("virtualservers").
+		Name(virtualServer.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(virtualServer).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the virtualServer and deletes it. Returns an error if one occurs.
+func (c *virtualServers) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("virtualservers").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *virtualServers) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("virtualservers").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched virtualServer.
+func (c *virtualServers) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.VirtualServer, err error) {
+	result = &v1beta1.VirtualServer{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("virtualservers").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied virtualServer.
+func (c *virtualServers) Apply(ctx context.Context, virtualServer *virtualserversv1beta1.VirtualServerApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.VirtualServer, err error) {
+	if virtualServer == nil {
+		return nil, fmt.Errorf("virtualServer provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(virtualServer)
+	if err != nil {
+		return nil, err
+	}
+	name := virtualServer.Name
+	if name == nil {
+		return nil, fmt.Errorf("virtualServer.Name must be provided to Apply")
+	}
+	result = &v1beta1.VirtualServer{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("virtualservers").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *virtualServers) ApplyStatus(ctx context.Context, virtualServer *virtualserversv1beta1.VirtualServerApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.VirtualServer, err error) {
+	if virtualServer == nil {
+		return nil, fmt.Errorf("virtualServer provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(virtualServer)
+	if err != nil {
+		return nil, err
+	}
+
+	name := virtualServer.Name
+	if name == nil {
+		return nil, fmt.Errorf("virtualServer.Name must be provided to Apply")
+	}
+
+	result = &v1beta1.VirtualServer{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("virtualservers").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,47 @@
+package externalversions_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	vsv1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	"github.com/coreweave/virtual-server/pkg/client/clientset/versioned/fake"
+	"github.com/coreweave/virtual-server/pkg/client/informers/externalversions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestVirtualServerInformer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := fake.NewSimpleClientset()
+	factory := externalversions.NewSharedInformerFactory(client, 0)
+	lister := factory.Virtualservers().V1alpha1().VirtualServers().Lister()
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+
+	vs := vsv1alpha1.NewVirtualServer("my-virtual-server", "default")
+	vs.SetOS(vsv1alpha1.VirtualServerOSTypeLinux)
+	if _, err := client.VirtualserversV1alpha1().VirtualServers("default").Create(ctx, vs, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		_, err := lister.VirtualServers("default").Get("my-virtual-server")
+		return err == nil, nil
+	})
+	if err != nil {
+		t.Fatalf("VirtualServer was not observed by the informer: %v", err)
+	}
+
+	list, err := lister.List(labels.Everything())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Spec.OS.Type != vsv1alpha1.VirtualServerOSTypeLinux {
+		t.Errorf("expected a single linux VirtualServer, got %+v", list)
+	}
+}
@@ -0,0 +1,89 @@
+package render
+
+import (
+	"fmt"
+
+	vsv1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	"github.com/coreweave/virtual-server/cloudinit"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CloudInitUserDataKey is the key of the cloud-init Secret holding the user data
+	CloudInitUserDataKey = cloudinit.UserDataKey
+	// CloudInitNetworkDataKey is the key of the cloud-init Secret holding the network data
+	CloudInitNetworkDataKey = cloudinit.NetworkDataKey
+)
+
+// Budget is the size of the cloud-init Secret of a VirtualServer, as counted against corev1.MaxSecretSize
+type Budget = cloudinit.Budget
+
+// CloudInitBudget returns the size of the cloud-init Secret rendered for the VirtualServer, including the generated
+// users, filesystem mounts and headers. The options must provide the Secrets referenced by the VirtualServer users, as for Render.
+// Unlike Render, the VirtualServer is not validated, so that the budget of a VirtualServer exceeding the limit may be reported.
+func CloudInitBudget(vs *vsv1alpha1.VirtualServer, opts ...Option) (*Budget, error) {
+	o := newOptions(opts)
+	vs = vs.DeepCopy()
+	vs.Default()
+	data, err := vs.CloudInitSecret(o.secretValueFunc(vs))
+	if err != nil {
+		return nil, err
+	}
+	return &data.Budget, nil
+}
+
+// cloudInitSecret returns the Secret holding the cloud-init user data and network data,
+// or nil if the VirtualServer has no users, filesystem mounts, cloud-init or network data.
+// An error is returned if the Secret exceeds corev1.MaxSecretSize even with compressed user data.
+func cloudInitSecret(vs *vsv1alpha1.VirtualServer, o *options) (*corev1.Secret, error) {
+	data, err := vs.CloudInitSecret(o.secretValueFunc(vs))
+	if err != nil {
+		return nil, err
+	}
+	if err := data.Budget.Err(); err != nil {
+		return nil, err
+	}
+	if data.IsEmpty() {
+		return nil, nil
+	}
+	return &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "Secret"},
+		ObjectMeta: objectMeta(vs, CloudInitSecretName(vs)),
+		Type:       corev1.SecretTypeOpaque,
+		StringData: data.StringData,
+		Data:       data.Data,
+	}, nil
+}
+
+// secretValueFunc returns the resolver of the Secret references of the VirtualServer users
+func (o *options) secretValueFunc(vs *vsv1alpha1.VirtualServer) vsv1alpha1.SecretValueFunc {
+	return func(selector *corev1.SecretKeySelector) (string, error) {
+		return o.secretValue(vs, selector)
+	}
+}
+
+// secretValue returns the value of the selected Secret key, or an empty string if an optional Secret or key is missing
+func (o *options) secretValue(vs *vsv1alpha1.VirtualServer, selector *corev1.SecretKeySelector) (string, error) {
+	optional := selector.Optional != nil && *selector.Optional
+	secret, ok := o.secrets[vs.Namespace+"/"+selector.Name]
+	if !ok {
+		secret, ok = o.secrets["/"+selector.Name]
+	}
+	if !ok {
+		if optional {
+			return "", nil
+		}
+		return "", fmt.Errorf("Secret %s was not provided", selector.Name)
+	}
+	if value, ok := secret.StringData[selector.Key]; ok {
+		return value, nil
+	}
+	if value, ok := secret.Data[selector.Key]; ok {
+		return string(value), nil
+	}
+	if optional {
+		return "", nil
+	}
+	return "", fmt.Errorf("Secret %s has no key %s", selector.Name, selector.Key)
+}
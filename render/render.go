@@ -0,0 +1,175 @@
+// Package render computes the KubeVirt VirtualMachine, DataVolumes, Services and cloud-init Secret
+// that a VirtualServer implies, without a running operator or cluster.
+//
+// The rendered objects may be diffed, reviewed or golden-tested offline.
+// They are owned by the VirtualServer and carry the VirtualServerNameLabel, so they can be matched to their VirtualServer.
+// Floating IPs and direct attach load balancer IPs are not rendered as they are provisioned at runtime.
+package render
+
+import (
+	"fmt"
+
+	vsv1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kvv1 "kubevirt.io/api/core/v1"
+	cdiv1beta "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// VirtualServerNameLabel is set on every rendered object, and on the VirtualMachineInstance to select it from the Services
+	VirtualServerNameLabel = "vs.coreweave.com/name"
+	// RegionNodeLabel is the node label the VirtualServer region is scheduled on
+	RegionNodeLabel = "topology.kubernetes.io/region"
+	// GPUClassNodeLabel is the node label the VirtualServer GPU type is scheduled on
+	GPUClassNodeLabel = "gpu.nvidia.com/class"
+	// CPUTypeNodeLabel is the node label the VirtualServer CPU type is scheduled on
+	CPUTypeNodeLabel = "node.coreweave.cloud/cpu"
+	// GPUDeviceName is the device plugin resource requested for each GPU
+	GPUDeviceName = "nvidia.com/gpu"
+)
+
+// Workload is the set of objects created for a VirtualServer
+type Workload struct {
+	VirtualMachine *kvv1.VirtualMachine
+	// RootDataVolume is nil when the root filesystem is ephemeral
+	RootDataVolume *cdiv1beta.DataVolume
+	// DataVolumes are the DataVolumes of the additional disks with a DataVolume template
+	DataVolumes []*cdiv1beta.DataVolume
+	// Services contains the TCP, UDP and headless Services, in that order, when they are required
+	Services []*corev1.Service
+	// CloudInitSecret is nil when the VirtualServer has no users or cloud-init.
+	// It holds the Ignition config when the provisioner is ignition.
+	CloudInitSecret *corev1.Secret
+	// SysprepSecret is nil when the VirtualServer has no Windows configuration
+	SysprepSecret *corev1.Secret
+}
+
+// Objects returns all rendered objects, in the order they are created
+func (w *Workload) Objects() []client.Object {
+	var objs []client.Object
+	if w.CloudInitSecret != nil {
+		objs = append(objs, w.CloudInitSecret)
+	}
+	if w.SysprepSecret != nil {
+		objs = append(objs, w.SysprepSecret)
+	}
+	if w.RootDataVolume != nil {
+		objs = append(objs, w.RootDataVolume)
+	}
+	for _, dv := range w.DataVolumes {
+		objs = append(objs, dv)
+	}
+	for _, svc := range w.Services {
+		objs = append(objs, svc)
+	}
+	return append(objs, w.VirtualMachine)
+}
+
+// Option configures rendering
+type Option func(*options)
+
+type options struct {
+	// secrets are the Secrets referenced by the VirtualServer, by name
+	secrets map[string]*corev1.Secret
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{secrets: map[string]*corev1.Secret{}}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithSecrets provides the Secrets referenced by the password and SSH public key Secret references of the VirtualServer users.
+// Secrets in another namespace than the VirtualServer are ignored; Secrets without a namespace are assumed to be in it.
+// Rendering fails if a referenced Secret or key is not provided, unless the reference is optional.
+func WithSecrets(secrets ...*corev1.Secret) Option {
+	return func(o *options) {
+		for _, secret := range secrets {
+			o.secrets[secret.Namespace+"/"+secret.Name] = secret
+		}
+	}
+}
+
+// Render returns the objects implied by the VirtualServer.
+// The VirtualServer is defaulted and validated first and is not modified.
+func Render(vs *vsv1alpha1.VirtualServer, opts ...Option) (*Workload, error) {
+	o := newOptions(opts)
+	vs = vs.DeepCopy()
+	vs.Default()
+	if errs := vs.Validate(); len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+
+	secret, err := cloudInitSecret(vs, o)
+	if err != nil {
+		return nil, fmt.Errorf("could not render cloud-init: %w", err)
+	}
+	sysprep, err := sysprepSecret(vs, o)
+	if err != nil {
+		return nil, fmt.Errorf("could not render sysprep: %w", err)
+	}
+	return &Workload{
+		VirtualMachine:  virtualMachine(vs, secret != nil, sysprep != nil),
+		RootDataVolume:  rootDataVolume(vs),
+		DataVolumes:     diskDataVolumes(vs),
+		Services:        services(vs),
+		CloudInitSecret: secret,
+		SysprepSecret:   sysprep,
+	}, nil
+}
+
+// VirtualMachineName returns the name of the VirtualMachine created for the VirtualServer
+func VirtualMachineName(vs *vsv1alpha1.VirtualServer) string {
+	return vs.Name
+}
+
+// RootDataVolumeName returns the name of the DataVolume backing the VirtualServer root filesystem
+func RootDataVolumeName(vs *vsv1alpha1.VirtualServer) string {
+	return vs.Name
+}
+
+// CloudInitSecretName returns the name of the Secret holding the VirtualServer cloud-init user data
+func CloudInitSecretName(vs *vsv1alpha1.VirtualServer) string {
+	return vs.Name + "-cloudinit"
+}
+
+// SysprepSecretName returns the name of the Secret holding the VirtualServer Windows answer file
+func SysprepSecretName(vs *vsv1alpha1.VirtualServer) string {
+	return vs.Name + "-sysprep"
+}
+
+// TCPServiceName returns the name of the Service exposing the VirtualServer TCP ports
+func TCPServiceName(vs *vsv1alpha1.VirtualServer) string {
+	return vs.Name + "-tcp"
+}
+
+// UDPServiceName returns the name of the Service exposing the VirtualServer UDP ports
+func UDPServiceName(vs *vsv1alpha1.VirtualServer) string {
+	return vs.Name + "-udp"
+}
+
+// HeadlessServiceName returns the name of the headless Service of the VirtualServer.
+// It is also used as the subdomain of the VirtualMachineInstance.
+func HeadlessServiceName(vs *vsv1alpha1.VirtualServer) string {
+	return vs.Name
+}
+
+// objectMeta returns the metadata of an object owned by the VirtualServer
+func objectMeta(vs *vsv1alpha1.VirtualServer, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      name,
+		Namespace: vs.Namespace,
+		Labels:    labels(vs),
+		OwnerReferences: []metav1.OwnerReference{
+			*metav1.NewControllerRef(vs, vsv1alpha1.GroupVersion.WithKind("VirtualServer")),
+		},
+	}
+}
+
+func labels(vs *vsv1alpha1.VirtualServer) map[string]string {
+	return map[string]string{VirtualServerNameLabel: vs.Name}
+}
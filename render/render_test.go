@@ -0,0 +1,431 @@
+package render_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"flag"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	vsv1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	"github.com/coreweave/virtual-server/cloudinit"
+	"github.com/coreweave/virtual-server/ignition"
+	"github.com/coreweave/virtual-server/render"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kvv1 "kubevirt.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func newLinuxGPUServer() *vsv1alpha1.VirtualServer {
+	vs := vsv1alpha1.NewVirtualServer("my-virtual-server", "default")
+	vs.UID = types.UID("00000000-0000-0000-0000-000000000000")
+	vs.SetRegion("ORD1")
+	vs.SetOS(vsv1alpha1.VirtualServerOSTypeLinux)
+	vs.SetGPUType("Quadro_RTX_4000")
+	vs.SetGPUCount(2)
+	vs.SetCPUCount(4)
+	vs.SetMemory("16Gi")
+	vs.ConfigureStorageRootWithPVCSource(vsv1alpha1.VirtualServerStorageRootPVCSource{
+		Size:             "40Gi",
+		PVCName:          "ubuntu2004-docker-master-20210601-ord1",
+		PVCNamespace:     "vd-images",
+		StorageClassName: "block-nvme-ord1",
+		VolumeMode:       corev1.PersistentVolumeBlock,
+		AccessMode:       corev1.ReadWriteOnce,
+	})
+	vs.AddPVCDisk("data", "my-data", false)
+	vs.SetDiskSerial("data", "data-serial")
+	vs.AddDataVolumeDiskWithHTTPSource("dataset", vsv1alpha1.VirtualServerStorageRootHTTPSource{
+		Size:             "100Gi",
+		ImageUrl:         "https://example.com/dataset.qcow2",
+		StorageClassName: "block-hdd-ord1",
+	})
+	vs.AddContainerDisk("tools", "quay.io/containerdisks/tools:latest")
+	vs.AddConfigMapDisk("config", "my-config")
+	vs.AddPVCFileSystem("shared", "my-shared", false)
+	mountPoint := "/mnt/shared"
+	vs.Spec.Storage.FileSystems[0].Mountpoint = &mountPoint
+	vs.AddSwap("4Gi")
+	vs.AddUser(vsv1alpha1.VirtualServerUser{
+		Username:     "myuser",
+		Password:     "mypassword",
+		SSHPublicKey: "ssh-ed25519 AAAA one\nssh-ed25519 AAAA two",
+	})
+	lockPassword := true
+	vs.AddUser(vsv1alpha1.VirtualServerUser{
+		Username:      "deploy",
+		Password:      "deploypassword",
+		SSHPublicKeys: []string{"ssh-ed25519 AAAA deploy"},
+		Groups:        []string{"docker", "adm"},
+		Sudo:          []string{"ALL=(ALL) NOPASSWD:/usr/bin/systemctl"},
+		Shell:         "/bin/sh",
+		LockPassword:  &lockPassword,
+	})
+	vs.AddCloudInit("packages:\n- curl\n")
+	vs.EnablePublicIP(true)
+	vs.ExposeTCPPorts([]int32{22, 443})
+	vs.ExposeUDPPort(4172)
+	vs.InitializeRunning(true)
+	return vs
+}
+
+func newWindowsServer() *vsv1alpha1.VirtualServer {
+	vs := vsv1alpha1.NewVirtualServer("my-windows-server", "default")
+	vs.UID = types.UID("00000000-0000-0000-0000-000000000001")
+	vs.SetOS(vsv1alpha1.VirtualServerOSTypeWindows)
+	vs.EnableUEFIBoot(true)
+	vs.SetCPUType("amd-epyc-rome")
+	vs.ConfigureStorageRootWithPVCSource(vsv1alpha1.VirtualServerStorageRootPVCSource{
+		Size:             "80Gi",
+		PVCName:          "winserver2019std",
+		StorageClassName: "block-nvme-ord1",
+	})
+	vs.Spec.Storage.Root.Ephemeral = true
+	vs.SetHeadless(true)
+	vs.AddVPC("my-vpc")
+	vs.SetMacAddress("02:00:00:00:00:01")
+	vs.AddVPC("my-storage-vpc")
+	vs.SetVPCMacAddress("my-storage-vpc", "02:00:00:00:00:02")
+	vs.SetNetworkConfig(&cloudinit.NetworkConfig{Ethernets: map[string]cloudinit.Ethernet{
+		"storage": cloudinit.StaticEthernet("02:00:00:00:00:02", []string{"10.0.0.10/24"}, ""),
+	}})
+	vs.SetLocalHostname("my-windows-host")
+	vs.SetWindowsComputerName("MY-WINDOWS")
+	vs.SetWindowsTimeZone("Pacific Standard Time")
+	vs.SetWindowsLocale("en-US")
+	vs.EnableWindowsRDP(true)
+	vs.AddWindowsFirstLogonCommand(`powershell -Command "Set-NetFirewallProfile -Profile Domain -Enabled False"`)
+	vs.Spec.Network.DisableK8sNetworking = true
+	vs.RunStrategy(kvv1.RunStrategyManual)
+	vs.AddUser(vsv1alpha1.VirtualServerUser{
+		Username:      "myadmin",
+		Password:      "mypassword",
+		SSHPublicKeys: []string{"ssh-ed25519 AAAA one"},
+		Administrator: true,
+	})
+	return vs
+}
+
+func newFlatcarServer() *vsv1alpha1.VirtualServer {
+	vs := vsv1alpha1.NewVirtualServer("my-flatcar-server", "default")
+	vs.UID = types.UID("00000000-0000-0000-0000-000000000002")
+	vs.SetRegion("ORD1")
+	vs.SetOS(vsv1alpha1.VirtualServerOSTypeLinux)
+	vs.SetProvisioner(vsv1alpha1.VirtualServerProvisionerIgnition)
+	vs.SetCPUCount(4)
+	vs.SetMemory("8Gi")
+	vs.ConfigureStorageRootWithPVCSource(vsv1alpha1.VirtualServerStorageRootPVCSource{
+		Size:             "40Gi",
+		PVCName:          "flatcar-stable-ord1",
+		StorageClassName: "block-nvme-ord1",
+	})
+	vs.AddPVCFileSystem("shared", "my-shared", false)
+	mountPoint := "/mnt/my-shared"
+	vs.Spec.Storage.FileSystems[0].Mountpoint = &mountPoint
+	vs.AddUser(vsv1alpha1.VirtualServerUser{
+		Username:       "core",
+		HashedPassword: "$6$salt$hash",
+		SSHPublicKeys:  []string{"ssh-ed25519 AAAA core"},
+	})
+	vs.SetLocalHostname("my-flatcar-host")
+	cfg := &ignition.Config{}
+	cfg.AddUnit(ignition.NewUnit("hello.service", "[Service]\nType=oneshot\nExecStart=/usr/bin/echo hello\n\n[Install]\nWantedBy=multi-user.target\n"))
+	vs.AddIgnitionConfig(cfg)
+	vs.ExposeTCPPorts([]int32{22})
+	return vs
+}
+
+func TestRender(t *testing.T) {
+	for name, vs := range map[string]*vsv1alpha1.VirtualServer{
+		"linux-gpu": newLinuxGPUServer(),
+		"windows":   newWindowsServer(),
+		"flatcar":   newFlatcarServer(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			orig := vs.DeepCopy()
+			w, err := render.Render(vs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equalJSON(t, vs, orig) {
+				t.Error("expected Render not to modify the VirtualServer")
+			}
+
+			var got bytes.Buffer
+			for _, obj := range w.Objects() {
+				out, err := yaml.Marshal(obj)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got.WriteString("---\n")
+				got.Write(out)
+			}
+
+			golden := filepath.Join("testdata", name+".yaml")
+			if *update {
+				if err := os.WriteFile(golden, got.Bytes(), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got.Bytes(), want) {
+				t.Errorf("rendered objects differ from %s, run go test ./render -update to update it:\n%s", golden, got.String())
+			}
+		})
+	}
+}
+
+func TestRenderInvalid(t *testing.T) {
+	vs := newLinuxGPUServer()
+	vs.DirectAttachLoadBalancerIP(true)
+	if _, err := render.Render(vs); err == nil {
+		t.Error("expected an error rendering a VirtualServer with ports and directAttachLoadBalancerIP")
+	}
+}
+
+func equalJSON(t *testing.T, a, b interface{}) bool {
+	t.Helper()
+	aj, err := yaml.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bj, err := yaml.Marshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bytes.Equal(aj, bj)
+}
+
+func TestRenderSecrets(t *testing.T) {
+	vs := vsv1alpha1.NewVirtualServer("my-virtual-server", "default")
+	vs.SetOS(vsv1alpha1.VirtualServerOSTypeLinux)
+	vs.ConfigureStorageRootWithPVCSource(vsv1alpha1.VirtualServerStorageRootPVCSource{
+		Size:             "40Gi",
+		PVCName:          "ubuntu2004-docker-master-20210601-ord1",
+		StorageClassName: "block-nvme-ord1",
+	})
+	user := vsv1alpha1.VirtualServerUser{Username: "myuser"}
+	user.SetPasswordSecretRef("my-credentials", "password")
+	user.SetSSHPublicKeySecretRef("my-credentials", "authorized_keys")
+	vs.AddUser(user)
+	hashed := vsv1alpha1.VirtualServerUser{Username: "hashed"}
+	if err := hashed.SetHashedPassword("$6$salt$hash"); err != nil {
+		t.Fatal(err)
+	}
+	vs.AddUser(hashed)
+
+	if _, err := render.Render(vs); err == nil {
+		t.Fatal("expected an error rendering without the referenced Secret")
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-credentials", Namespace: "default"},
+		Data: map[string][]byte{
+			"password":        []byte("secretpassword"),
+			"authorized_keys": []byte("ssh-ed25519 AAAA one\nssh-ed25519 AAAA two\n"),
+		},
+	}
+	w, err := render.Render(vs, render.WithSecrets(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	userData := w.CloudInitSecret.StringData[render.CloudInitUserDataKey]
+	for _, want := range []string{
+		"plain_text_passwd: secretpassword",
+		"- ssh-ed25519 AAAA one\n",
+		"- ssh-ed25519 AAAA two\n",
+		"hashed_passwd: $6$salt$hash",
+		"ssh_pwauth: true",
+	} {
+		if !strings.Contains(userData, want) {
+			t.Errorf("expected the user data to contain %q:\n%s", want, userData)
+		}
+	}
+}
+
+func TestRenderIgnitionSudoers(t *testing.T) {
+	vs := newFlatcarServer()
+	vs.AddUser(vsv1alpha1.VirtualServerUser{Username: "first.last", SSHPublicKeys: []string{"ssh-ed25519 AAAA first"}})
+	w, err := render.Render(vs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userData := w.CloudInitSecret.StringData[render.CloudInitUserDataKey]
+	// sudo skips /etc/sudoers.d files whose names contain a '.'
+	if !strings.Contains(userData, `"path":"/etc/sudoers.d/first_last"`) || strings.Contains(userData, "/etc/sudoers.d/first.last") {
+		t.Errorf("expected the sudoers file of first.last to be named first_last:\n%s", userData)
+	}
+}
+
+func TestRenderSysprep(t *testing.T) {
+	vs := newWindowsServer()
+	vs.SetWindowsAdministratorPasswordSecretRef("my-credentials", "password")
+	if _, err := render.Render(vs); err == nil {
+		t.Fatal("expected an error rendering without the referenced Secret")
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-credentials", Namespace: "default"},
+		StringData: map[string]string{"password": "secretpassword"},
+	}
+	w, err := render.Render(vs, render.WithSecrets(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	answerFile := w.SysprepSecret.StringData[render.SysprepAnswerFileKey]
+	for _, want := range []string{
+		"<ComputerName>MY-WINDOWS</ComputerName>",
+		"<AdministratorPassword>",
+		"<PlainText>false</PlainText>",
+	} {
+		if !strings.Contains(answerFile, want) {
+			t.Errorf("expected the answer file to contain %q:\n%s", want, answerFile)
+		}
+	}
+	if strings.Contains(answerFile, "secretpassword") {
+		t.Errorf("expected the Administrator password not to be stored in plain text:\n%s", answerFile)
+	}
+}
+
+func TestRenderMultiPart(t *testing.T) {
+	vs := newLinuxGPUServer()
+	if err := vs.AddCloudInitParts(
+		cloudinit.Part{ContentType: cloudinit.ContentTypeCloudConfig, Content: "#cloud-config\nusers:\n- name: extra\nruncmd:\n- [touch, /tmp/first]\n"},
+		cloudinit.Part{ContentType: cloudinit.ContentTypeShellScript, Filename: "setup.sh", Content: "#!/bin/bash\necho hello\n"},
+		cloudinit.Part{ContentType: cloudinit.ContentTypeCloudConfig, Content: "#cloud-config\nruncmd:\n- [touch, /tmp/second]\n"},
+	); err != nil {
+		t.Fatal(err)
+	}
+	w, err := render.Render(vs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts, err := cloudinit.ParseUserData(w.CloudInitSecret.StringData[render.CloudInitUserDataKey])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parts) != 2 || parts[0].ContentType != cloudinit.ContentTypeCloudConfig || parts[1].Filename != "setup.sh" {
+		t.Fatalf("expected the merged cloud-config followed by the script, got %+v", parts)
+	}
+
+	var config struct {
+		Users []struct {
+			Name string `json:"name"`
+		} `json:"users"`
+		RunCmd [][]string `json:"runcmd"`
+	}
+	if err := yaml.Unmarshal([]byte(parts[0].Content), &config); err != nil {
+		t.Fatal(err)
+	}
+	var users []string
+	for _, user := range config.Users {
+		users = append(users, user.Name)
+	}
+	if want := []string{"myuser", "deploy", "extra"}; !reflect.DeepEqual(users, want) {
+		t.Errorf("expected users %q, got %q", want, users)
+	}
+	if want := [][]string{{"touch", "/tmp/second"}}; !reflect.DeepEqual(config.RunCmd, want) {
+		t.Errorf("expected the last runcmd to override the previous ones, got %q", config.RunCmd)
+	}
+}
+
+func TestRenderMergeHow(t *testing.T) {
+	vs := newLinuxGPUServer()
+	if err := vs.AddCloudInitParts(
+		cloudinit.Part{ContentType: cloudinit.ContentTypeCloudConfig, Content: "#cloud-config\nruncmd:\n- [touch, /tmp/first]\n"},
+		cloudinit.Part{ContentType: cloudinit.ContentTypeCloudConfig, Content: "#cloud-config\nmerge_how: list(append)+dict(recurse_list)+str()\nusers:\n- name: extra\nruncmd:\n- [touch, /tmp/second]\n"},
+	); err != nil {
+		t.Fatal(err)
+	}
+	w, err := render.Render(vs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config struct {
+		Users []struct {
+			Name string `json:"name"`
+		} `json:"users"`
+		RunCmd   [][]string  `json:"runcmd"`
+		MergeHow interface{} `json:"merge_how"`
+	}
+	if err := yaml.Unmarshal([]byte(w.CloudInitSecret.StringData[render.CloudInitUserDataKey]), &config); err != nil {
+		t.Fatal(err)
+	}
+	var users []string
+	for _, user := range config.Users {
+		users = append(users, user.Name)
+	}
+	if want := []string{"myuser", "deploy", "extra"}; !reflect.DeepEqual(users, want) {
+		t.Errorf("expected users %q, got %q", want, users)
+	}
+	if want := [][]string{{"touch", "/tmp/first"}, {"touch", "/tmp/second"}}; !reflect.DeepEqual(config.RunCmd, want) {
+		t.Errorf("expected merge_how to append runcmd, got %q", config.RunCmd)
+	}
+	if config.MergeHow != nil {
+		t.Errorf("expected merge_how not to be rendered, got %v", config.MergeHow)
+	}
+}
+
+func TestCloudInitBudget(t *testing.T) {
+	vs := newLinuxGPUServer()
+	w, err := render.Render(vs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	budget, err := render.CloudInitBudget(vs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userData := w.CloudInitSecret.StringData[render.CloudInitUserDataKey]
+	if want := len(userData); budget.Size != want || budget.UserData != len(userData) || budget.Compressed {
+		t.Errorf("expected an uncompressed Secret of %d bytes, got %+v", want, budget)
+	}
+	if budget.Remaining != corev1.MaxSecretSize-budget.Size {
+		t.Errorf("expected %d remaining bytes, got %d", corev1.MaxSecretSize-budget.Size, budget.Remaining)
+	}
+
+	// Compressible user data exceeding the limit is gzip compressed
+	vs.Spec.CloudInit = "write_files:\n- path: /etc/large\n  content: " + strings.Repeat("a", corev1.MaxSecretSize) + "\n"
+	w, err = render.Render(vs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if budget, err = render.CloudInitBudget(vs); err != nil || !budget.Compressed || budget.Remaining < 0 {
+		t.Fatalf("expected compressed user data fitting in the Secret, got %+v, %v", budget, err)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(w.CloudInitSecret.Data[render.CloudInitUserDataKey]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decompressed, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(decompressed), "#cloud-config\n") || !strings.Contains(string(decompressed), strings.Repeat("a", 100)) {
+		t.Errorf("expected the decompressed user data to be the cloud-config, got %.100s", decompressed)
+	}
+
+	// Incompressible user data exceeding the limit is an error
+	random := make([]byte, corev1.MaxSecretSize+1024)
+	rand.New(rand.NewSource(1)).Read(random)
+	vs.Spec.CloudInit = "write_files:\n- path: /etc/large\n  encoding: b64\n  content: " + base64.StdEncoding.EncodeToString(random) + "\n"
+	if budget, err = render.CloudInitBudget(vs); err != nil || budget.Remaining >= 0 {
+		t.Errorf("expected the budget to be exceeded, got %+v, %v", budget, err)
+	}
+	if _, err := render.Render(vs); err == nil {
+		t.Error("expected an error rendering a cloud-init Secret exceeding the limit")
+	}
+}
@@ -0,0 +1,64 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	vsv1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// AllowSharedIPAnnotation lets the TCP and UDP Services of a VirtualServer share a load balancer IP
+const AllowSharedIPAnnotation = "metallb.universe.tf/allow-shared-ip"
+
+// services returns the Services exposing the VirtualServer ports, and its headless Service
+func services(vs *vsv1alpha1.VirtualServer) []*corev1.Service {
+	var svcs []*corev1.Service
+	network := vs.Spec.Network
+	if len(network.TCP.Ports) > 0 {
+		svcs = append(svcs, portService(vs, TCPServiceName(vs), corev1.ProtocolTCP, network.TCP.Ports))
+	}
+	if len(network.UDP.Ports) > 0 {
+		svcs = append(svcs, portService(vs, UDPServiceName(vs), corev1.ProtocolUDP, network.UDP.Ports))
+	}
+	if network.Headless {
+		svc := service(vs, HeadlessServiceName(vs))
+		svc.Spec.ClusterIP = corev1.ClusterIPNone
+		svc.Spec.PublishNotReadyAddresses = true
+		svcs = append(svcs, svc)
+	}
+	return svcs
+}
+
+// portService returns a Service exposing the ports with the protocol.
+// The Service is a LoadBalancer if the VirtualServer is public, otherwise it is only reachable within the cluster.
+func portService(vs *vsv1alpha1.VirtualServer, name string, protocol corev1.Protocol, ports []vsv1alpha1.Port) *corev1.Service {
+	svc := service(vs, name)
+	svc.Spec.Type = corev1.ServiceTypeClusterIP
+	if vs.Spec.Network.Public {
+		svc.Spec.Type = corev1.ServiceTypeLoadBalancer
+		svc.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeLocal
+		svc.Annotations = map[string]string{AllowSharedIPAnnotation: vs.Name}
+	}
+	for _, port := range ports {
+		svc.Spec.Ports = append(svc.Spec.Ports, corev1.ServicePort{
+			Name:       fmt.Sprintf("%s-%d", strings.ToLower(string(protocol)), port),
+			Protocol:   protocol,
+			Port:       int32(port),
+			TargetPort: intstr.FromInt(int(port)),
+		})
+	}
+	return svc
+}
+
+func service(vs *vsv1alpha1.VirtualServer, name string) *corev1.Service {
+	return &corev1.Service{
+		TypeMeta:   metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "Service"},
+		ObjectMeta: objectMeta(vs, name),
+		Spec: corev1.ServiceSpec{
+			Selector: labels(vs),
+		},
+	}
+}
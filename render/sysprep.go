@@ -0,0 +1,38 @@
+package render
+
+import (
+	"fmt"
+
+	vsv1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SysprepAnswerFileKey is the key of the sysprep Secret holding the answer file, as expected by KubeVirt
+const SysprepAnswerFileKey = "autounattend.xml"
+
+// sysprepSecret returns the Secret holding the Windows answer file, or nil if the VirtualServer has no Windows configuration
+func sysprepSecret(vs *vsv1alpha1.VirtualServer, o *options) (*corev1.Secret, error) {
+	windows := vs.Spec.OS.Windows
+	if windows == nil {
+		return nil, nil
+	}
+	password := ""
+	if windows.AdministratorPasswordSecretRef != nil {
+		value, err := o.secretValue(vs, windows.AdministratorPasswordSecretRef)
+		if err != nil {
+			return nil, fmt.Errorf("Administrator password: %w", err)
+		}
+		password = value
+	}
+	answerFile, err := windows.UnattendConfig(password).Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "Secret"},
+		ObjectMeta: objectMeta(vs, SysprepSecretName(vs)),
+		Type:       corev1.SecretTypeOpaque,
+		StringData: map[string]string{SysprepAnswerFileKey: answerFile},
+	}, nil
+}
@@ -0,0 +1,321 @@
+package render
+
+import (
+	"fmt"
+
+	vsv1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kvv1 "kubevirt.io/api/core/v1"
+	cdiv1beta "kubevirt.io/containerized-data-importer-api/pkg/apis/core/v1beta1"
+)
+
+const (
+	// SwapDiskName is the name of the disk and volume backing the VirtualServer swap
+	SwapDiskName = "swap"
+	// CloudInitDiskName is the name of the disk and volume holding the cloud-init user data
+	CloudInitDiskName = "cloudinitdisk"
+	// SysprepDiskName is the name of the CD-ROM and volume holding the Windows answer file
+	SysprepDiskName = "sysprep"
+	// PodNetworkName is the name of the kubernetes pod network and interface
+	PodNetworkName = "default"
+)
+
+// rootDataVolume returns the DataVolume backing the root filesystem, or nil if the root filesystem is ephemeral
+func rootDataVolume(vs *vsv1alpha1.VirtualServer) *cdiv1beta.DataVolume {
+	root := vs.Spec.Storage.Root
+	if root.Ephemeral {
+		return nil
+	}
+	return dataVolume(vs, RootDataVolumeName(vs), &vsv1alpha1.VirtualServerDataVolumeTemplate{
+		Size:             root.Size,
+		Source:           root.Source,
+		StorageClassName: root.StorageClassName,
+		VolumeMode:       root.VolumeMode,
+		AccessMode:       root.AccessMode,
+	})
+}
+
+// diskDataVolumes returns the DataVolumes of the additional disks with a DataVolume template, in order
+func diskDataVolumes(vs *vsv1alpha1.VirtualServer) []*cdiv1beta.DataVolume {
+	var dvs []*cdiv1beta.DataVolume
+	for _, disk := range vs.Spec.Storage.AdditionalDisks {
+		if disk.DataVolumeTemplate != nil {
+			dvs = append(dvs, dataVolume(vs, disk.Spec.DataVolume.Name, disk.DataVolumeTemplate))
+		}
+	}
+	return dvs
+}
+
+func dataVolume(vs *vsv1alpha1.VirtualServer, name string, template *vsv1alpha1.VirtualServerDataVolumeTemplate) *cdiv1beta.DataVolume {
+	volumeMode := template.VolumeMode
+	storageClassName := template.StorageClassName
+	return &cdiv1beta.DataVolume{
+		TypeMeta:   metav1.TypeMeta{APIVersion: cdiv1beta.SchemeGroupVersion.String(), Kind: "DataVolume"},
+		ObjectMeta: objectMeta(vs, name),
+		Spec: cdiv1beta.DataVolumeSpec{
+			Source: template.Source.DeepCopy(),
+			PVC: &corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{template.AccessMode},
+				VolumeMode:  &volumeMode,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: template.Size},
+				},
+				StorageClassName: &storageClassName,
+			},
+		},
+	}
+}
+
+// virtualMachine returns the VirtualMachine of the VirtualServer.
+// withCloudInit attaches the cloud-init Secret as a NoCloud disk, or a config drive for Ignition, and withSysprep the sysprep Secret as a CD-ROM.
+func virtualMachine(vs *vsv1alpha1.VirtualServer, withCloudInit bool, withSysprep bool) *kvv1.VirtualMachine {
+	spec := vs.Spec
+	memory := spec.Resources.Memory.DeepCopy()
+	runStrategy := kvv1.RunStrategyHalted
+	if spec.RunStrategy != nil {
+		runStrategy = *spec.RunStrategy
+	} else if spec.InitializeRunning {
+		runStrategy = kvv1.RunStrategyAlways
+	}
+
+	vmi := kvv1.VirtualMachineInstanceSpec{
+		Domain: kvv1.DomainSpec{
+			Resources: kvv1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    *resource.NewQuantity(int64(spec.Resources.CPU.Count), resource.DecimalSI),
+					corev1.ResourceMemory: spec.Resources.Memory,
+				},
+			},
+			CPU:    &kvv1.CPU{Cores: spec.Resources.CPU.Count},
+			Memory: &kvv1.Memory{Guest: &memory},
+			Devices: kvv1.Devices{
+				UseVirtioTransitional: spec.UseVirtioTransitional,
+			},
+		},
+		Affinity:                      affinity(vs),
+		TerminationGracePeriodSeconds: spec.TerminationGracePeriodSeconds,
+		LivenessProbe:                 spec.LivenessProbe.DeepCopy(),
+		ReadinessProbe:                spec.ReadinessProbe.DeepCopy(),
+		DNSConfig:                     spec.Network.DNSConfig.DeepCopy(),
+	}
+	if spec.Network.DNSPolicy != nil {
+		vmi.DNSPolicy = *spec.Network.DNSPolicy
+	}
+	if spec.Firmware.UUID != "" || spec.Firmware.Serial != "" || spec.OS.EnableUEFIBoot {
+		vmi.Domain.Firmware = &kvv1.Firmware{
+			UUID:   spec.Firmware.UUID,
+			Serial: spec.Firmware.Serial,
+		}
+		if spec.OS.EnableUEFIBoot {
+			secureBoot := false
+			vmi.Domain.Firmware.Bootloader = &kvv1.Bootloader{EFI: &kvv1.EFI{SecureBoot: &secureBoot}}
+		}
+	}
+	if spec.OS.Type == vsv1alpha1.VirtualServerOSTypeWindows {
+		vmi.Domain.Features = &kvv1.Features{
+			ACPI: kvv1.FeatureState{},
+			Hyperv: &kvv1.FeatureHyperv{
+				Relaxed: &kvv1.FeatureState{},
+				VAPIC:   &kvv1.FeatureState{},
+			},
+		}
+	}
+	if spec.Network.Headless {
+		vmi.Hostname = vs.Name
+		vmi.Subdomain = HeadlessServiceName(vs)
+	}
+	if spec.MetaData != nil && spec.MetaData.LocalHostname != "" {
+		vmi.Hostname = spec.MetaData.LocalHostname
+	}
+	if vs.IsGpuServer() {
+		for i := uint32(0); i < *spec.Resources.GPU.Count; i++ {
+			vmi.Domain.Devices.GPUs = append(vmi.Domain.Devices.GPUs, kvv1.GPU{
+				Name:       fmt.Sprintf("gpu%d", i),
+				DeviceName: GPUDeviceName,
+			})
+		}
+	}
+
+	addStorage(vs, &vmi)
+	addNetworks(vs, &vmi)
+	if withCloudInit {
+		secretRef := &corev1.LocalObjectReference{Name: CloudInitSecretName(vs)}
+		var source kvv1.VolumeSource
+		if vs.UsesIgnition() {
+			// Fedora CoreOS and Flatcar read the Ignition config from the config drive user data on KubeVirt
+			source.CloudInitConfigDrive = &kvv1.CloudInitConfigDriveSource{UserDataSecretRef: secretRef}
+		} else {
+			source.CloudInitNoCloud = &kvv1.CloudInitNoCloudSource{UserDataSecretRef: secretRef}
+			if spec.NetworkData != "" {
+				source.CloudInitNoCloud.NetworkDataSecretRef = secretRef
+			}
+		}
+		vmi.Domain.Devices.Disks = append(vmi.Domain.Devices.Disks, kvv1.Disk{
+			Name:       CloudInitDiskName,
+			DiskDevice: kvv1.DiskDevice{Disk: &kvv1.DiskTarget{Bus: "virtio"}},
+		})
+		vmi.Volumes = append(vmi.Volumes, kvv1.Volume{
+			Name:         CloudInitDiskName,
+			VolumeSource: source,
+		})
+	}
+
+	if withSysprep {
+		vmi.Domain.Devices.Disks = append(vmi.Domain.Devices.Disks, kvv1.Disk{
+			Name:       SysprepDiskName,
+			DiskDevice: kvv1.DiskDevice{CDRom: &kvv1.CDRomTarget{Bus: "sata"}},
+		})
+		vmi.Volumes = append(vmi.Volumes, kvv1.Volume{
+			Name: SysprepDiskName,
+			VolumeSource: kvv1.VolumeSource{Sysprep: &kvv1.SysprepSource{
+				Secret: &corev1.LocalObjectReference{Name: SysprepSecretName(vs)},
+			}},
+		})
+	}
+
+	return &kvv1.VirtualMachine{
+		TypeMeta:   metav1.TypeMeta{APIVersion: kvv1.GroupVersion.String(), Kind: "VirtualMachine"},
+		ObjectMeta: objectMeta(vs, VirtualMachineName(vs)),
+		Spec: kvv1.VirtualMachineSpec{
+			RunStrategy: &runStrategy,
+			Template: &kvv1.VirtualMachineInstanceTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels(vs)},
+				Spec:       vmi,
+			},
+		},
+	}
+}
+
+// addStorage adds the root filesystem, additional disks, filesystems and swap to the VirtualMachineInstance
+func addStorage(vs *vsv1alpha1.VirtualServer, vmi *kvv1.VirtualMachineInstanceSpec) {
+	storage := vs.Spec.Storage
+	devices := &vmi.Domain.Devices
+
+	bootOrder := uint(1)
+	devices.Disks = append(devices.Disks, kvv1.Disk{
+		Name:       vsv1alpha1.RootDiskName,
+		DiskDevice: kvv1.DiskDevice{Disk: &kvv1.DiskTarget{Bus: "virtio"}},
+		BootOrder:  &bootOrder,
+		Serial:     storage.Root.Serial,
+	})
+	root := kvv1.Volume{Name: vsv1alpha1.RootDiskName}
+	if storage.Root.Ephemeral {
+		root.Ephemeral = &kvv1.EphemeralVolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: storage.Root.Source.PVC.Name},
+		}
+	} else {
+		root.DataVolume = &kvv1.DataVolumeSource{Name: RootDataVolumeName(vs)}
+	}
+	vmi.Volumes = append(vmi.Volumes, root)
+
+	for _, disk := range storage.AdditionalDisks {
+		devices.Disks = append(devices.Disks, kvv1.Disk{
+			Name:       disk.Name,
+			DiskDevice: kvv1.DiskDevice{Disk: &kvv1.DiskTarget{Bus: "virtio", ReadOnly: disk.ReadOnly}},
+			Serial:     disk.Serial,
+		})
+		vmi.Volumes = append(vmi.Volumes, kvv1.Volume{Name: disk.Name, VolumeSource: *disk.Spec.DeepCopy()})
+	}
+
+	for _, fs := range storage.FileSystems {
+		devices.Filesystems = append(devices.Filesystems, kvv1.Filesystem{
+			Name:     fs.Name,
+			Virtiofs: &kvv1.FilesystemVirtiofs{},
+		})
+		vmi.Volumes = append(vmi.Volumes, kvv1.Volume{Name: fs.Name, VolumeSource: *fs.Spec.DeepCopy()})
+	}
+
+	if storage.Swap != nil {
+		devices.Disks = append(devices.Disks, kvv1.Disk{
+			Name:       SwapDiskName,
+			DiskDevice: kvv1.DiskDevice{Disk: &kvv1.DiskTarget{Bus: "virtio"}},
+		})
+		vmi.Volumes = append(vmi.Volumes, kvv1.Volume{
+			Name:         SwapDiskName,
+			VolumeSource: kvv1.VolumeSource{EmptyDisk: &kvv1.EmptyDiskSource{Capacity: storage.Swap.DeepCopy()}},
+		})
+	}
+}
+
+// addNetworks adds the pod network and VPC networks to the VirtualMachineInstance.
+// The MAC address is set on the first interface.
+func addNetworks(vs *vsv1alpha1.VirtualServer, vmi *kvv1.VirtualMachineInstanceSpec) {
+	network := vs.Spec.Network
+	devices := &vmi.Domain.Devices
+
+	if network.DisableK8sNetworking {
+		autoattach := false
+		devices.AutoattachPodInterface = &autoattach
+	} else {
+		devices.Interfaces = append(devices.Interfaces, kvv1.Interface{
+			Name:                   PodNetworkName,
+			InterfaceBindingMethod: kvv1.InterfaceBindingMethod{Masquerade: &kvv1.InterfaceMasquerade{}},
+		})
+		vmi.Networks = append(vmi.Networks, kvv1.Network{
+			Name:          PodNetworkName,
+			NetworkSource: kvv1.NetworkSource{Pod: &kvv1.PodNetwork{}},
+		})
+	}
+
+	for _, vpc := range network.VPCs {
+		devices.Interfaces = append(devices.Interfaces, kvv1.Interface{
+			Name:                   vpc.Name,
+			InterfaceBindingMethod: kvv1.InterfaceBindingMethod{Bridge: &kvv1.InterfaceBridge{}},
+			MacAddress:             vpc.MACAddress,
+		})
+		vmi.Networks = append(vmi.Networks, kvv1.Network{
+			Name:          vpc.Name,
+			NetworkSource: kvv1.NetworkSource{Multus: &kvv1.MultusNetwork{NetworkName: vpc.Name}},
+		})
+	}
+
+	if network.MACAddress != "" && len(devices.Interfaces) > 0 {
+		devices.Interfaces[0].MacAddress = network.MACAddress
+	}
+}
+
+// affinity returns the VirtualServer affinity with the region, GPU and CPU type node requirements added to every node selector term
+func affinity(vs *vsv1alpha1.VirtualServer) *corev1.Affinity {
+	var requirements []corev1.NodeSelectorRequirement
+	if vs.Spec.Region != "" {
+		requirements = append(requirements, nodeSelectorRequirement(RegionNodeLabel, vs.Spec.Region))
+	}
+	if vs.IsGpuServer() {
+		requirements = append(requirements, nodeSelectorRequirement(GPUClassNodeLabel, *vs.Spec.Resources.GPU.Type))
+	} else if vs.Spec.Resources.CPU.Type != nil {
+		requirements = append(requirements, nodeSelectorRequirement(CPUTypeNodeLabel, *vs.Spec.Resources.CPU.Type))
+	}
+
+	aff := vs.Spec.Affinity.DeepCopy()
+	if len(requirements) == 0 {
+		return aff
+	}
+	if aff == nil {
+		aff = &corev1.Affinity{}
+	}
+	if aff.NodeAffinity == nil {
+		aff.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	if aff.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		aff.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	selector := aff.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(selector.NodeSelectorTerms) == 0 {
+		selector.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	for i := range selector.NodeSelectorTerms {
+		term := &selector.NodeSelectorTerms[i]
+		term.MatchExpressions = append(term.MatchExpressions, requirements...)
+	}
+	return aff
+}
+
+func nodeSelectorRequirement(key, value string) corev1.NodeSelectorRequirement {
+	return corev1.NodeSelectorRequirement{
+		Key:      key,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{value},
+	}
+}
@@ -0,0 +1,271 @@
+// Package unattend builds and validates Windows answer files (unattend.xml) for Windows VirtualServers.
+//
+// The answer file is applied by Windows Setup when a generalized (sysprepped) image is first started: the computer
+// name, time zone, product key and Remote Desktop are configured in the specialize pass, and the locale,
+// Administrator password and first logon commands in the oobeSystem pass.
+package unattend
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// MaxComputerNameLength is the maximum length of a NetBIOS computer name
+const MaxComputerNameLength = 15
+
+var (
+	computerNameRegExp = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+	localeRegExp       = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+	productKeyRegExp   = regexp.MustCompile(`^[A-Z0-9]{5}(-[A-Z0-9]{5}){4}$`)
+)
+
+// Config is the personalization of a Windows Virtual Server
+type Config struct {
+	// ComputerName is the NetBIOS name of the computer, up to 15 letters, digits and hyphens.
+	// Windows generates a random name when empty.
+	ComputerName string
+	// TimeZone is the Windows time zone ID, such as Pacific Standard Time
+	TimeZone string
+	// Locale is the language and region, such as en-US, used for the input, system, UI and user locales
+	Locale string
+	// ProductKey is the Windows product key, in XXXXX-XXXXX-XXXXX-XXXXX-XXXXX format
+	ProductKey string
+	// AdministratorPassword is the password of the built-in Administrator account
+	AdministratorPassword string
+	// FirstLogonCommands are run in order the first time a user logs on
+	FirstLogonCommands []string
+	// EnableRDP enables Remote Desktop with network level authentication, and allows it through the firewall
+	EnableRDP bool
+}
+
+// Validate checks the Config. The Administrator password is not included in the errors.
+func (c *Config) Validate(fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if c.ComputerName != "" {
+		p := fldPath.Child("computerName")
+		if len(c.ComputerName) > MaxComputerNameLength {
+			allErrs = append(allErrs, field.TooLong(p, c.ComputerName, MaxComputerNameLength))
+		}
+		if !computerNameRegExp.MatchString(c.ComputerName) || strings.Trim(c.ComputerName, "0123456789") == "" {
+			allErrs = append(allErrs, field.Invalid(p, c.ComputerName, "must consist of letters, digits and hyphens, and may not be only digits"))
+		}
+	}
+	if strings.TrimSpace(c.TimeZone) != c.TimeZone {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeZone"), c.TimeZone, "must be a Windows time zone ID without surrounding spaces, such as Pacific Standard Time"))
+	}
+	if c.Locale != "" && !localeRegExp.MatchString(c.Locale) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("locale"), c.Locale, "must be a language and region, such as en-US"))
+	}
+	if c.ProductKey != "" && !productKeyRegExp.MatchString(c.ProductKey) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("productKey"), "<product key>", "must be in XXXXX-XXXXX-XXXXX-XXXXX-XXXXX format"))
+	}
+	for i, command := range c.FirstLogonCommands {
+		if strings.TrimSpace(command) == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("firstLogonCommands").Index(i), ""))
+		}
+	}
+	return allErrs
+}
+
+// Marshal returns the answer file
+func (c *Config) Marshal() (string, error) {
+	specialize := settings{Pass: "specialize"}
+	shellSetup := &shellSetupSpecialize{
+		componentAttrs: newComponentAttrs("Microsoft-Windows-Shell-Setup"),
+		ComputerName:   c.ComputerName,
+		TimeZone:       c.TimeZone,
+		ProductKey:     c.ProductKey,
+	}
+	if *shellSetup != (shellSetupSpecialize{componentAttrs: shellSetup.componentAttrs}) {
+		specialize.Components = append(specialize.Components, shellSetup)
+	}
+	if c.EnableRDP {
+		specialize.Components = append(specialize.Components,
+			&terminalServices{componentAttrs: newComponentAttrs("Microsoft-Windows-TerminalServices-LocalSessionManager"), DenyTSConnections: false},
+			&rdpAuthentication{componentAttrs: newComponentAttrs("Microsoft-Windows-TerminalServices-RDP-WinStationExtensions"), UserAuthentication: 1},
+			&firewall{
+				componentAttrs: newComponentAttrs("Networking-MPSSVC-Svc"),
+				Groups: []firewallGroup{{
+					Action:   "add",
+					KeyValue: "RemoteDesktop",
+					Active:   true,
+					// The indirect string of the Remote Desktop group, independent of the display language
+					Group:   "@FirewallAPI.dll,-28752",
+					Profile: "all",
+				}},
+			},
+		)
+	}
+
+	oobe := settings{Pass: "oobeSystem"}
+	if c.Locale != "" {
+		oobe.Components = append(oobe.Components, &internationalCore{
+			componentAttrs: newComponentAttrs("Microsoft-Windows-International-Core"),
+			InputLocale:    c.Locale,
+			SystemLocale:   c.Locale,
+			UILanguage:     c.Locale,
+			UserLocale:     c.Locale,
+		})
+	}
+	shellSetupOOBE := &shellSetupOOBE{
+		componentAttrs: newComponentAttrs("Microsoft-Windows-Shell-Setup"),
+		OOBE: oobeSettings{
+			HideEULAPage:              true,
+			HideOEMRegistrationScreen: true,
+			HideOnlineAccountScreens:  true,
+			HideWirelessSetupInOOBE:   true,
+			ProtectYourPC:             3,
+		},
+	}
+	if c.AdministratorPassword != "" {
+		shellSetupOOBE.UserAccounts = &userAccounts{AdministratorPassword: password{
+			Value:     encodePassword(c.AdministratorPassword, "AdministratorPassword"),
+			PlainText: false,
+		}}
+	}
+	for i, command := range c.FirstLogonCommands {
+		shellSetupOOBE.FirstLogonCommands = append(shellSetupOOBE.FirstLogonCommands, synchronousCommand{
+			Action:      "add",
+			Order:       i + 1,
+			CommandLine: command,
+		})
+	}
+	oobe.Components = append(oobe.Components, shellSetupOOBE)
+
+	u := unattend{
+		Xmlns:    "urn:schemas-microsoft-com:unattend",
+		XmlnsWcm: "http://schemas.microsoft.com/WMIConfig/2002/State",
+	}
+	if len(specialize.Components) > 0 {
+		u.Settings = append(u.Settings, specialize)
+	}
+	u.Settings = append(u.Settings, oobe)
+
+	out, err := xml.MarshalIndent(u, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("could not marshal unattend.xml: %w", err)
+	}
+	return xml.Header + string(out) + "\n", nil
+}
+
+// encodePassword obfuscates the password as Windows Setup expects when PlainText is false:
+// the base64 encoded UTF-16LE password followed by the name of the element
+func encodePassword(password, element string) string {
+	codes := utf16.Encode([]rune(password + element))
+	b := make([]byte, 0, 2*len(codes))
+	for _, c := range codes {
+		b = append(b, byte(c), byte(c>>8))
+	}
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+type unattend struct {
+	XMLName  xml.Name   `xml:"unattend"`
+	Xmlns    string     `xml:"xmlns,attr"`
+	XmlnsWcm string     `xml:"xmlns:wcm,attr"`
+	Settings []settings `xml:"settings"`
+}
+
+type settings struct {
+	Pass       string        `xml:"pass,attr"`
+	Components []interface{} `xml:"component"`
+}
+
+// componentAttrs are the attributes identifying a component for 64-bit Windows
+type componentAttrs struct {
+	Name                  string `xml:"name,attr"`
+	ProcessorArchitecture string `xml:"processorArchitecture,attr"`
+	PublicKeyToken        string `xml:"publicKeyToken,attr"`
+	Language              string `xml:"language,attr"`
+	VersionScope          string `xml:"versionScope,attr"`
+}
+
+func newComponentAttrs(name string) componentAttrs {
+	return componentAttrs{
+		Name:                  name,
+		ProcessorArchitecture: "amd64",
+		PublicKeyToken:        "31bf3856ad364e35",
+		Language:              "neutral",
+		VersionScope:          "nonSxS",
+	}
+}
+
+type shellSetupSpecialize struct {
+	XMLName xml.Name `xml:"component"`
+	componentAttrs
+	ComputerName string `xml:"ComputerName,omitempty"`
+	TimeZone     string `xml:"TimeZone,omitempty"`
+	ProductKey   string `xml:"ProductKey,omitempty"`
+}
+
+type terminalServices struct {
+	XMLName xml.Name `xml:"component"`
+	componentAttrs
+	DenyTSConnections bool `xml:"fDenyTSConnections"`
+}
+
+type rdpAuthentication struct {
+	XMLName xml.Name `xml:"component"`
+	componentAttrs
+	UserAuthentication int `xml:"UserAuthentication"`
+}
+
+type firewall struct {
+	XMLName xml.Name `xml:"component"`
+	componentAttrs
+	Groups []firewallGroup `xml:"FirewallGroups>FirewallGroup"`
+}
+
+type firewallGroup struct {
+	Action   string `xml:"wcm:action,attr"`
+	KeyValue string `xml:"wcm:keyValue,attr"`
+	Active   bool   `xml:"Active"`
+	Group    string `xml:"Group"`
+	Profile  string `xml:"Profile"`
+}
+
+type internationalCore struct {
+	XMLName xml.Name `xml:"component"`
+	componentAttrs
+	InputLocale  string `xml:"InputLocale"`
+	SystemLocale string `xml:"SystemLocale"`
+	UILanguage   string `xml:"UILanguage"`
+	UserLocale   string `xml:"UserLocale"`
+}
+
+type shellSetupOOBE struct {
+	XMLName xml.Name `xml:"component"`
+	componentAttrs
+	OOBE               oobeSettings         `xml:"OOBE"`
+	UserAccounts       *userAccounts        `xml:"UserAccounts,omitempty"`
+	FirstLogonCommands []synchronousCommand `xml:"FirstLogonCommands>SynchronousCommand,omitempty"`
+}
+
+type oobeSettings struct {
+	HideEULAPage              bool `xml:"HideEULAPage"`
+	HideOEMRegistrationScreen bool `xml:"HideOEMRegistrationScreen"`
+	HideOnlineAccountScreens  bool `xml:"HideOnlineAccountScreens"`
+	HideWirelessSetupInOOBE   bool `xml:"HideWirelessSetupInOOBE"`
+	ProtectYourPC             int  `xml:"ProtectYourPC"`
+}
+
+type userAccounts struct {
+	AdministratorPassword password `xml:"AdministratorPassword"`
+}
+
+type password struct {
+	Value     string `xml:"Value"`
+	PlainText bool   `xml:"PlainText"`
+}
+
+type synchronousCommand struct {
+	Action      string `xml:"wcm:action,attr"`
+	Order       int    `xml:"Order"`
+	CommandLine string `xml:"CommandLine"`
+}
@@ -0,0 +1,102 @@
+package unattend_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/coreweave/virtual-server/unattend"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestMarshal(t *testing.T) {
+	cfg := &unattend.Config{
+		ComputerName:          "MY-SERVER",
+		TimeZone:              "UTC",
+		AdministratorPassword: "password",
+		FirstLogonCommands:    []string{"cmd /c echo first", "cmd /c echo second"},
+	}
+	got, err := cfg.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<unattend xmlns="urn:schemas-microsoft-com:unattend" xmlns:wcm="http://schemas.microsoft.com/WMIConfig/2002/State">
+  <settings pass="specialize">
+    <component name="Microsoft-Windows-Shell-Setup" processorArchitecture="amd64" publicKeyToken="31bf3856ad364e35" language="neutral" versionScope="nonSxS">
+      <ComputerName>MY-SERVER</ComputerName>
+      <TimeZone>UTC</TimeZone>
+    </component>
+  </settings>
+  <settings pass="oobeSystem">
+    <component name="Microsoft-Windows-Shell-Setup" processorArchitecture="amd64" publicKeyToken="31bf3856ad364e35" language="neutral" versionScope="nonSxS">
+      <OOBE>
+        <HideEULAPage>true</HideEULAPage>
+        <HideOEMRegistrationScreen>true</HideOEMRegistrationScreen>
+        <HideOnlineAccountScreens>true</HideOnlineAccountScreens>
+        <HideWirelessSetupInOOBE>true</HideWirelessSetupInOOBE>
+        <ProtectYourPC>3</ProtectYourPC>
+      </OOBE>
+      <UserAccounts>
+        <AdministratorPassword>
+          <Value>cABhAHMAcwB3AG8AcgBkAEEAZABtAGkAbgBpAHMAdAByAGEAdABvAHIAUABhAHMAcwB3AG8AcgBkAA==</Value>
+          <PlainText>false</PlainText>
+        </AdministratorPassword>
+      </UserAccounts>
+      <FirstLogonCommands>
+        <SynchronousCommand wcm:action="add">
+          <Order>1</Order>
+          <CommandLine>cmd /c echo first</CommandLine>
+        </SynchronousCommand>
+        <SynchronousCommand wcm:action="add">
+          <Order>2</Order>
+          <CommandLine>cmd /c echo second</CommandLine>
+        </SynchronousCommand>
+      </FirstLogonCommands>
+    </component>
+  </settings>
+</unattend>
+`
+	if got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestMarshalEmpty(t *testing.T) {
+	got, err := (&unattend.Config{}).Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(got, `pass="specialize"`) || strings.Contains(got, "UserAccounts") {
+		t.Errorf("expected only the oobeSystem pass, got:\n%s", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  unattend.Config
+		want []string
+	}{
+		{name: "empty"},
+		{name: "valid", cfg: unattend.Config{ComputerName: "WIN-01", TimeZone: "Pacific Standard Time", Locale: "en-US", ProductKey: "AAAAA-BBBBB-CCCCC-DDDDD-EEEEE"}},
+		{name: "long computer name", cfg: unattend.Config{ComputerName: "my-windows-server"}, want: []string{"windows.computerName"}},
+		{name: "numeric computer name", cfg: unattend.Config{ComputerName: "12345"}, want: []string{"windows.computerName"}},
+		{name: "computer name with underscore", cfg: unattend.Config{ComputerName: "MY_SERVER"}, want: []string{"windows.computerName"}},
+		{name: "padded time zone", cfg: unattend.Config{TimeZone: " UTC"}, want: []string{"windows.timeZone"}},
+		{name: "invalid locale", cfg: unattend.Config{Locale: "English"}, want: []string{"windows.locale"}},
+		{name: "invalid product key", cfg: unattend.Config{ProductKey: "AAAAA-BBBBB"}, want: []string{"windows.productKey"}},
+		{name: "empty command", cfg: unattend.Config{FirstLogonCommands: []string{"cmd", ""}}, want: []string{"windows.firstLogonCommands[1]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, err := range tt.cfg.Validate(field.NewPath("windows")) {
+				got = append(got, err.Field)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected errors on %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
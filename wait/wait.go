@@ -0,0 +1,335 @@
+// Package wait blocks until a VirtualServer reaches a desired state, such as ready, started, stopped,
+// having an external IP assigned or having its root disk resized.
+//
+// The VirtualServer is watched when the client supports it, falling back to polling when it does not
+// or when the watch is refused or closed by the API server.
+// Waiting ends when the context is done or the timeout elapses, in which case an *Error reporting the last
+// observed condition reason is returned.
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	vsv1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultPollInterval is the interval between polls when the VirtualServer cannot be watched
+const DefaultPollInterval = 2 * time.Second
+
+var (
+	// ErrFailed is returned when the VirtualServer has a failure reason, such as VirtualMachineNameTaken,
+	// and cannot reach the desired state without a change to its spec
+	ErrFailed = errors.New("VirtualServer failed")
+	// ErrDeleted is returned when the VirtualServer is deleted while waiting
+	ErrDeleted = errors.New("VirtualServer was deleted")
+)
+
+// Error is returned when waiting ends before the VirtualServer reached the desired state.
+// The cause, such as context.DeadlineExceeded or ErrFailed, may be checked with errors.Is.
+type Error struct {
+	// Key identifies the VirtualServer
+	Key client.ObjectKey
+	// Reason is the last observed reason of the VirtualServer, empty if the VirtualServer was never observed
+	Reason vsv1alpha1.VirtualServerConditionReason
+	// Message is the message of the last observed reason
+	Message string
+	// Err is the cause
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("waiting for VirtualServer %s: %v", e.Key, e.Err)
+	}
+	return fmt.Sprintf("waiting for VirtualServer %s: %v, last observed reason %s: %s", e.Key, e.Err, e.Reason, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Predicate returns true when the VirtualServer has reached the desired state.
+// An error stops waiting and is returned as the cause of an *Error.
+type Predicate func(vs *vsv1alpha1.VirtualServer) (bool, error)
+
+// Option configures waiting
+type Option func(*options)
+
+type options struct {
+	timeout      time.Duration
+	pollInterval time.Duration
+}
+
+// WithTimeout limits the time spent waiting, in addition to the context deadline
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithPollInterval sets the interval between polls when the VirtualServer cannot be watched.
+// Defaults to DefaultPollInterval.
+func WithPollInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.pollInterval = interval
+	}
+}
+
+// WaitForCondition waits until the predicate returns true for the VirtualServer, and returns the VirtualServer.
+// The VirtualServer is watched if c is a client.WithWatch, and polled otherwise.
+func WaitForCondition(ctx context.Context, c client.Client, key client.ObjectKey, predicate Predicate, opts ...Option) (*vsv1alpha1.VirtualServer, error) {
+	o := options{pollInterval: DefaultPollInterval}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.timeout)
+		defer cancel()
+	}
+
+	w := &waiter{client: c, key: key, predicate: predicate}
+	if wc, ok := c.(client.WithWatch); ok {
+		if vs, done, err := w.watch(ctx, wc); done {
+			return vs, w.wrap(err)
+		}
+	}
+	vs, err := w.poll(ctx, o.pollInterval)
+	return vs, w.wrap(err)
+}
+
+// WaitForReady waits until the VirtualServer has reconciled its current spec and is ready.
+// Waiting stops with ErrFailed if the VirtualServer has a failure reason.
+func WaitForReady(ctx context.Context, c client.Client, key client.ObjectKey, opts ...Option) (*vsv1alpha1.VirtualServer, error) {
+	return WaitForCondition(ctx, c, key, func(vs *vsv1alpha1.VirtualServer) (bool, error) {
+		if !vs.IsReconciled() {
+			return false, nil
+		}
+		if reason, _ := failureReason(vs); reason != "" {
+			return false, ErrFailed
+		}
+		return apimeta.IsStatusConditionTrue(vs.Status.Conditions, string(vsv1alpha1.VSConditionTypeReady)), nil
+	}, opts...)
+}
+
+// WaitForStarted waits until the VirtualServer has reconciled its current spec and has started.
+// Waiting stops with ErrFailed if the VirtualServer has a failure reason.
+func WaitForStarted(ctx context.Context, c client.Client, key client.ObjectKey, opts ...Option) (*vsv1alpha1.VirtualServer, error) {
+	return WaitForCondition(ctx, c, key, func(vs *vsv1alpha1.VirtualServer) (bool, error) {
+		if !vs.IsReconciled() {
+			return false, nil
+		}
+		if reason, _ := failureReason(vs); reason != "" {
+			return false, ErrFailed
+		}
+		return apimeta.IsStatusConditionTrue(vs.Status.Conditions, string(vsv1alpha1.VSConditionTypeStarted)), nil
+	}, opts...)
+}
+
+// WaitForRestarted waits until the VirtualServer has reconciled its current spec and has started again after since.
+// The VirtualServer has started again once its Started condition is true after being observed false while waiting,
+// or after transitioning to true strictly after since. As condition times have a precision of seconds,
+// a restart within the second of since is only seen through the observed stop.
+// Waiting stops with ErrFailed if the VirtualServer has a failure reason.
+func WaitForRestarted(ctx context.Context, c client.Client, key client.ObjectKey, since time.Time, opts ...Option) (*vsv1alpha1.VirtualServer, error) {
+	stopped := false
+	return WaitForCondition(ctx, c, key, func(vs *vsv1alpha1.VirtualServer) (bool, error) {
+		if !vs.IsReconciled() {
+			return false, nil
+		}
+		if reason, _ := failureReason(vs); reason != "" {
+			return false, ErrFailed
+		}
+		started := apimeta.FindStatusCondition(vs.Status.Conditions, string(vsv1alpha1.VSConditionTypeStarted))
+		if started == nil || started.Status != metav1.ConditionTrue {
+			stopped = true
+			return false, nil
+		}
+		return stopped || started.LastTransitionTime.After(since), nil
+	}, opts...)
+}
+
+// WaitForStopped waits until the VirtualServer has reconciled its current spec and has stopped
+func WaitForStopped(ctx context.Context, c client.Client, key client.ObjectKey, opts ...Option) (*vsv1alpha1.VirtualServer, error) {
+	return WaitForCondition(ctx, c, key, func(vs *vsv1alpha1.VirtualServer) (bool, error) {
+		if !vs.IsReconciled() {
+			return false, nil
+		}
+		return apimeta.IsStatusConditionFalse(vs.Status.Conditions, string(vsv1alpha1.VSConditionTypeStarted)), nil
+	}, opts...)
+}
+
+// WaitForRootDiskResized waits until the VirtualServer has reconciled its current spec and the guest sees the root disk
+// size requested in the spec, such as after ResizeRootDisk.
+// Waiting stops with ErrFailed if the VirtualServer has a failure reason.
+func WaitForRootDiskResized(ctx context.Context, c client.Client, key client.ObjectKey, opts ...Option) (*vsv1alpha1.VirtualServer, error) {
+	return WaitForCondition(ctx, c, key, func(vs *vsv1alpha1.VirtualServer) (bool, error) {
+		if !vs.IsReconciled() {
+			return false, nil
+		}
+		if reason, _ := failureReason(vs); reason != "" {
+			return false, ErrFailed
+		}
+		return vs.IsRootDiskResized(), nil
+	}, opts...)
+}
+
+// WaitForExternalIP waits until an external IP is assigned to the VirtualServer, and returns the IP.
+// Waiting stops with ErrFailed if the VirtualServer has a failure reason.
+func WaitForExternalIP(ctx context.Context, c client.Client, key client.ObjectKey, opts ...Option) (string, error) {
+	vs, err := WaitForCondition(ctx, c, key, func(vs *vsv1alpha1.VirtualServer) (bool, error) {
+		if vs.Status.ExternalIP() != "" {
+			return true, nil
+		}
+		if reason, _ := failureReason(vs); reason != "" && vs.IsReconciled() {
+			return false, ErrFailed
+		}
+		return false, nil
+	}, opts...)
+	if err != nil {
+		return "", err
+	}
+	return vs.Status.ExternalIP(), nil
+}
+
+// failureReason returns the first failure reason of the VirtualServer conditions, and its message
+func failureReason(vs *vsv1alpha1.VirtualServer) (vsv1alpha1.VirtualServerConditionReason, string) {
+	for _, condition := range vs.Status.Conditions {
+		reason := vsv1alpha1.VirtualServerConditionReason(condition.Reason)
+		if condition.Status == metav1.ConditionFalse && vsv1alpha1.IsFailureReason(reason) {
+			return reason, condition.Message
+		}
+	}
+	return "", ""
+}
+
+type waiter struct {
+	client    client.Client
+	key       client.ObjectKey
+	predicate Predicate
+	// last is the last observed VirtualServer
+	last *vsv1alpha1.VirtualServer
+}
+
+// observe records and evaluates the VirtualServer
+func (w *waiter) observe(vs *vsv1alpha1.VirtualServer) (bool, error) {
+	w.last = vs
+	return w.predicate(vs)
+}
+
+func (w *waiter) get(ctx context.Context) (*vsv1alpha1.VirtualServer, error) {
+	vs := &vsv1alpha1.VirtualServer{}
+	if err := w.client.Get(ctx, w.key, vs); err != nil {
+		if apierrors.IsNotFound(err) && w.last != nil {
+			return nil, ErrDeleted
+		}
+		return nil, err
+	}
+	return vs, nil
+}
+
+// watch waits using a watch on the VirtualServer.
+// done is false if the watch could not be established or was closed, in which case waiting should continue by polling.
+func (w *waiter) watch(ctx context.Context, c client.WithWatch) (vs *vsv1alpha1.VirtualServer, done bool, err error) {
+	// Get the VirtualServer first, so that the state before the watch starts is not missed
+	vs, err = w.get(ctx)
+	if err != nil {
+		return nil, true, err
+	}
+	if ok, err := w.observe(vs); ok || err != nil {
+		return vs, true, err
+	}
+
+	watcher, err := c.Watch(ctx, &vsv1alpha1.VirtualServerList{}, &client.ListOptions{
+		Namespace:     w.key.Namespace,
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", w.key.Name),
+		Raw:           &metav1.ListOptions{ResourceVersion: vs.ResourceVersion},
+	})
+	if err != nil {
+		return nil, false, nil
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, true, ctx.Err()
+		case event, open := <-watcher.ResultChan():
+			if !open {
+				return nil, false, nil
+			}
+			switch event.Type {
+			case watch.Error:
+				return nil, false, nil
+			case watch.Deleted:
+				if isKey(event.Object, w.key) {
+					return nil, true, ErrDeleted
+				}
+				continue
+			}
+			if !isKey(event.Object, w.key) {
+				continue
+			}
+			vs, ok := event.Object.(*vsv1alpha1.VirtualServer)
+			if !ok {
+				if vs, err = w.get(ctx); err != nil {
+					return nil, true, err
+				}
+			}
+			if ok, err := w.observe(vs); ok || err != nil {
+				return vs, true, err
+			}
+		}
+	}
+}
+
+// poll waits by getting the VirtualServer every interval
+func (w *waiter) poll(ctx context.Context, interval time.Duration) (*vsv1alpha1.VirtualServer, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		vs, err := w.get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if ok, err := w.observe(vs); ok || err != nil {
+			return vs, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// wrap returns an *Error reporting the last observed reason, or nil if err is nil
+func (w *waiter) wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+	e := &Error{Key: w.key, Err: err}
+	if w.last != nil {
+		if reason, message := failureReason(w.last); reason != "" {
+			e.Reason, e.Message = reason, message
+		} else if ready := apimeta.FindStatusCondition(w.last.Status.Conditions, string(vsv1alpha1.VSConditionTypeReady)); ready != nil {
+			e.Reason, e.Message = vsv1alpha1.VirtualServerConditionReason(ready.Reason), ready.Message
+		}
+	}
+	return e
+}
+
+func isKey(obj interface{}, key client.ObjectKey) bool {
+	o, ok := obj.(client.Object)
+	return ok && o.GetNamespace() == key.Namespace && o.GetName() == key.Name
+}
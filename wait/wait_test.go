@@ -0,0 +1,182 @@
+package wait_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	vsv1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	"github.com/coreweave/virtual-server/wait"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var key = client.ObjectKey{Namespace: "default", Name: "my-virtual-server"}
+
+// pollingClient hides the Watch method of the client, so that waiting falls back to polling
+type pollingClient struct {
+	client.Client
+}
+
+func newClient(t *testing.T) client.WithWatch {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := vsv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	vs := vsv1alpha1.NewVirtualServer(key.Name, key.Namespace)
+	vs.InitializeStatus()
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(vs).Build()
+}
+
+// setConditions updates the status of the VirtualServer after a delay
+func setConditions(t *testing.T, c client.Client, delay time.Duration, update func(vs *vsv1alpha1.VirtualServer) error) {
+	t.Helper()
+	go func() {
+		time.Sleep(delay)
+		vs := &vsv1alpha1.VirtualServer{}
+		if err := c.Get(context.Background(), key, vs); err != nil {
+			t.Errorf("could not get VirtualServer: %v", err)
+			return
+		}
+		if err := update(vs); err != nil {
+			t.Errorf("could not update conditions: %v", err)
+			return
+		}
+		if err := c.Update(context.Background(), vs); err != nil {
+			t.Errorf("could not update VirtualServer: %v", err)
+		}
+	}()
+}
+
+func markReady(vs *vsv1alpha1.VirtualServer) error {
+	for _, s := range []struct {
+		conditionType vsv1alpha1.VirtualServerConditionType
+		reason        vsv1alpha1.VirtualServerConditionReason
+	}{
+		{vsv1alpha1.VSConditionTypeSecretReady, vsv1alpha1.VSConditionReasonSecretCreated},
+		{vsv1alpha1.VSConditionTypeServicesReady, vsv1alpha1.VSConditionReasonServicesReady},
+		{vsv1alpha1.VSConditionTypeVMReady, vsv1alpha1.VSConditionReasonVMReady},
+		{vsv1alpha1.VSConditionTypeStarted, vsv1alpha1.VSConditionReasonStarted},
+	} {
+		vs.SetCondition(s.conditionType, metav1.ConditionTrue, s.reason, nil, true)
+	}
+	externalIP := "203.0.113.1"
+	vs.Status.Network.ExternalIP = &externalIP
+	return nil
+}
+
+func TestWaitForReady(t *testing.T) {
+	for name, c := range map[string]func(client.WithWatch) client.Client{
+		"watch": func(c client.WithWatch) client.Client { return c },
+		"poll":  func(c client.WithWatch) client.Client { return pollingClient{c} },
+	} {
+		t.Run(name, func(t *testing.T) {
+			fakeClient := newClient(t)
+			setConditions(t, fakeClient, 50*time.Millisecond, markReady)
+
+			vs, err := wait.WaitForReady(context.Background(), c(fakeClient), key, wait.WithTimeout(5*time.Second), wait.WithPollInterval(10*time.Millisecond))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if vs.Status.Phase != vsv1alpha1.VirtualServerPhaseRunning {
+				t.Errorf("expected phase %s, got %s", vsv1alpha1.VirtualServerPhaseRunning, vs.Status.Phase)
+			}
+		})
+	}
+}
+
+func TestWaitForExternalIP(t *testing.T) {
+	c := newClient(t)
+	setConditions(t, c, 50*time.Millisecond, markReady)
+
+	ip, err := wait.WaitForExternalIP(context.Background(), c, key, wait.WithTimeout(5*time.Second))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ip != "203.0.113.1" {
+		t.Errorf("expected external IP 203.0.113.1, got %q", ip)
+	}
+}
+
+func TestWaitForStopped(t *testing.T) {
+	c := newClient(t)
+	setConditions(t, c, 50*time.Millisecond, func(vs *vsv1alpha1.VirtualServer) error {
+		if err := markReady(vs); err != nil {
+			return err
+		}
+		vs.SetCondition(vsv1alpha1.VSConditionTypeStarted, metav1.ConditionFalse, vsv1alpha1.VSConditionReasonStopped, nil, true)
+		return nil
+	})
+
+	if _, err := wait.WaitForStopped(context.Background(), c, key, wait.WithTimeout(5*time.Second)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWaitForStartedFailed(t *testing.T) {
+	c := newClient(t)
+	message := "VirtualMachine my-virtual-server is owned by another VirtualServer"
+	setConditions(t, c, 50*time.Millisecond, func(vs *vsv1alpha1.VirtualServer) error {
+		vs.SetCondition(vsv1alpha1.VSConditionTypeVMReady, metav1.ConditionFalse, vsv1alpha1.VSConditionReasonVMNameTaken, &message, true)
+		return nil
+	})
+
+	_, err := wait.WaitForStarted(context.Background(), c, key, wait.WithTimeout(5*time.Second))
+	if !errors.Is(err, wait.ErrFailed) {
+		t.Fatalf("expected %v, got %v", wait.ErrFailed, err)
+	}
+	var waitErr *wait.Error
+	if !errors.As(err, &waitErr) {
+		t.Fatalf("expected a *wait.Error, got %T", err)
+	}
+	if waitErr.Reason != vsv1alpha1.VSConditionReasonVMNameTaken || waitErr.Message != message {
+		t.Errorf("expected the last reason %s: %s, got %s: %s", vsv1alpha1.VSConditionReasonVMNameTaken, message, waitErr.Reason, waitErr.Message)
+	}
+}
+
+func TestWaitForConditionTimeout(t *testing.T) {
+	c := newClient(t)
+	_, err := wait.WaitForCondition(context.Background(), c, key, func(vs *vsv1alpha1.VirtualServer) (bool, error) {
+		return false, nil
+	}, wait.WithTimeout(50*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	var waitErr *wait.Error
+	if !errors.As(err, &waitErr) || waitErr.Reason != vsv1alpha1.VSConditionReasonInitializing {
+		t.Errorf("expected the last reason %s, got %v", vsv1alpha1.VSConditionReasonInitializing, err)
+	}
+}
+
+func TestWaitForConditionCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := wait.WaitForReady(ctx, pollingClient{newClient(t)}, key)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestWaitForRootDiskResized(t *testing.T) {
+	c := newClient(t)
+	setConditions(t, c, 50*time.Millisecond, func(vs *vsv1alpha1.VirtualServer) error {
+		vs.SetRootDiskStatus(vs.Spec.Storage.Root.Size, true, nil)
+		return nil
+	})
+	setConditions(t, c, 100*time.Millisecond, func(vs *vsv1alpha1.VirtualServer) error {
+		vs.SetRootDiskStatus(vs.Spec.Storage.Root.Size, false, &vs.Spec.Storage.Root.Size)
+		return nil
+	})
+
+	vs, err := wait.WaitForRootDiskResized(context.Background(), c, key, wait.WithTimeout(5*time.Second), wait.WithPollInterval(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if vs.Status.RootDisk.ResizeState != vsv1alpha1.VirtualServerRootDiskResizeStateComplete {
+		t.Errorf("expected resize state %s, got %s", vsv1alpha1.VirtualServerRootDiskResizeStateComplete, vs.Status.RootDisk.ResizeState)
+	}
+}
@@ -0,0 +1,102 @@
+// Package webhook serves the VirtualServer mutating and validating admission webhooks,
+// and the conversion webhook between the v1alpha1 and v1beta1 VirtualServer versions.
+//
+// The webhooks may be registered with a controller-runtime manager through
+// (*v1alpha1.VirtualServer).SetupWebhookWithManager, or run without a manager using NewServer or NewHandler.
+// The conversion webhook must be configured on the CRD with the Webhook conversion strategy and ConvertPath.
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+
+	vsv1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	vsv1beta1 "github.com/coreweave/virtual-server/api/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+)
+
+const (
+	// MutatePath is the path the VirtualServer mutating webhook is served on
+	MutatePath = "/mutate-virtualservers-coreweave-com-v1alpha1-virtualserver"
+	// ValidatePath is the path the VirtualServer validating webhook is served on
+	ValidatePath = "/validate-virtualservers-coreweave-com-v1alpha1-virtualserver"
+	// ConvertPath is the path the VirtualServer conversion webhook is served on
+	ConvertPath = "/convert"
+)
+
+// Options configures the webhook Server
+type Options struct {
+	// Host is the address the server listens on.
+	// Defaults to all addresses
+	Host string
+	// Port is the port the server listens on.
+	// Defaults to 9443
+	Port int
+	// CertDir is the directory containing tls.crt and tls.key.
+	// Defaults to <temp-dir>/k8s-webhook-server/serving-certs
+	CertDir string
+}
+
+// NewScheme returns a scheme with the client-go and all VirtualServer versions registered
+func NewScheme() (*runtime.Scheme, error) {
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		vsv1alpha1.AddToScheme,
+		vsv1beta1.AddToScheme,
+	} {
+		if err := addToScheme(scheme); err != nil {
+			return nil, err
+		}
+	}
+	return scheme, nil
+}
+
+// NewServer returns a webhook server with the VirtualServer webhooks registered.
+// The server may be run without a manager using StartStandalone with the scheme returned by NewScheme.
+func NewServer(opts Options) *ctrlwebhook.Server {
+	server := &ctrlwebhook.Server{
+		Host:    opts.Host,
+		Port:    opts.Port,
+		CertDir: opts.CertDir,
+	}
+	server.Register(MutatePath, admission.DefaultingWebhookFor(&vsv1alpha1.VirtualServer{}))
+	server.Register(ValidatePath, admission.ValidatingWebhookFor(&vsv1alpha1.VirtualServer{}))
+	server.Register(ConvertPath, &conversion.Webhook{})
+	return server
+}
+
+// NewHandler returns an http.Handler serving the VirtualServer webhooks on MutatePath, ValidatePath and ConvertPath.
+// If scheme is nil, the scheme returned by NewScheme is used.
+// TLS is not terminated by the handler, which makes it suitable for mounting on an existing server or for tests.
+func NewHandler(scheme *runtime.Scheme) (http.Handler, error) {
+	if scheme == nil {
+		var err error
+		if scheme, err = NewScheme(); err != nil {
+			return nil, err
+		}
+	}
+
+	mux := http.NewServeMux()
+	for path, hook := range map[string]*admission.Webhook{
+		MutatePath:   admission.DefaultingWebhookFor(&vsv1alpha1.VirtualServer{}),
+		ValidatePath: admission.ValidatingWebhookFor(&vsv1alpha1.VirtualServer{}),
+	} {
+		handler, err := admission.StandaloneWebhook(hook, admission.StandaloneOptions{Scheme: scheme})
+		if err != nil {
+			return nil, fmt.Errorf("could not create webhook for %s: %w", path, err)
+		}
+		mux.Handle(path, handler)
+	}
+
+	convert := &conversion.Webhook{}
+	if err := convert.InjectScheme(scheme); err != nil {
+		return nil, fmt.Errorf("could not create conversion webhook: %w", err)
+	}
+	mux.Handle(ConvertPath, convert)
+	return mux, nil
+}
@@ -0,0 +1,193 @@
+package webhook_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	vsv1alpha1 "github.com/coreweave/virtual-server/api/v1alpha1"
+	vsv1beta1 "github.com/coreweave/virtual-server/api/v1beta1"
+	"github.com/coreweave/virtual-server/webhook"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+func newVirtualServer() *vsv1alpha1.VirtualServer {
+	vs := vsv1alpha1.NewVirtualServer("my-virtual-server", "default")
+	vs.APIVersion = vsv1alpha1.GroupVersion.String()
+	vs.Kind = "VirtualServer"
+	vs.SetOS(vsv1alpha1.VirtualServerOSTypeLinux)
+	vs.SetGPUType("Quadro_RTX_4000")
+	vs.ConfigureStorageRootWithPVCSource(vsv1alpha1.VirtualServerStorageRootPVCSource{
+		Size:             "40Gi",
+		PVCName:          "ubuntu2004-docker-master-20210601-ord1",
+		PVCNamespace:     "vd-images",
+		StorageClassName: "block-nvme-ord1",
+		VolumeMode:       corev1.PersistentVolumeBlock,
+		AccessMode:       corev1.ReadWriteOnce,
+	})
+	return vs
+}
+
+// review posts an AdmissionReview for vs to path and returns the response
+func review(t *testing.T, server *httptest.Server, path string, vs *vsv1alpha1.VirtualServer) *admissionv1.AdmissionResponse {
+	t.Helper()
+	raw, err := json.Marshal(vs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := json.Marshal(admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: admissionv1.SchemeGroupVersion.String(), Kind: "AdmissionReview"},
+		Request: &admissionv1.AdmissionRequest{
+			UID:       types.UID("test"),
+			Kind:      metav1.GroupVersionKind{Group: vsv1alpha1.GroupVersion.Group, Version: vsv1alpha1.GroupVersion.Version, Kind: "VirtualServer"},
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.Post(server.URL+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	out := admissionv1.AdmissionReview{}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Response == nil {
+		t.Fatal("admission review has no response")
+	}
+	return out.Response
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	handler, err := webhook.NewHandler(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestValidatingWebhook(t *testing.T) {
+	server := newTestServer(t)
+
+	if resp := review(t, server, webhook.ValidatePath, newVirtualServer()); !resp.Allowed {
+		t.Errorf("expected valid VirtualServer to be allowed, got %v", resp.Result)
+	}
+
+	vs := newVirtualServer()
+	vs.ExposeTCPPort(22)
+	vs.DirectAttachLoadBalancerIP(true)
+	resp := review(t, server, webhook.ValidatePath, vs)
+	if resp.Allowed {
+		t.Fatal("expected VirtualServer with ports and directAttachLoadBalancerIP to be denied")
+	}
+	if !strings.Contains(resp.Result.Message, "spec.network.tcp.ports") {
+		t.Errorf("expected denial to reference spec.network.tcp.ports, got %q", resp.Result.Message)
+	}
+}
+
+func TestMutatingWebhook(t *testing.T) {
+	server := newTestServer(t)
+
+	resp := review(t, server, webhook.MutatePath, newVirtualServer())
+	if !resp.Allowed {
+		t.Fatalf("expected mutation to be allowed, got %v", resp.Result)
+	}
+
+	patches := []map[string]interface{}{}
+	if err := json.Unmarshal(resp.Patch, &patches); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range patches {
+		if p["path"] == "/spec/resources/gpu/count" && p["value"] == float64(1) {
+			return
+		}
+	}
+	t.Errorf("expected gpu count to be defaulted to 1, got patches %s", resp.Patch)
+}
+
+func TestConversionWebhook(t *testing.T) {
+	server := newTestServer(t)
+
+	vs := newVirtualServer()
+	vs.AddUser(vsv1alpha1.VirtualServerUser{Username: "myuser", SSHPublicKey: "ssh-ed25519 AAAA one\nssh-ed25519 AAAA two"})
+	raw, err := json.Marshal(vs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := json.Marshal(apixv1.ConversionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: apixv1.SchemeGroupVersion.String(), Kind: "ConversionReview"},
+		Request: &apixv1.ConversionRequest{
+			UID:               types.UID("test"),
+			DesiredAPIVersion: vsv1beta1.GroupVersion.String(),
+			Objects:           []runtime.RawExtension{{Raw: raw}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.Post(server.URL+webhook.ConvertPath, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	out := apixv1.ConversionReview{}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Response == nil || out.Response.Result.Status != metav1.StatusSuccess {
+		t.Fatalf("expected conversion to succeed, got %+v", out.Response)
+	}
+
+	converted := &vsv1beta1.VirtualServer{}
+	if err := json.Unmarshal(out.Response.ConvertedObjects[0].Raw, converted); err != nil {
+		t.Fatal(err)
+	}
+	if converted.APIVersion != vsv1beta1.GroupVersion.String() {
+		t.Errorf("expected apiVersion %s, got %s", vsv1beta1.GroupVersion, converted.APIVersion)
+	}
+	if converted.Spec.Resources.GPU == nil || converted.Spec.Resources.GPU.Type != "Quadro_RTX_4000" {
+		t.Errorf("expected gpu type Quadro_RTX_4000, got %+v", converted.Spec.Resources.GPU)
+	}
+	if keys := converted.Spec.Users[0].SSHPublicKeys; len(keys) != 2 {
+		t.Errorf("expected 2 ssh public keys, got %q", keys)
+	}
+}
+
+func TestCRDConversionWebhook(t *testing.T) {
+	data, err := os.ReadFile("../crd/virtualservers.coreweave.com_virtualservers.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	crd := apixv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(data, &crd); err != nil {
+		t.Fatal(err)
+	}
+	conv := crd.Spec.Conversion
+	if conv == nil || conv.Strategy != apixv1.WebhookConverter || conv.Webhook == nil || conv.Webhook.ClientConfig == nil ||
+		conv.Webhook.ClientConfig.Service == nil || conv.Webhook.ClientConfig.Service.Path == nil {
+		t.Fatalf("expected the CRD to use the Webhook conversion strategy, got %+v", conv)
+	}
+	if path := *conv.Webhook.ClientConfig.Service.Path; path != webhook.ConvertPath {
+		t.Errorf("expected conversion webhook path %s, got %s", webhook.ConvertPath, path)
+	}
+}